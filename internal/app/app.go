@@ -6,12 +6,18 @@ import (
 	"bank-app-backend/internal/controllers/http"
 	"bank-app-backend/internal/controllers/middleware"
 	"bank-app-backend/internal/db"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/lib/cache"
 	"bank-app-backend/internal/lib/kafka"
 	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/lib/notify"
+	"bank-app-backend/internal/lib/password"
 	redis "bank-app-backend/internal/lib/redis"
+	token "bank-app-backend/internal/lib/token"
 	"bank-app-backend/internal/repository"
 	"bank-app-backend/internal/server"
 	"bank-app-backend/internal/services"
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -20,6 +26,7 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"log"
+	"strings"
 )
 
 var (
@@ -52,68 +59,278 @@ func Run() {
 
 	loggerZap.Debug("debug messages are enabled")
 
-	kafkaProdAccountCreated, err := kafka.NewProducer("localhost:9092", "account.created")
+	kafkaBrokers := strings.Join(cfg.Kafka.Brokers, ",")
+
+	kafkaProdAccountCreated, err := kafka.NewProducer(kafkaBrokers, entities.TopicAccountCreated)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	kafkaProdTransactionCompleted, err := kafka.NewProducer("localhost:9092", "transaction.completed")
+	kafkaProdTransactionCompleted, err := kafka.NewProducer(kafkaBrokers, entities.TopicTransactionCompleted)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	kafkaProdAccountOverdrawn, err := kafka.NewProducer(kafkaBrokers, entities.TopicAccountOverdrawn)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	r := gin.Default()
+	if err := r.SetTrustedProxies(cfg.HTTPServer.TrustedProxies); err != nil {
+		loggerZap.Fatal("Invalid trusted proxies", zap.Error(err))
+	}
+	r.Use(middleware.RequestIDMiddleware())
 	r.Use(middleware.ZapLoggerMiddleware())
 	r.Use(middleware.PrometheusMiddleware(requestCount))
 
+	// Кеш
+	cacheBus := cache.NewBus(redisClient, cfg.Cache.InvalidationChannel)
+	go cacheBus.Run(context.Background())
+
+	usersCache := cache.New[entities.User](cacheBus, "user", cache.Options{
+		TTL:      cfg.Cache.Users.TTL,
+		LocalTTL: cfg.Cache.Users.LocalTTL,
+	})
+	accountsCache := cache.New[entities.Account](cacheBus, "account", cache.Options{
+		TTL:      cfg.Cache.Accounts.TTL,
+		LocalTTL: cfg.Cache.Accounts.LocalTTL,
+	})
+
 	// Репозитории
-	authRepo := repository.NewUsersRepository(database, redisClient)
-	usersRepo := repository.NewUsersRepository(database, redisClient)
-	accountsRepo := repository.NewAccountsRepository(database)
+	authRepo := repository.NewUsersRepository(database, usersCache)
+	usersRepo := repository.NewUsersRepository(database, usersCache)
+	accountsRepo := repository.NewAccountsRepository(database, accountsCache)
 	transactionRepo := repository.NewTransactionsRepository(database)
+	loginAttemptsRepo := repository.NewLoginAttemptsRepository(redisClient)
+	sessionsRepo := repository.NewSessionsRepository(redisClient)
+	apiKeysRepo := repository.NewAPIKeysRepository(database)
+	kycRepo := repository.NewKYCRepository(database)
+	erasureRepo := repository.NewErasureRequestsRepository(database)
+	auditRepo := repository.NewAuditRepository(database)
+	notificationsRepo := repository.NewNotificationsRepository(database)
+	payeesRepo := repository.NewPayeesRepository(database)
+	accountMembersRepo := repository.NewAccountMembersRepository(database)
+	limitsRepo := repository.NewLimitsRepository(database)
+	transferReviewsRepo := repository.NewTransferReviewsRepository(database)
+	interestRepo := repository.NewInterestRepository(database)
+	balancesRepo := repository.NewBalancesRepository(database)
+	holdsRepo := repository.NewHoldsRepository(database)
+	jobRunsRepo := repository.NewJobRunsRepository(database)
+	feesRepo := repository.NewFeesRepository(database)
+	potsRepo := repository.NewPotsRepository(database)
+	cardsRepo := repository.NewCardsRepository(database)
+	loansRepo := repository.NewLoansRepository(database)
 
 	// Сервисы
-	authorizationService := services.NewAuthService(authRepo, redisClient)
-	usersService := services.NewUsersService(usersRepo)
-	accountsService := services.NewAccountsService(accountsRepo, transactionRepo, kafkaProdAccountCreated)
-	transactionService := services.NewTransactionService(transactionRepo)
-	transferService := services.NewTransfersService(transactionRepo, accountsRepo, kafkaProdTransactionCompleted)
+	passwordPolicy := password.Policy{
+		MinLength:      cfg.Password.MinLength,
+		MaxLength:      cfg.Password.MaxLength,
+		RequireUpper:   cfg.Password.RequireUpper,
+		RequireLower:   cfg.Password.RequireLower,
+		RequireDigit:   cfg.Password.RequireDigit,
+		RequireSpecial: cfg.Password.RequireSpecial,
+		RejectCommon:   cfg.Password.RejectCommon,
+	}
+
+	auditService := services.NewAuditService(auditRepo, cfg.Audit.Secret)
+	loginProtectionService := services.NewLoginProtectionService(loginAttemptsRepo, cfg.Login, auditService)
+	authorizationService := services.NewAuthService(authRepo, sessionsRepo, redisClient, passwordPolicy, loginProtectionService, auditService)
+	sessionsService := services.NewSessionsService(sessionsRepo)
+	apiKeysService := services.NewAPIKeysService(apiKeysRepo, usersRepo, auditService)
+	privacyService := services.NewPrivacyService(usersRepo, kycRepo, accountsRepo, accountMembersRepo, transactionRepo, sessionsRepo, apiKeysRepo, erasureRepo, notificationsRepo, payeesRepo, holdsRepo, cardsRepo, loansRepo, auditService)
+	usersService := services.NewUsersService(usersRepo, accountsRepo, kycRepo, sessionsRepo, auditService)
+	kycService := services.NewKYCService(kycRepo, cfg.KYC, auditService)
+	accountMembersService := services.NewAccountMembersService(accountMembersRepo, accountsRepo, usersRepo, cfg.Accounts.InvitationTTL, auditService)
+	limitsService := services.NewLimitsService(limitsRepo, kycService, accountMembersService, auditService)
+	currenciesService := services.NewCurrenciesService(cfg.Currencies.Enabled)
+	potsService := services.NewPotsService(potsRepo, accountsRepo, accountMembersService, currenciesService, cfg.Jobs, jobRunsRepo, auditService)
+	feesService := services.NewFeesService(feesRepo, accountsRepo, accountMembersService, cfg.Fees.Accounts, auditService)
+	accountsService := services.NewAccountsService(accountsRepo, holdsRepo, cardsRepo, loansRepo, accountMembersService, limitsService, feesService, potsService, currenciesService, kycService, kafkaProdAccountCreated, kafkaProdTransactionCompleted, kafkaProdAccountOverdrawn, auditService)
+	transactionService := services.NewTransactionService(transactionRepo, accountsRepo)
+	notificationsService := services.NewNotificationsService(notificationsRepo, usersRepo, setupNotificationSenders(cfg.Notify), notificationChannels(cfg.Notify.DefaultChannels))
+	fraudService := services.NewFraudService(transactionRepo, cfg.Fraud)
+	payeesService := services.NewPayeesService(payeesRepo, accountsRepo, cfg.Payees.CoolingOff, auditService)
+	holdsService := services.NewHoldsService(holdsRepo, accountsRepo, accountMembersService, limitsService, feesService, potsService, currenciesService, cfg.Holds, kafkaProdAccountOverdrawn, auditService)
+	accountStatusService := services.NewAccountStatusService(accountsRepo, cfg.Accounts.DormantAfter, cfg.Jobs.Interval, cfg.Jobs.Timezone, jobRunsRepo, auditService)
+	balancesService := services.NewBalancesService(balancesRepo, accountMembersService, cfg.Jobs, jobRunsRepo, auditService)
+	cardsService := services.NewCardsService(cardsRepo, repository.NewCardAttemptsRepository(redisClient), accountsRepo, accountMembersService, holdsService, cfg.Cards, cfg.Jobs.Timezone, auditService)
+	loansService := services.NewLoansService(loansRepo, accountsRepo, accountMembersService, currenciesService, cfg.Loans, cfg.Jobs, jobRunsRepo, auditService)
+	interestService := services.NewInterestService(interestRepo, accountsRepo, accountMembersService, cfg.Products, cfg.Overdraft, cfg.Jobs, jobRunsRepo, auditService)
+	transferService := services.NewTransfersService(transactionRepo, accountsRepo, accountMembersService, limitsService, feesService, potsService, currenciesService, fraudService, transferReviewsRepo, kycService, payeesService, kafkaProdTransactionCompleted, kafkaProdAccountOverdrawn, auditService)
 
 	// Хендлеры
 	authHandlers := http.NewAuthHandler(authorizationService)
 	usersHandlers := http.NewUsersHandler(usersService)
 	accountsHandlers := http.NewAccountsHandler(accountsService)
 	transferHandlers := http.NewTransactionsHandler(transferService, transactionService)
+	sessionsHandlers := http.NewSessionsHandler(sessionsService)
+	apiKeysHandlers := http.NewAPIKeysHandler(apiKeysService)
+	kycHandlers := http.NewKYCHandler(kycService)
+	privacyHandlers := http.NewPrivacyHandler(privacyService)
+	adminHandlers := http.NewAdminHandler(loginProtectionService)
+	auditHandlers := http.NewAuditHandler(auditService)
+	notificationsHandlers := http.NewNotificationsHandler(notificationsService)
+	payeesHandlers := http.NewPayeesHandler(payeesService)
+	accountMembersHandlers := http.NewAccountMembersHandler(accountMembersService)
+	limitsHandlers := http.NewLimitsHandler(limitsService)
+	transferReviewsHandlers := http.NewTransferReviewsHandler(transferService)
+	interestHandlers := http.NewInterestHandler(interestService)
+	balancesHandlers := http.NewBalancesHandler(balancesService)
+	accountStatusHandlers := http.NewAccountStatusHandler(accountStatusService)
+	holdsHandlers := http.NewHoldsHandler(holdsService)
+	cardsHandlers := http.NewCardsHandler(cardsService)
+	loansHandlers := http.NewLoansHandler(loansService)
+	feesHandlers := http.NewFeesHandler(feesService)
+	potsHandlers := http.NewPotsHandler(potsService)
+	currenciesHandlers := http.NewCurrenciesHandler(currenciesService)
+
+	if err := feesService.CheckAccounts(context.Background()); err != nil {
+		loggerZap.Fatal("Invalid fee accounts", zap.Error(err))
+	}
+
+	if err := usersService.EnsureAdmins(context.Background(), cfg.RBAC.BootstrapAdmins); err != nil {
+		loggerZap.Error("Failed to bootstrap admins", zap.Error(err))
+	}
+
+	go func() {
+		topics := []string{entities.TopicAccountCreated, entities.TopicTransactionCompleted, entities.TopicAccountOverdrawn}
+		if err := kafka.RunConsumer(context.Background(), kafkaBrokers, topics, cfg.Kafka.GroupID, notificationsService.HandleEvent); err != nil {
+			loggerZap.Error("Kafka consumer stopped", zap.Error(err))
+		}
+	}()
+
+	go interestService.Run(context.Background())
+	go balancesService.Run(context.Background())
+	go accountStatusService.Run(context.Background())
+	go holdsService.Run(context.Background())
+	go potsService.Run(context.Background())
+	go loansService.Run(context.Background())
+
+	jwtAuth := middleware.JWTAuthMiddleware(token.AccessSecret, usersService, sessionsService)
 
 	auth := r.Group("/auth")
-	auth.Use(middleware.JWTAuthMiddleware([]byte("jwt_access_secret")))
+	auth.Use(middleware.APIKeyAuthMiddleware(apiKeysService, usersService, jwtAuth))
 
 	{
-		auth.GET("/me", usersHandlers.Me)
-		auth.GET("/accounts", accountsHandlers.GetAllByUser)
-		auth.POST("/accounts", accountsHandlers.Create)
-		auth.POST("/accounts/deposit", accountsHandlers.Deposit)
-		auth.GET("/accounts/:id", accountsHandlers.GetByID)
-		auth.PATCH("/accounts/:id", accountsHandlers.CloseAccount)
-		auth.GET("/transactions", transferHandlers.GetTransactions)
-		auth.POST("/transfers/internal", transferHandlers.InternalTransfer)
-		auth.POST("/transfers/external", transferHandlers.ExternalTransfer)
-		auth.GET("/transactions/:id", transferHandlers.GetTransactionById)
+		auth.GET("/me", middleware.RequireScope(entities.ScopeProfileRead), usersHandlers.Me)
+		auth.GET("/me/export", middleware.RejectAPIKey(), privacyHandlers.Export)
+		auth.POST("/me/erasure", middleware.RejectAPIKey(), privacyHandlers.RequestErasure)
+		auth.POST("/password", middleware.RejectAPIKey(), authHandlers.ChangePassword)
+		auth.GET("/kyc", middleware.RequireScope(entities.ScopeProfileRead), kycHandlers.Get)
+		auth.PUT("/kyc", middleware.RejectAPIKey(), kycHandlers.Submit)
+		auth.GET("/notifications", middleware.RequireScope(entities.ScopeProfileRead), notificationsHandlers.List)
+		auth.POST("/notifications/read-all", middleware.RejectAPIKey(), notificationsHandlers.MarkAllRead)
+		auth.POST("/notifications/:id/read", middleware.RejectAPIKey(), notificationsHandlers.MarkRead)
+		auth.GET("/notifications/preferences", middleware.RequireScope(entities.ScopeProfileRead), notificationsHandlers.GetPreferences)
+		auth.PUT("/notifications/preferences", middleware.RejectAPIKey(), notificationsHandlers.UpdatePreferences)
+		auth.GET("/sessions", middleware.RejectAPIKey(), sessionsHandlers.List)
+		auth.DELETE("/sessions", middleware.RejectAPIKey(), sessionsHandlers.RevokeAll)
+		auth.DELETE("/sessions/:id", middleware.RejectAPIKey(), sessionsHandlers.Revoke)
+		auth.GET("/accounts", middleware.RequireScope(entities.ScopeAccountsRead), accountsHandlers.GetAllByUser)
+		auth.POST("/accounts", middleware.RequireScope(entities.ScopeAccountsWrite), accountsHandlers.Create)
+		auth.POST("/accounts/deposit", middleware.RequireScope(entities.ScopeAccountsWrite), accountsHandlers.Deposit)
+		auth.POST("/accounts/withdraw", middleware.RequireScope(entities.ScopeTransfersWrite), accountsHandlers.Withdraw)
+		auth.GET("/accounts/:id", middleware.RequireScope(entities.ScopeAccountsRead), accountsHandlers.GetByID)
+		auth.PATCH("/accounts/:id", middleware.RequireScope(entities.ScopeAccountsWrite), accountsHandlers.CloseAccount)
+		auth.GET("/accounts/:id/holds", middleware.RequireScope(entities.ScopeAccountsRead), holdsHandlers.List)
+		auth.POST("/accounts/:id/holds", middleware.RequireScope(entities.ScopeTransfersWrite), holdsHandlers.Create)
+		auth.POST("/holds/:id/capture", middleware.RequireScope(entities.ScopeTransfersWrite), holdsHandlers.Capture)
+		auth.POST("/holds/:id/release", middleware.RequireScope(entities.ScopeTransfersWrite), holdsHandlers.Release)
+		auth.GET("/accounts/:id/pots", middleware.RequireScope(entities.ScopeAccountsRead), potsHandlers.List)
+		auth.POST("/accounts/:id/pots", middleware.RequireScope(entities.ScopeAccountsWrite), potsHandlers.Create)
+		auth.PATCH("/pots/:id", middleware.RequireScope(entities.ScopeAccountsWrite), potsHandlers.Update)
+		auth.DELETE("/pots/:id", middleware.RequireScope(entities.ScopeAccountsWrite), potsHandlers.Delete)
+		auth.POST("/pots/:id/deposit", middleware.RequireScope(entities.ScopeAccountsWrite), potsHandlers.Deposit)
+		auth.POST("/pots/:id/withdraw", middleware.RequireScope(entities.ScopeAccountsWrite), potsHandlers.Withdraw)
+		auth.GET("/accounts/:id/balance", middleware.RequireScope(entities.ScopeAccountsRead), balancesHandlers.BalanceAt)
+		auth.GET("/accounts/:id/cards", middleware.RequireScope(entities.ScopeAccountsRead), cardsHandlers.List)
+		auth.POST("/accounts/:id/cards", middleware.RejectAPIKey(), cardsHandlers.Issue)
+		auth.GET("/cards/:id", middleware.RequireScope(entities.ScopeAccountsRead), cardsHandlers.Get)
+		auth.DELETE("/cards/:id", middleware.RequireScope(entities.ScopeAccountsWrite), cardsHandlers.Cancel)
+		auth.POST("/cards/:id/freeze", middleware.RequireScope(entities.ScopeAccountsWrite), cardsHandlers.Freeze)
+		auth.POST("/cards/:id/unfreeze", middleware.RequireScope(entities.ScopeAccountsWrite), cardsHandlers.Unfreeze)
+		auth.PUT("/cards/:id/limits", middleware.RequireScope(entities.ScopeAccountsWrite), cardsHandlers.SetLimits)
+		auth.POST("/loans", middleware.RejectAPIKey(), loansHandlers.Apply)
+		auth.GET("/loans", middleware.RequireScope(entities.ScopeAccountsRead), loansHandlers.List)
+		auth.GET("/loans/:id", middleware.RequireScope(entities.ScopeAccountsRead), loansHandlers.Get)
+		auth.GET("/loans/:id/balance", middleware.RequireScope(entities.ScopeAccountsRead), loansHandlers.Balance)
+		auth.GET("/accounts/:id/interest", middleware.RequireScope(entities.ScopeAccountsRead), interestHandlers.Get)
+		auth.GET("/accounts/:id/members", middleware.RequireScope(entities.ScopeAccountsRead), accountMembersHandlers.List)
+		auth.PATCH("/accounts/:id/members/:userId", middleware.RejectAPIKey(), accountMembersHandlers.Update)
+		auth.DELETE("/accounts/:id/members/:userId", middleware.RejectAPIKey(), accountMembersHandlers.Remove)
+		auth.GET("/accounts/:id/invitations", middleware.RejectAPIKey(), accountMembersHandlers.AccountInvitations)
+		auth.POST("/accounts/:id/invitations", middleware.RejectAPIKey(), accountMembersHandlers.Invite)
+		auth.DELETE("/accounts/:id/invitations/:invitationId", middleware.RejectAPIKey(), accountMembersHandlers.RevokeInvitation)
+		auth.GET("/invitations", middleware.RejectAPIKey(), accountMembersHandlers.MyInvitations)
+		auth.POST("/invitations/:id/accept", middleware.RejectAPIKey(), accountMembersHandlers.Accept)
+		auth.POST("/invitations/:id/decline", middleware.RejectAPIKey(), accountMembersHandlers.Decline)
+		auth.GET("/payees", middleware.RequireScope(entities.ScopeAccountsRead), payeesHandlers.List)
+		auth.POST("/payees", middleware.RequireScope(entities.ScopeTransfersWrite), payeesHandlers.Create)
+		auth.GET("/payees/:id", middleware.RequireScope(entities.ScopeAccountsRead), payeesHandlers.Get)
+		auth.PATCH("/payees/:id", middleware.RequireScope(entities.ScopeTransfersWrite), payeesHandlers.Update)
+		auth.DELETE("/payees/:id", middleware.RequireScope(entities.ScopeTransfersWrite), payeesHandlers.Delete)
+		auth.GET("/limits", middleware.RequireScope(entities.ScopeAccountsRead), limitsHandlers.Status)
+		auth.POST("/fees/preview", middleware.RequireScope(entities.ScopeAccountsRead), feesHandlers.Preview)
+		auth.GET("/transactions", middleware.RequireScope(entities.ScopeTransactionsRead), transferHandlers.GetTransactions)
+		auth.POST("/transfers/internal", middleware.RequireScope(entities.ScopeTransfersWrite), transferHandlers.InternalTransfer)
+		auth.POST("/transfers/external", middleware.RequireScope(entities.ScopeTransfersWrite), transferHandlers.ExternalTransfer)
+		auth.GET("/transactions/:id", middleware.RequireScope(entities.ScopeTransactionsRead), transferHandlers.GetTransactionById)
 	}
 
 	users := r.Group("/users")
+	users.Use(jwtAuth, middleware.RequireRoles(entities.RoleAdmin))
 	{
 		users.GET("", usersHandlers.GetAll)
+		users.GET("/:id", usersHandlers.GetByID)
 		users.PATCH("/:id", usersHandlers.Update)
+		users.POST("/:id/block", usersHandlers.Block)
+		users.POST("/:id/unblock", usersHandlers.Unblock)
+		users.PATCH("/:id/role", usersHandlers.UpdateRole)
+	}
+
+	admin := r.Group("/admin")
+	admin.Use(jwtAuth, middleware.RequireRoles(entities.RoleAdmin, entities.RoleSupport))
+	{
+		admin.POST("/login/unlock", adminHandlers.UnlockLogin)
+		admin.GET("/kyc", kycHandlers.List)
+		admin.PATCH("/kyc/:userId/status", kycHandlers.Review)
+		admin.GET("/erasure-requests", middleware.RequireRoles(entities.RoleAdmin), privacyHandlers.ListErasureRequests)
+		admin.POST("/erasure-requests/:id", middleware.RequireRoles(entities.RoleAdmin), privacyHandlers.ProcessErasure)
+		admin.GET("/api-keys", middleware.RequireRoles(entities.RoleAdmin), apiKeysHandlers.GetAll)
+		admin.POST("/api-keys", middleware.RequireRoles(entities.RoleAdmin), apiKeysHandlers.Create)
+		admin.DELETE("/api-keys/:id", middleware.RequireRoles(entities.RoleAdmin), apiKeysHandlers.Revoke)
+		admin.GET("/transfer-reviews", transferReviewsHandlers.List)
+		admin.POST("/transfer-reviews/:id/approve", transferReviewsHandlers.Approve)
+		admin.POST("/transfer-reviews/:id/reject", transferReviewsHandlers.Reject)
+		admin.GET("/limits", limitsHandlers.Rules)
+		admin.PUT("/limits", middleware.RequireRoles(entities.RoleAdmin), limitsHandlers.SetRule)
+		admin.DELETE("/limits/:id", middleware.RequireRoles(entities.RoleAdmin), limitsHandlers.DeleteRule)
+		admin.POST("/accounts/:id/freeze", accountStatusHandlers.Freeze)
+		admin.POST("/accounts/:id/unfreeze", middleware.RequireRoles(entities.RoleAdmin), accountStatusHandlers.Unfreeze)
+		admin.PUT("/accounts/:id/overdraft", middleware.RequireRoles(entities.RoleAdmin), accountsHandlers.SetOverdraft)
+		admin.GET("/fees", feesHandlers.Rules)
+		admin.PUT("/fees", middleware.RequireRoles(entities.RoleAdmin), feesHandlers.SetRule)
+		admin.DELETE("/fees/:id", middleware.RequireRoles(entities.RoleAdmin), feesHandlers.DeleteRule)
+		admin.POST("/interest/run", middleware.RequireRoles(entities.RoleAdmin), interestHandlers.Run)
+		admin.POST("/balances/snapshot", middleware.RequireRoles(entities.RoleAdmin), balancesHandlers.Snapshot)
+		admin.GET("/loans", loansHandlers.Applications)
+		admin.POST("/loans/:id/approve", middleware.RequireRoles(entities.RoleAdmin), loansHandlers.Approve)
+		admin.POST("/loans/:id/reject", middleware.RequireRoles(entities.RoleAdmin), loansHandlers.Reject)
+		admin.POST("/loans/repayments/run", middleware.RequireRoles(entities.RoleAdmin), loansHandlers.RunRepayments)
+		admin.GET("/audit", middleware.RequireRoles(entities.RoleAdmin), auditHandlers.Search)
+		admin.GET("/audit/verify", middleware.RequireRoles(entities.RoleAdmin), auditHandlers.Verify)
 	}
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	r.GET("/currencies", currenciesHandlers.List)
+	r.POST("/network/cards/authorize", middleware.RequireNetworkKey(cfg.Cards.NetworkKey), cardsHandlers.Authorize)
 	r.POST("/register", authHandlers.Register)
 	r.POST("/login", authHandlers.Login)
 	r.POST("/refresh", authHandlers.Refresh)
-	r.POST("/logout", authHandlers.Logout)
+	r.POST("/logout", jwtAuth, authHandlers.Logout)
 
 	if err := server.StartServer(r, cfg.HTTPServer, loggerZap); err != nil {
 		loggerZap.Error("Server failed", zap.Error(err))
@@ -132,3 +349,27 @@ func setupDatabase(path string, logger *zap.Logger) *gorm.DB {
 	}
 	return database
 }
+
+// setupNotificationSenders создаёт заглушки внешних каналов уведомлений из конфигурации
+func setupNotificationSenders(cfg config.NotificationsConfig) map[entities.NotificationChannel]notify.Sender {
+	senders := make(map[entities.NotificationChannel]notify.Sender)
+	for channel, kind := range cfg.Senders {
+		if entities.NotificationChannel(channel) == entities.ChannelInApp {
+			continue
+		}
+		sender, err := notify.NewSender(channel, kind, cfg.OutboxDir)
+		if err != nil {
+			lib.Log.Fatal("Invalid notification sender", zap.Error(err))
+		}
+		senders[entities.NotificationChannel(channel)] = sender
+	}
+	return senders
+}
+
+func notificationChannels(names []string) []entities.NotificationChannel {
+	channels := make([]entities.NotificationChannel, len(names))
+	for i, name := range names {
+		channels[i] = entities.NotificationChannel(name)
+	}
+	return channels
+}
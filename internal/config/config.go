@@ -11,7 +11,217 @@ type Config struct {
 	Env        string `yaml:"env" env-default:"local"`
 	Storage    string `yaml:"storage_path" env-required:"true"`
 	HTTPServer `yaml:"http_server"`
-	Redis      RedisConfig `yaml:"redis"`
+	Redis      RedisConfig              `yaml:"redis"`
+	Password   PasswordPolicyConfig     `yaml:"password_policy"`
+	Login      LoginProtectionConfig    `yaml:"login_protection"`
+	RBAC       RBACConfig               `yaml:"rbac"`
+	KYC        KYCConfig                `yaml:"kyc"`
+	Cache      CacheConfig              `yaml:"cache"`
+	Kafka      KafkaConfig              `yaml:"kafka"`
+	Notify     NotificationsConfig      `yaml:"notifications"`
+	Payees     PayeesConfig             `yaml:"payees"`
+	Accounts   AccountsConfig           `yaml:"accounts"`
+	Fraud      FraudConfig              `yaml:"fraud"`
+	Products   map[string]ProductConfig `yaml:"products"`
+	Overdraft  ProductConfig            `yaml:"overdraft"`
+	Jobs       JobsConfig               `yaml:"jobs"`
+	Holds      HoldsConfig              `yaml:"holds"`
+	Fees       FeesConfig               `yaml:"fees"`
+	Currencies CurrenciesConfig         `yaml:"currencies"`
+	Cards      CardsConfig              `yaml:"cards"`
+	Loans      LoansConfig              `yaml:"loans"`
+	Audit      AuditConfig              `yaml:"audit"`
+}
+
+type AuditConfig struct {
+	// Secret — ключ HMAC, которым в журнале аудита заменяются персональные данные (email, имя, IP)
+	Secret string `yaml:"secret" env-required:"true" env:"AUDIT_SECRET"`
+}
+
+type LoansConfig struct {
+	// InterestRate — годовая ставка по новым кредитам, например 0.15 для 15%
+	InterestRate float64 `yaml:"interest_rate" env-default:"0.15"`
+	// MinAmount и MaxAmount — допустимая сумма кредита
+	MinAmount float64 `yaml:"min_amount" env-default:"10000"`
+	MaxAmount float64 `yaml:"max_amount" env-default:"3000000"`
+	// MaxTermMonths — наибольший срок кредита в месяцах
+	MaxTermMonths int `yaml:"max_term_months" env-default:"60"`
+	// LateFee — штраф за платёж, не внесённый дольше GraceDays дней после срока; 0 отключает
+	LateFee   float64 `yaml:"late_fee" env-default:"500"`
+	GraceDays int     `yaml:"grace_days" env-default:"3"`
+}
+
+type CardsConfig struct {
+	// BIN — первые шесть цифр номеров выпускаемых карт
+	BIN string `yaml:"bin" env-default:"400000"`
+	// ValidityYears — срок действия карты в годах
+	ValidityYears int `yaml:"validity_years" env-default:"3"`
+	// Secret — ключ HMAC, которым хешируются номера карт
+	Secret string `yaml:"secret" env-required:"true" env:"CARDS_SECRET"`
+	// NetworkKey — ключ, с которым платёжная сеть (симулятор) вызывает авторизацию; пустой отключает её
+	NetworkKey string `yaml:"network_key" env:"CARDS_NETWORK_KEY"`
+	// MaxCVVAttempts — после скольких неверных CVV за CVVAttemptWindow авторизации по карте
+	// отклоняются на CVVLockout; 0 отключает ограничение
+	MaxCVVAttempts   int           `yaml:"max_cvv_attempts" env-default:"3"`
+	CVVAttemptWindow time.Duration `yaml:"cvv_attempt_window" env-default:"24h"`
+	CVVLockout       time.Duration `yaml:"cvv_lockout" env-default:"24h"`
+}
+
+type CurrenciesConfig struct {
+	// Enabled — коды ISO 4217 валют, в которых можно открывать счета
+	Enabled []string `yaml:"enabled" env-separator:"," env-default:"RUB"`
+}
+
+type FeesConfig struct {
+	// Accounts — счета банка (тип bank), на которые зачисляются комиссии, по валютам;
+	// проверяются при запуске
+	Accounts map[string]uint `yaml:"accounts"`
+}
+
+type HoldsConfig struct {
+	// DefaultTTL — срок действия холда, если он не указан в запросе
+	DefaultTTL time.Duration `yaml:"default_ttl" env-default:"168h"`
+	// MaxTTL — наибольший допустимый срок действия холда
+	MaxTTL time.Duration `yaml:"max_ttl" env-default:"720h"`
+	// SweepInterval — как часто истёкшие холды снимаются со счетов
+	SweepInterval time.Duration `yaml:"sweep_interval" env-default:"1m"`
+}
+
+// ProductConfig — правила продукта; ключом в секции products служит тип счёта (Account.Type).
+// Секция overdraft задаёт в том же виде ставку по отрицательному остатку любого счёта.
+type ProductConfig struct {
+	// InterestRate — годовая ставка, например 0.08 для 8%; 0 — проценты не начисляются
+	InterestRate float64 `yaml:"interest_rate"`
+	// DayCount — конвенция подсчёта дней: ACT/365 (по умолчанию), ACT/360, ACT/ACT или 30/360
+	DayCount string `yaml:"day_count"`
+}
+
+type JobsConfig struct {
+	// Interval — как часто планировщик проверяет, не пора ли запустить ежедневные задачи
+	Interval time.Duration `yaml:"interval" env-default:"1h"`
+	// Timezone — часовой пояс, в котором определяется завершившийся операционный день
+	Timezone string `yaml:"timezone" env-default:"UTC"`
+}
+
+// FraudConfig — правила проверки переводов на мошенничество. Action правила — hold
+// (на ручную проверку) или block; пустое значение отключает правило.
+type FraudConfig struct {
+	// BlockAfter — при скольких сработавших правилах перевод блокируется; 0 отключает эскалацию
+	BlockAfter    int                      `yaml:"block_after" env-default:"0"`
+	Velocity      FraudVelocityConfig      `yaml:"velocity"`
+	NewPayee      FraudNewPayeeConfig      `yaml:"new_payee"`
+	UnusualAmount FraudUnusualAmountConfig `yaml:"unusual_amount"`
+	NightTime     FraudNightTimeConfig     `yaml:"night_time"`
+}
+
+// FraudVelocityConfig срабатывает, если за Window пользователь сделал больше MaxTransfers переводов
+type FraudVelocityConfig struct {
+	Action       string        `yaml:"action"`
+	Window       time.Duration `yaml:"window" env-default:"10m"`
+	MaxTransfers int           `yaml:"max_transfers" env-default:"5"`
+}
+
+// FraudNewPayeeConfig срабатывает на внешний перевод от MinAmount на счёт, куда пользователь ещё не переводил
+type FraudNewPayeeConfig struct {
+	Action    string  `yaml:"action"`
+	MinAmount float64 `yaml:"min_amount"`
+}
+
+// FraudUnusualAmountConfig срабатывает, если сумма больше среднего по последним History
+// переводам в Multiplier раз; при истории короче MinHistory правило не применяется
+type FraudUnusualAmountConfig struct {
+	Action     string  `yaml:"action"`
+	History    int     `yaml:"history" env-default:"20"`
+	MinHistory int     `yaml:"min_history" env-default:"5"`
+	Multiplier float64 `yaml:"multiplier" env-default:"5"`
+}
+
+// FraudNightTimeConfig срабатывает на перевод от MinAmount с StartHour до EndHour по времени Timezone
+type FraudNightTimeConfig struct {
+	Action    string  `yaml:"action"`
+	StartHour int     `yaml:"start_hour" env-default:"0"`
+	EndHour   int     `yaml:"end_hour" env-default:"6"`
+	Timezone  string  `yaml:"timezone" env-default:"UTC"`
+	MinAmount float64 `yaml:"min_amount"`
+}
+
+type AccountsConfig struct {
+	// InvitationTTL — сколько действует приглашение в совместный счёт
+	InvitationTTL time.Duration `yaml:"invitation_ttl" env-default:"168h"`
+	// DormantAfter — через сколько времени без операций клиента счёт становится dormant; 0 отключает
+	DormantAfter time.Duration `yaml:"dormant_after"`
+}
+
+type PayeesConfig struct {
+	// CoolingOff — сколько времени после добавления получателя переводы ему запрещены; 0 отключает ограничение
+	CoolingOff time.Duration `yaml:"cooling_off" env-default:"0s"`
+}
+
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers" env:"KAFKA_BROKERS" env-separator:"," env-default:"localhost:9092"`
+	GroupID string   `yaml:"group_id" env-default:"bank-app-group"`
+}
+
+type NotificationsConfig struct {
+	// DefaultChannels — каналы, включённые для всех событий, пока пользователь не изменил настройки
+	DefaultChannels []string `yaml:"default_channels" env-separator:"," env-default:"in_app,email"`
+	// Senders — заглушка для каждого внешнего канала: file или log
+	Senders   map[string]string `yaml:"senders"`
+	OutboxDir string            `yaml:"outbox_dir" env-default:"./var/notifications"`
+}
+
+type CacheConfig struct {
+	// InvalidationChannel — канал Redis pub/sub для рассылки инвалидаций между инстансами
+	InvalidationChannel string           `yaml:"invalidation_channel" env-default:"cache:invalidate"`
+	Users               CacheEntryConfig `yaml:"users"`
+	Accounts            CacheEntryConfig `yaml:"accounts"`
+}
+
+// CacheEntryConfig — время жизни записей сущности в Redis и в памяти инстанса
+type CacheEntryConfig struct {
+	TTL      time.Duration `yaml:"ttl"`
+	LocalTTL time.Duration `yaml:"local_ttl"`
+}
+
+type KYCConfig struct {
+	MinAge   int           `yaml:"min_age" env-default:"18"`
+	Basic    KYCTierConfig `yaml:"basic"`
+	Verified KYCTierConfig `yaml:"verified"`
+}
+
+// KYCTierConfig — ограничения уровня верификации; 0 означает отсутствие ограничения
+type KYCTierConfig struct {
+	MaxAccounts       int     `yaml:"max_accounts"`
+	MaxDepositAmount  float64 `yaml:"max_deposit_amount"`
+	MaxTransferAmount float64 `yaml:"max_transfer_amount"`
+	ExternalTransfers bool    `yaml:"external_transfers"`
+}
+
+type RBACConfig struct {
+	// BootstrapAdmins — email пользователей, которым при старте назначается роль admin
+	BootstrapAdmins []string `yaml:"bootstrap_admins" env:"RBAC_BOOTSTRAP_ADMINS" env-separator:","`
+}
+
+type LoginProtectionConfig struct {
+	MaxAttemptsPerEmail int           `yaml:"max_attempts_per_email" env-default:"10"`
+	MaxAttemptsPerIP    int           `yaml:"max_attempts_per_ip" env-default:"50"`
+	DelayAfter          int           `yaml:"delay_after" env-default:"3"`
+	BaseDelay           time.Duration `yaml:"base_delay" env-default:"1s"`
+	MaxDelay            time.Duration `yaml:"max_delay" env-default:"30s"`
+	AttemptWindow       time.Duration `yaml:"attempt_window" env-default:"15m"`
+	LockoutDuration     time.Duration `yaml:"lockout_duration" env-default:"30m"`
+}
+
+// PasswordPolicyConfig — требования к паролям. MaxLength считается в байтах и не может
+// превышать 72 — предел bcrypt.
+type PasswordPolicyConfig struct {
+	MinLength      int  `yaml:"min_length" env-default:"8"`
+	MaxLength      int  `yaml:"max_length" env-default:"72"`
+	RequireUpper   bool `yaml:"require_upper" env-default:"true"`
+	RequireLower   bool `yaml:"require_lower" env-default:"true"`
+	RequireDigit   bool `yaml:"require_digit" env-default:"true"`
+	RequireSpecial bool `yaml:"require_special" env-default:"false"`
+	RejectCommon   bool `yaml:"reject_common" env-default:"true"`
 }
 
 type RedisConfig struct {
@@ -26,6 +236,9 @@ type HTTPServer struct {
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 	User        string        `yaml:"user" env-required:"true"`
 	Password    string        `yaml:"password" env-required:"true" env:"HTTP_SERVER_PASSWORD"`
+	// TrustedProxies - адреса или подсети прокси, которым доверяем X-Forwarded-For и X-Real-IP;
+	// если список пуст, IP клиента берётся из адреса соединения
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 func MustLoad() *Config {
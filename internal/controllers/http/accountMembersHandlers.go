@@ -0,0 +1,363 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type AccountMembersHandler struct {
+	service services.AccountMembersService
+}
+
+func NewAccountMembersHandler(s services.AccountMembersService) *AccountMembersHandler {
+	return &AccountMembersHandler{service: s}
+}
+
+// @Summary      List account members
+// @Description  Returns the members of an account with their roles. Available to any member.
+// @Tags         Account members
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Account ID"
+// @Success      200 {array} entities.AccountMember
+// @Failure      400 {object} entities.ErrorResponse "Invalid account ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/accounts/{id}/members [get]
+func (h *AccountMembersHandler) List(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	members, err := h.service.List(c.Request.Context(), userID, uint(accountID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// @Summary      Change account member
+// @Description  Changes the role or spend limit of a member. Only the owner can do this; the owner's own membership cannot be changed.
+// @Tags         Account members
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                          true "Account ID"
+// @Param        userId  path int                          true "Member user ID"
+// @Param        request body entities.UpdateMemberRequest true "New role and limit"
+// @Success      200 {object} entities.AccountMember
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not the account owner"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Router       /auth/accounts/{id}/members/{userId} [patch]
+func (h *AccountMembersHandler) Update(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	memberID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req entities.UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	member, err := h.service.UpdateMember(c.Request.Context(), userID, uint(accountID), uint(memberID), &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// @Summary      Remove account member
+// @Description  Removes a member from an account. The owner can remove anyone except themselves; other members can only remove themselves.
+// @Tags         Account members
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id     path int true "Account ID"
+// @Param        userId path int true "Member user ID"
+// @Success      200 {object} entities.MessageResponse "Member removed"
+// @Failure      400 {object} entities.ErrorResponse "Invalid request"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Router       /auth/accounts/{id}/members/{userId} [delete]
+func (h *AccountMembersHandler) Remove(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	memberID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.service.RemoveMember(c.Request.Context(), userID, uint(accountID), uint(memberID)); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
+
+// @Summary      Invite to account
+// @Description  Invites a user by email to join the account as co_owner, viewer or spender. Only the owner can invite.
+// @Tags         Account members
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                          true "Account ID"
+// @Param        request body entities.InviteMemberRequest true "Invitation"
+// @Success      201 {object} entities.AccountInvitation
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not the account owner"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      409 {object} entities.ErrorResponse "User is already a member"
+// @Router       /auth/accounts/{id}/invitations [post]
+func (h *AccountMembersHandler) Invite(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var req entities.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	invitation, err := h.service.Invite(c.Request.Context(), userID, uint(accountID), &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// @Summary      List account invitations
+// @Description  Returns all invitations sent for the account. Only the owner can see them.
+// @Tags         Account members
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Account ID"
+// @Success      200 {array} entities.AccountInvitation
+// @Failure      400 {object} entities.ErrorResponse "Invalid account ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not the account owner"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Router       /auth/accounts/{id}/invitations [get]
+func (h *AccountMembersHandler) AccountInvitations(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	invitations, err := h.service.AccountInvitations(c.Request.Context(), userID, uint(accountID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// @Summary      Revoke invitation
+// @Description  Revokes a pending invitation to the account
+// @Tags         Account members
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id           path int true "Account ID"
+// @Param        invitationId path int true "Invitation ID"
+// @Success      200 {object} entities.MessageResponse "Invitation revoked"
+// @Failure      400 {object} entities.ErrorResponse "Invalid request"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not the account owner"
+// @Failure      404 {object} entities.ErrorResponse "Invitation not found"
+// @Router       /auth/accounts/{id}/invitations/{invitationId} [delete]
+func (h *AccountMembersHandler) RevokeInvitation(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(c.Param("invitationId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	if err := h.service.RevokeInvitation(c.Request.Context(), userID, uint(accountID), uint(invitationID)); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked"})
+}
+
+// @Summary      My invitations
+// @Description  Returns pending invitations addressed to the authenticated user's email
+// @Tags         Account members
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} entities.AccountInvitation
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/invitations [get]
+func (h *AccountMembersHandler) MyInvitations(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	invitations, err := h.service.MyInvitations(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// @Summary      Accept invitation
+// @Description  Accepts an invitation and makes the user a member of the account with the offered role
+// @Tags         Account members
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Invitation ID"
+// @Success      200 {object} entities.AccountMember
+// @Failure      400 {object} entities.ErrorResponse "Invalid request"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Invitation not found"
+// @Failure      409 {object} entities.ErrorResponse "Already a member"
+// @Router       /auth/invitations/{id}/accept [post]
+func (h *AccountMembersHandler) Accept(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	member, err := h.service.AcceptInvitation(c.Request.Context(), userID, uint(id))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// @Summary      Decline invitation
+// @Description  Declines an invitation to join an account
+// @Tags         Account members
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Invitation ID"
+// @Success      200 {object} entities.MessageResponse "Invitation declined"
+// @Failure      400 {object} entities.ErrorResponse "Invalid request"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Invitation not found"
+// @Router       /auth/invitations/{id}/decline [post]
+func (h *AccountMembersHandler) Decline(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	if err := h.service.DeclineInvitation(c.Request.Context(), userID, uint(id)); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation declined"})
+}
+
+func (h *AccountMembersHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAccountNotFound), errors.Is(err, services.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAccountForbidden), errors.Is(err, services.ErrOwnerMembership):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyMember):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
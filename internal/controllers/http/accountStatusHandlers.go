@@ -0,0 +1,86 @@
+package http
+
+import (
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type AccountStatusHandler struct {
+	service services.AccountStatusService
+}
+
+func NewAccountStatusHandler(s services.AccountStatusService) *AccountStatusHandler {
+	return &AccountStatusHandler{service: s}
+}
+
+// @Summary      Freeze account
+// @Description  Freezes an account: no money can be paid into or taken from it until it is unfrozen. The reason is shown to the account members and written to the audit log.
+// @Tags         Account status
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                           true "Account ID"
+// @Param        request body entities.AccountStatusRequest true "Reason"
+// @Success      200 {object} entities.AccountResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      409 {object} entities.ErrorResponse "Account cannot be frozen in its status"
+// @Router       /admin/accounts/{id}/freeze [post]
+func (h *AccountStatusHandler) Freeze(c *gin.Context) {
+	h.change(c, h.service.Freeze)
+}
+
+// @Summary      Unfreeze account
+// @Description  Returns a frozen account to the active status
+// @Tags         Account status
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                           true "Account ID"
+// @Param        request body entities.AccountStatusRequest true "Reason"
+// @Success      200 {object} entities.AccountResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      409 {object} entities.ErrorResponse "Account is not frozen"
+// @Router       /admin/accounts/{id}/unfreeze [post]
+func (h *AccountStatusHandler) Unfreeze(c *gin.Context) {
+	h.change(c, h.service.Unfreeze)
+}
+
+func (h *AccountStatusHandler) change(c *gin.Context, change func(ctx context.Context, accountID uint, reason string) (*entities.Account, error)) {
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var req entities.AccountStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	account, err := change(c.Request.Context(), uint(accountID), req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAccountNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, account.ToResponse())
+}
@@ -4,6 +4,7 @@ import (
 	"bank-app-backend/internal/controllers/http/helpers"
 	"bank-app-backend/internal/entities"
 	"bank-app-backend/internal/services"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -19,7 +20,7 @@ func NewAccountsHandler(s services.AccountsService) *AccountsHandler {
 
 // GetAllByUser godoc
 // @Summary Get all user accounts
-// @Description Returns a list of accounts belonging to the authenticated user
+// @Description Returns all accounts the authenticated user is a member of, including joint accounts
 // @Tags accounts
 // @Security BearerAuth
 // @Produce json
@@ -37,6 +38,7 @@ func (h *AccountsHandler) GetAllByUser(c *gin.Context) {
 	accounts, err := h.service.GetAll(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, entities.AccountsToResponse(accounts))
@@ -44,7 +46,7 @@ func (h *AccountsHandler) GetAllByUser(c *gin.Context) {
 
 // GetByID godoc
 // @Summary Get a user account by ID
-// @Description Returns a specific account by ID if it belongs to the authenticated user
+// @Description Returns a specific account by ID if the authenticated user is its member
 // @Tags accounts
 // @Security BearerAuth
 // @Produce json
@@ -72,7 +74,12 @@ func (h *AccountsHandler) GetByID(c *gin.Context) {
 
 	account, err := h.service.GetByID(c.Request.Context(), userID, accountID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+		if errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, account.ToResponse())
@@ -87,15 +94,17 @@ func (h *AccountsHandler) GetByID(c *gin.Context) {
 // @Produce json
 // @Param account body entities.CreateAccountRequest true "Account creation data"
 // @Success 201 {object} entities.AccountResponse
-// @Failure 400 {object} entities.ErrorResponse "Invalid input"
+// @Failure 400 {object} entities.ErrorResponse "Invalid input or unsupported currency"
 // @Failure 401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed for the verification tier"
 // @Failure 500 {object} entities.ErrorResponse "Failed to create account"
 // @Router /auth/accounts [post]
 func (h *AccountsHandler) Create(c *gin.Context) {
 	var req entities.CreateAccountRequest
 
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
 	}
 
 	userID, err := helpers.ExtractUserID(c)
@@ -106,7 +115,16 @@ func (h *AccountsHandler) Create(c *gin.Context) {
 
 	account, err := h.service.Create(c.Request.Context(), userID, &req)
 	if err != nil {
+		if errors.Is(err, services.ErrKYCRestricted) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrUnsupportedCurrency) || errors.Is(err, services.ErrReservedAccountType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create account", "details": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusCreated, account.ToResponse())
@@ -114,7 +132,7 @@ func (h *AccountsHandler) Create(c *gin.Context) {
 
 // Deposit godoc
 // @Summary Deposit to an account
-// @Description Adds money to the user's account
+// @Description Adds money to an account. Viewers of a joint account cannot deposit.
 // @Tags accounts
 // @Security BearerAuth
 // @Accept json
@@ -123,11 +141,15 @@ func (h *AccountsHandler) Create(c *gin.Context) {
 // @Success 200 {object} entities.AccountResponse
 // @Failure 400 {object} entities.ErrorResponse "Invalid input or account"
 // @Failure 401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed for the verification tier or account role"
+// @Failure 404 {object} entities.ErrorResponse "Account not found"
+// @Failure 409 {object} entities.ErrorResponse "Account status does not allow the operation"
 // @Router /auth/accounts/deposit [post]
 func (h *AccountsHandler) Deposit(c *gin.Context) {
 	var req entities.DepositRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
 	}
 
 	userID, err := helpers.ExtractUserID(c)
@@ -138,7 +160,73 @@ func (h *AccountsHandler) Deposit(c *gin.Context) {
 
 	account, err := h.service.Deposit(c.Request.Context(), userID, req.AccountID, req.Amount)
 	if err != nil {
+		if errors.Is(err, services.ErrKYCRestricted) || errors.Is(err, services.ErrAccountForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrAccountInactive) || errors.Is(err, services.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account.ToResponse())
+}
+
+// Withdraw godoc
+// @Summary Withdraw from an account
+// @Description Takes money out of the bank from an account. Counts towards transfer limits.
+// @Tags accounts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param withdrawal body entities.WithdrawRequest true "Withdrawal data"
+// @Success 200 {object} entities.AccountResponse
+// @Failure 400 {object} entities.ErrorResponse "Invalid input or insufficient funds"
+// @Failure 401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed for the account role or spend limit"
+// @Failure 404 {object} entities.ErrorResponse "Account not found"
+// @Failure 422 {object} entities.LimitExceededResponse "Transfer limit exceeded"
+// @Failure 409 {object} entities.ErrorResponse "Account status does not allow the operation"
+// @Router /auth/accounts/withdraw [post]
+func (h *AccountsHandler) Withdraw(c *gin.Context) {
+	var req entities.WithdrawRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.service.Withdraw(c.Request.Context(), userID, req.AccountID, req.Amount)
+	if err != nil {
+		if writeLimitExceeded(c, err) {
+			return
+		}
+		if errors.Is(err, services.ErrAccountForbidden) || errors.Is(err, services.ErrSpendLimitExceeded) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrAccountInactive) || errors.Is(err, services.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, account.ToResponse())
@@ -146,7 +234,7 @@ func (h *AccountsHandler) Deposit(c *gin.Context) {
 
 // CloseAccount godoc
 // @Summary Close a user account
-// @Description Closes an account if its balance is zero
+// @Description Closes an account if its balance is zero. Only the account owner can close it.
 // @Tags accounts
 // @Security BearerAuth
 // @Produce json
@@ -154,6 +242,9 @@ func (h *AccountsHandler) Deposit(c *gin.Context) {
 // @Success 200 {object} entities.MessageResponse "Account closed successfully"
 // @Failure 400 {object} entities.ErrorResponse "Cannot close account"
 // @Failure 401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not the account owner"
+// @Failure 404 {object} entities.ErrorResponse "Account not found"
+// @Failure 409 {object} entities.ErrorResponse "Account status does not allow the operation"
 // @Router /auth/accounts/{id} [patch]
 func (h *AccountsHandler) CloseAccount(c *gin.Context) {
 	userID, err := helpers.ExtractUserID(c)
@@ -173,8 +264,67 @@ func (h *AccountsHandler) CloseAccount(c *gin.Context) {
 
 	err = h.service.Delete(c.Request.Context(), userID, accountID)
 	if err != nil {
+		if errors.Is(err, services.ErrAccountForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrAccountInactive) || errors.Is(err, services.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Account closed successfully"})
 }
+
+// SetOverdraft godoc
+// @Summary Set account overdraft
+// @Description Sets how far below zero the account balance may go. The limit cannot be lower than the current debt; 0 turns the overdraft off.
+// @Tags accounts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param request body entities.SetOverdraftRequest true "Overdraft limit"
+// @Success 200 {object} entities.AccountResponse
+// @Failure 400 {object} entities.ErrorResponse "Invalid input or limit precision"
+// @Failure 401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Forbidden"
+// @Failure 404 {object} entities.ErrorResponse "Account not found"
+// @Failure 409 {object} entities.ErrorResponse "Limit is below the current debt or the account is closed"
+// @Router /admin/accounts/{id}/overdraft [put]
+func (h *AccountsHandler) SetOverdraft(c *gin.Context) {
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var req entities.SetOverdraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	account, err := h.service.SetOverdraft(c.Request.Context(), uint(accountID), req.Limit)
+	if err != nil {
+		if errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrOverdraftBelowDebt) || errors.Is(err, services.ErrAccountInactive) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account.ToResponse())
+}
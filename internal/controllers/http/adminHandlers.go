@@ -0,0 +1,50 @@
+package http
+
+import (
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AdminHandler struct {
+	loginProtection services.LoginProtectionService
+}
+
+func NewAdminHandler(loginProtection services.LoginProtectionService) *AdminHandler {
+	return &AdminHandler{loginProtection: loginProtection}
+}
+
+// @Summary      Unlock login
+// @Description  Removes a login lockout and resets failed attempt counters for an email and/or IP
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.UnlockLoginRequest true "Email and/or IP to unlock"
+// @Success      200 {object} entities.MessageResponse "Login unlocked"
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/login/unlock [post]
+func (h *AdminHandler) UnlockLogin(c *gin.Context) {
+	var req entities.UnlockLoginRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	if req.Email == "" && req.IP == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email or IP is required"})
+		return
+	}
+
+	if err := h.loginProtection.Unlock(c.Request.Context(), req.Email, req.IP); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Login unlocked"})
+}
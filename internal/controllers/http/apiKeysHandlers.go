@@ -0,0 +1,108 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type APIKeysHandler struct {
+	service services.APIKeysService
+}
+
+func NewAPIKeysHandler(s services.APIKeysService) *APIKeysHandler {
+	return &APIKeysHandler{service: s}
+}
+
+// @Summary      Issue API key
+// @Description  Issues a scoped API key acting on behalf of a user. The key is returned only once.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.CreateAPIKeyRequest true "API key data"
+// @Success      201 {object} entities.CreateAPIKeyResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Router       /admin/api-keys [post]
+func (h *APIKeysHandler) Create(c *gin.Context) {
+	var req entities.CreateAPIKeyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	adminID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	rawKey, key, err := h.service.Create(c.Request.Context(), adminID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entities.CreateAPIKeyResponse{
+		Key:    rawKey,
+		APIKey: key.ToResponse(),
+	})
+}
+
+// @Summary      List API keys
+// @Description  Returns metadata of all issued API keys
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} entities.APIKeyResponse
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/api-keys [get]
+func (h *APIKeysHandler) GetAll(c *gin.Context) {
+	keys, err := h.service.GetAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.APIKeysToResponse(keys))
+}
+
+// @Summary      Revoke API key
+// @Description  Revokes an API key; further requests with it are rejected
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "API key ID"
+// @Success      200 {object} entities.MessageResponse "API key revoked"
+// @Failure      400 {object} entities.ErrorResponse "Invalid API key ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "API key not found"
+// @Router       /admin/api-keys/{id} [delete]
+func (h *APIKeysHandler) Revoke(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return
+	}
+
+	if err := h.service.Revoke(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, services.ErrAPIKeyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AuditHandler struct {
+	service services.AuditService
+}
+
+func NewAuditHandler(service services.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// @Summary      Search audit log
+// @Description  Returns audit log entries, newest first, with filters and pagination. Requires the admin role.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        actorId      query int    false "Actor user ID"
+// @Param        action       query string false "Action (e.g. auth.login, transfer.create)"
+// @Param        resourceType query string false "Resource type (user, account, transaction, ...)"
+// @Param        resourceId   query string false "Resource ID"
+// @Param        requestId    query string false "Request ID"
+// @Param        result       query string false "Result (success, failure)"
+// @Param        fromDate     query string false "From date (YYYY-MM-DD)"
+// @Param        toDate       query string false "To date (YYYY-MM-DD)"
+// @Param        page         query int    false "Page number"
+// @Param        limit        query int    false "Page size (max 100)"
+// @Success      200 {object} entities.AuditPage "Audit entries retrieved successfully"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/audit [get]
+func (h *AuditHandler) Search(c *gin.Context) {
+	page, err := h.service.Search(c.Request.Context(), helpers.BuildAuditFilter(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary      Verify audit log
+// @Description  Recomputes the hash chain of the audit log and reports the first tampered entry, if any. Requires the admin role.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} entities.AuditVerification "Verification result"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/audit/verify [get]
+func (h *AuditHandler) Verify(c *gin.Context) {
+	result, err := h.service.Verify(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -1,11 +1,16 @@
 package http
 
 import (
+	"bank-app-backend/internal/controllers/http/helpers"
 	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/lib/password"
 	"bank-app-backend/internal/services"
+	"errors"
 	"github.com/gin-gonic/gin"
 	_ "github.com/golang-jwt/jwt/v5"
+	"math"
 	"net/http"
+	"strconv"
 	_ "time"
 )
 
@@ -24,7 +29,7 @@ func NewAuthHandler(s services.AuthService) *AuthHandler {
 // @Produce      json
 // @Param        request body entities.RegisterRequest true "Register Request"
 // @Success      201 {object} entities.User
-// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data or weak password"
 // @Failure      500 {object} entities.ErrorResponse "Internal server error"
 // @Router       /register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -32,10 +37,15 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
 	}
 
 	user, err := h.service.RegisterUser(c.Request.Context(), req)
 	if err != nil {
+		if errors.Is(err, password.ErrWeakPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Weak password", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -58,6 +68,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Param        request body entities.LoginRequest true "User login request"
 // @Success      200 {object} entities.AuthResponse "Login successful"
 // @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Invalid email or password"
+// @Failure      403 {object} entities.ErrorResponse "User is blocked"
+// @Failure      429 {object} entities.ErrorResponse "Too many failed attempts"
 // @Failure      500 {object} entities.ErrorResponse "Internal server error"
 // @Router       /login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -65,11 +78,23 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
 	}
 
-	resp, err := h.service.Login(c.Request.Context(), req)
+	resp, err := h.service.Login(c.Request.Context(), req, helpers.ExtractClientInfo(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		var throttled *services.LoginThrottledError
+		switch {
+		case errors.As(err, &throttled):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": throttled.Error()})
+		case errors.Is(err, services.ErrInvalidCredentials):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrUserBlocked):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
 		return
 	}
 
@@ -84,6 +109,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Param        request body entities.RefreshTokenRequest true "Refresh token request"
 // @Success      200 {object} entities.AuthResponse "Tokens successfully refreshed"
 // @Failure      400 {object} entities.ErrorResponse "Invalid refresh token"
+// @Failure      403 {object} entities.ErrorResponse "User is blocked"
 // @Failure      500 {object} entities.ErrorResponse "Internal server error"
 // @Router       /refresh [post]
 func (h *AuthHandler) Refresh(c *gin.Context) {
@@ -94,8 +120,12 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	tokens, err := h.service.RefreshToken(c.Request.Context(), req.RefreshToken)
+	tokens, err := h.service.RefreshToken(c.Request.Context(), req.RefreshToken, helpers.ExtractClientInfo(c))
 	if err != nil {
+		if errors.Is(err, services.ErrUserBlocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -104,24 +134,22 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 }
 
 // @Summary      Log out user
-// @Description  Logs out the user by invalidating the session or token
+// @Description  Logs out the current device by revoking the session of the access token; other sessions stay active
 // @Tags         Authentication
-// @Accept       json
+// @Security     BearerAuth
 // @Produce      json
-// @Param        request body entities.LogoutRequest true "Logout request"
 // @Success      200 {object} map[string]interface{} "Logout successful"
-// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
 // @Failure      500 {object} entities.ErrorResponse "Internal server error"
 // @Router       /logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
-	var req entities.LogoutRequest
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
-	err := h.service.Logout(c.Request.Context(), req.UserID)
+	err = h.service.Logout(c.Request.Context(), userID, helpers.ExtractSessionID(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -129,3 +157,47 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Logout successful"})
 }
+
+// @Summary      Change password
+// @Description  Changes the authenticated user's password and revokes all active sessions
+// @Tags         Authentication
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.ChangePasswordRequest true "Change password request"
+// @Success      200 {object} entities.MessageResponse "Password changed successfully"
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data or weak password"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized or wrong current password"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/password [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req entities.ChangePasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.service.ChangePassword(c.Request.Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidCurrentPassword):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, password.ErrWeakPassword):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Weak password", "details": err.Error()})
+		case errors.Is(err, services.ErrPasswordReused):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
+}
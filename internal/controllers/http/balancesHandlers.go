@@ -0,0 +1,108 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type BalancesHandler struct {
+	service services.BalancesService
+}
+
+func NewBalancesHandler(s services.BalancesService) *BalancesHandler {
+	return &BalancesHandler{service: s}
+}
+
+// @Summary      Balance at a point in time
+// @Description  Returns the ledger balance of an account at a timestamp, computed from the latest end-of-day snapshot before it and the transactions after the snapshot. Returns the current balance by default.
+// @Tags         Balances
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path  int    true  "Account ID"
+// @Param        at query string false "Timestamp, RFC 3339"
+// @Success      200 {object} entities.AccountBalanceAt
+// @Failure      400 {object} entities.ErrorResponse "Invalid account ID or timestamp"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/accounts/{id}/balance [get]
+func (h *BalancesHandler) BalanceAt(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var at time.Time
+	if param := c.Query("at"); param != "" {
+		at, err = time.Parse(time.RFC3339, param)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timestamp, expected RFC 3339"})
+			return
+		}
+	}
+
+	balance, err := h.service.BalanceAt(c.Request.Context(), userID, uint(accountID), at)
+	if err != nil {
+		if errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrBalanceAtFuture) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+// @Summary      Run balance snapshot job
+// @Description  Stores the closing balance of every account for a finished business date. Runs for the previous business date by default. Running the same date again overwrites its snapshots with the same values.
+// @Tags         Balances
+// @Security     BearerAuth
+// @Produce      json
+// @Param        date query string false "Business date, YYYY-MM-DD"
+// @Success      200 {object} entities.BalanceSnapshotResult
+// @Failure      400 {object} entities.ErrorResponse "Invalid date or the date is not over yet"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/balances/snapshot [post]
+func (h *BalancesHandler) Snapshot(c *gin.Context) {
+	var date time.Time
+	if param := c.Query("date"); param != "" {
+		parsed, err := time.Parse(entities.BusinessDateLayout, param)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		date = parsed
+	}
+
+	result, err := h.service.SnapshotDay(c.Request.Context(), date)
+	if err != nil {
+		if errors.Is(err, services.ErrBusinessDateNotOver) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,259 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type CardsHandler struct {
+	service services.CardsService
+}
+
+func NewCardsHandler(s services.CardsService) *CardsHandler {
+	return &CardsHandler{service: s}
+}
+
+// @Summary      List cards
+// @Description  Returns the virtual cards of an account with masked numbers
+// @Tags         Cards
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Account ID"
+// @Success      200 {array} entities.CardResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid account ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/accounts/{id}/cards [get]
+func (h *CardsHandler) List(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	cards, err := h.service.List(c.Request.Context(), userID, uint(accountID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	response := make([]*entities.CardResponse, 0, len(cards))
+	for _, card := range cards {
+		response = append(response, card.ToResponse())
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Issue card
+// @Description  Issues a virtual debit card on an account to the current user. The full card number and CVV are returned only in this response.
+// @Tags         Cards
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                       true "Account ID"
+// @Param        request body entities.IssueCardRequest true "Card"
+// @Success      201 {object} entities.IssuedCardResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed for the account role or above the spend limit"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      409 {object} entities.ErrorResponse "Account status does not allow the operation"
+// @Router       /auth/accounts/{id}/cards [post]
+func (h *CardsHandler) Issue(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var req entities.IssueCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	issued, err := h.service.Issue(c.Request.Context(), userID, uint(accountID), &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, issued)
+}
+
+// @Summary      Get card
+// @Description  Returns a card with a masked number
+// @Tags         Cards
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Card ID"
+// @Success      200 {object} entities.CardResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid card ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Card not found"
+// @Router       /auth/cards/{id} [get]
+func (h *CardsHandler) Get(c *gin.Context) {
+	h.handleCard(c, h.service.Get)
+}
+
+// @Summary      Freeze card
+// @Description  Temporarily blocks card payments. Available to the cardholder and account managers.
+// @Tags         Cards
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Card ID"
+// @Success      200 {object} entities.CardResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid card ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not the cardholder or an account manager"
+// @Failure      404 {object} entities.ErrorResponse "Card not found"
+// @Failure      409 {object} entities.ErrorResponse "Card is not active"
+// @Router       /auth/cards/{id}/freeze [post]
+func (h *CardsHandler) Freeze(c *gin.Context) {
+	h.handleCard(c, h.service.Freeze)
+}
+
+// @Summary      Unfreeze card
+// @Description  Allows payments with a frozen card again
+// @Tags         Cards
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Card ID"
+// @Success      200 {object} entities.CardResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid card ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not the cardholder or an account manager"
+// @Failure      404 {object} entities.ErrorResponse "Card not found"
+// @Failure      409 {object} entities.ErrorResponse "Card is not frozen"
+// @Router       /auth/cards/{id}/unfreeze [post]
+func (h *CardsHandler) Unfreeze(c *gin.Context) {
+	h.handleCard(c, h.service.Unfreeze)
+}
+
+// @Summary      Cancel card
+// @Description  Permanently cancels a card. Payments already authorized stay on hold.
+// @Tags         Cards
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Card ID"
+// @Success      200 {object} entities.CardResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid card ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not the cardholder or an account manager"
+// @Failure      404 {object} entities.ErrorResponse "Card not found"
+// @Failure      409 {object} entities.ErrorResponse "Card is already cancelled"
+// @Router       /auth/cards/{id} [delete]
+func (h *CardsHandler) Cancel(c *gin.Context) {
+	h.handleCard(c, h.service.Cancel)
+}
+
+// @Summary      Set card limits
+// @Description  Sets the per-transaction and daily spending limits of a card. Account transfer limits still apply.
+// @Tags         Cards
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                        true "Card ID"
+// @Param        request body entities.CardLimitsRequest true "Limits"
+// @Success      200 {object} entities.CardResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not the cardholder or an account manager, or above the holder's spend limit"
+// @Failure      404 {object} entities.ErrorResponse "Card not found"
+// @Failure      409 {object} entities.ErrorResponse "Card is cancelled"
+// @Router       /auth/cards/{id}/limits [put]
+func (h *CardsHandler) SetLimits(c *gin.Context) {
+	var req entities.CardLimitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	h.handleCard(c, func(ctx context.Context, userID, cardID uint) (*entities.Card, error) {
+		return h.service.SetLimits(ctx, userID, cardID, &req)
+	})
+}
+
+// @Summary      Authorize card payment
+// @Description  Called by the card network (or a local simulator) with the X-Network-Key header. Approves the payment and places a hold on the account, or declines it with a reason.
+// @Tags         Cards
+// @Accept       json
+// @Produce      json
+// @Param        X-Network-Key header string                            true "Card network key"
+// @Param        request       body   entities.CardAuthorizationRequest true "Authorization"
+// @Success      200 {object} entities.CardAuthorizationResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Invalid network key"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /network/cards/authorize [post]
+func (h *CardsHandler) Authorize(c *gin.Context) {
+	var req entities.CardAuthorizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	result, err := h.service.Authorize(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *CardsHandler) handleCard(c *gin.Context, action func(ctx context.Context, userID, cardID uint) (*entities.Card, error)) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	cardID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid card ID"})
+		return
+	}
+
+	card, err := action(c.Request.Context(), userID, uint(cardID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card.ToResponse())
+}
+
+func (h *CardsHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAccountNotFound), errors.Is(err, services.ErrCardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAccountForbidden), errors.Is(err, services.ErrSpendLimitExceeded):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAccountInactive), errors.Is(err, services.ErrInvalidCardStatusChange):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidCardLimits):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
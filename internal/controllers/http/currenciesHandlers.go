@@ -0,0 +1,25 @@
+package http
+
+import (
+	"bank-app-backend/internal/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type CurrenciesHandler struct {
+	service services.CurrenciesService
+}
+
+func NewCurrenciesHandler(s services.CurrenciesService) *CurrenciesHandler {
+	return &CurrenciesHandler{service: s}
+}
+
+// @Summary      List currencies
+// @Description  Returns the ISO 4217 currencies in which accounts can be opened, with their minor units
+// @Tags         Currencies
+// @Produce      json
+// @Success      200 {array} entities.Currency
+// @Router       /currencies [get]
+func (h *CurrenciesHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.List())
+}
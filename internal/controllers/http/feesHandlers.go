@@ -0,0 +1,137 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type FeesHandler struct {
+	service services.FeesService
+}
+
+func NewFeesHandler(s services.FeesService) *FeesHandler {
+	return &FeesHandler{service: s}
+}
+
+// @Summary      Preview fee
+// @Description  Returns the fee for a transfer or withdrawal from an account and the total that would be taken from it
+// @Tags         Fees
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.FeePreviewRequest true "Operation"
+// @Success      200 {object} entities.FeeQuote
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/fees/preview [post]
+func (h *FeesHandler) Preview(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req entities.FeePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	quote, err := h.service.Preview(c.Request.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// @Summary      List fee rules
+// @Description  Returns all fee rules
+// @Tags         Fees
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} entities.FeeRule
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/fees [get]
+func (h *FeesHandler) Rules(c *gin.Context) {
+	rules, err := h.service.Rules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Summary      Set fee rule
+// @Description  Creates or replaces the fee rule for a transfer type, account product and currency. Empty fields match any value; the most specific matching rule is applied.
+// @Tags         Fees
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.SetFeeRuleRequest true "Fee rule"
+// @Success      200 {object} entities.FeeRule
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Router       /admin/fees [put]
+func (h *FeesHandler) SetRule(c *gin.Context) {
+	var req entities.SetFeeRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	rule, err := h.service.SetRule(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary      Delete fee rule
+// @Description  Deletes a fee rule
+// @Tags         Fees
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Rule ID"
+// @Success      200 {object} entities.MessageResponse "Rule deleted"
+// @Failure      400 {object} entities.ErrorResponse "Invalid rule ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "Rule not found"
+// @Router       /admin/fees/{id} [delete]
+func (h *FeesHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := h.service.DeleteRule(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, services.ErrFeeRuleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Rule deleted"})
+}
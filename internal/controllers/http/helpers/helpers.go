@@ -23,6 +23,54 @@ func ExtractUserID(c *gin.Context) (uint, error) {
 	return userIDUint, nil
 }
 
+// ExtractSessionID извлекает id текущей сессии из контекста запроса
+func ExtractSessionID(c *gin.Context) string {
+	return c.GetString("sessionID")
+}
+
+// ExtractClientInfo извлекает IP и User-Agent клиента из запроса
+func ExtractClientInfo(c *gin.Context) entities.ClientInfo {
+	return entities.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// BuildUserFilter подготавливает фильтр для поиска пользователей
+func BuildUserFilter(c *gin.Context) *entities.UserFilter {
+	filter := &entities.UserFilter{
+		Query:  c.Query("query"),
+		SortBy: c.DefaultQuery("sortBy", "id"),
+		Desc:   c.Query("order") == "desc",
+		Page:   1,
+		Limit:  20,
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filter.Page = page
+		}
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			filter.Limit = limit
+		}
+	}
+
+	if role := c.Query("role"); role != "" {
+		r := entities.Role(role)
+		filter.Role = &r
+	}
+
+	if blockedStr := c.Query("blocked"); blockedStr != "" {
+		if blocked, err := strconv.ParseBool(blockedStr); err == nil {
+			filter.Blocked = &blocked
+		}
+	}
+
+	return filter
+}
+
 // BuildTransactionFilter подтготавливает фильтр для транзацкии
 func BuildTransactionFilter(c *gin.Context, userID uint) *entities.TransactionFilter {
 	filter := &entities.TransactionFilter{
@@ -72,3 +120,83 @@ func BuildTransactionFilter(c *gin.Context, userID uint) *entities.TransactionFi
 
 	return filter
 }
+
+// BuildAuditFilter подготавливает фильтр для поиска по журналу аудита
+func BuildAuditFilter(c *gin.Context) *entities.AuditFilter {
+	filter := &entities.AuditFilter{
+		ResourceType: c.Query("resourceType"),
+		ResourceID:   c.Query("resourceId"),
+		RequestID:    c.Query("requestId"),
+		Page:         1,
+		Limit:        50,
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filter.Page = page
+		}
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			filter.Limit = limit
+		}
+	}
+
+	if actorStr := c.Query("actorId"); actorStr != "" {
+		if actorID, err := strconv.ParseUint(actorStr, 10, 64); err == nil {
+			id := uint(actorID)
+			filter.ActorID = &id
+		}
+	}
+
+	if action := c.Query("action"); action != "" {
+		a := entities.AuditAction(action)
+		filter.Action = &a
+	}
+
+	if result := c.Query("result"); result != "" {
+		r := entities.AuditResult(result)
+		filter.Result = &r
+	}
+
+	if fromStr := c.Query("fromDate"); fromStr != "" {
+		if fromTime, err := time.Parse("2006-01-02", fromStr); err == nil {
+			filter.FromDate = &fromTime
+		}
+	}
+
+	if toStr := c.Query("toDate"); toStr != "" {
+		if toTime, err := time.Parse("2006-01-02", toStr); err == nil {
+			end := toTime.AddDate(0, 0, 1)
+			filter.ToDate = &end
+		}
+	}
+
+	return filter
+}
+
+// BuildNotificationFilter подготавливает фильтр для списка уведомлений
+func BuildNotificationFilter(c *gin.Context, userID uint) *entities.NotificationFilter {
+	filter := &entities.NotificationFilter{
+		UserID: userID,
+		Page:   1,
+		Limit:  20,
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filter.Page = page
+		}
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			filter.Limit = limit
+		}
+	}
+
+	if unread, err := strconv.ParseBool(c.Query("unread")); err == nil {
+		filter.UnreadOnly = unread
+	}
+
+	return filter
+}
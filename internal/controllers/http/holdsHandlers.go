@@ -0,0 +1,200 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type HoldsHandler struct {
+	service services.HoldsService
+}
+
+func NewHoldsHandler(s services.HoldsService) *HoldsHandler {
+	return &HoldsHandler{service: s}
+}
+
+// @Summary      List holds
+// @Description  Returns the holds of an account, newest first
+// @Tags         Holds
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id     path  int    true  "Account ID"
+// @Param        status query string false "Filter by status" Enums(active, captured, released, expired)
+// @Success      200 {array} entities.Hold
+// @Failure      400 {object} entities.ErrorResponse "Invalid account ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/accounts/{id}/holds [get]
+func (h *HoldsHandler) List(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	holds, err := h.service.List(c.Request.Context(), userID, uint(accountID), entities.HoldStatus(c.Query("status")))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, holds)
+}
+
+// @Summary      Create hold
+// @Description  Reserves money on an account without moving it. The held amount is excluded from the available balance until the hold is captured, released or expires. Counts towards transfer limits.
+// @Tags         Holds
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                        true "Account ID"
+// @Param        request body entities.CreateHoldRequest true "Hold"
+// @Success      201 {object} entities.Hold
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data, amount precision or insufficient funds"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed for the account role or spend limit"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      409 {object} entities.ErrorResponse "Account status does not allow the operation"
+// @Failure      422 {object} entities.LimitExceededResponse "Transfer limit exceeded"
+// @Router       /auth/accounts/{id}/holds [post]
+func (h *HoldsHandler) Create(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var req entities.CreateHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	hold, err := h.service.Create(c.Request.Context(), userID, uint(accountID), &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, hold)
+}
+
+// @Summary      Capture hold
+// @Description  Takes all or part of the held money from the account as a capture transaction, plus the capture fee. The rest of the hold is released. Card holds cannot be captured here.
+// @Tags         Holds
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                         true  "Hold ID"
+// @Param        request body entities.CaptureHoldRequest false "Amount to capture"
+// @Success      200 {object} entities.Hold
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data, amount exceeds the hold or insufficient funds for the fee"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed for the account role"
+// @Failure      404 {object} entities.ErrorResponse "Hold not found"
+// @Failure      409 {object} entities.ErrorResponse "Hold is not active, has expired or is a card hold"
+// @Failure      422 {object} entities.LimitExceededResponse "Transfer limit exceeded"
+// @Router       /auth/holds/{id}/capture [post]
+func (h *HoldsHandler) Capture(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	holdID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hold ID"})
+		return
+	}
+
+	var req entities.CaptureHoldRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+			return
+		}
+	}
+
+	hold, err := h.service.Capture(c.Request.Context(), userID, uint(holdID), req.Amount)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hold)
+}
+
+// @Summary      Release hold
+// @Description  Cancels a hold and returns the money to the available balance
+// @Tags         Holds
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Hold ID"
+// @Success      200 {object} entities.Hold
+// @Failure      400 {object} entities.ErrorResponse "Invalid hold ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed for the account role"
+// @Failure      404 {object} entities.ErrorResponse "Hold not found"
+// @Failure      409 {object} entities.ErrorResponse "Hold is not active or is a card hold"
+// @Router       /auth/holds/{id}/release [post]
+func (h *HoldsHandler) Release(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	holdID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hold ID"})
+		return
+	}
+
+	hold, err := h.service.Release(c.Request.Context(), userID, uint(holdID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hold)
+}
+
+func (h *HoldsHandler) writeError(c *gin.Context, err error) {
+	if writeLimitExceeded(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrAccountNotFound), errors.Is(err, services.ErrHoldNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAccountForbidden), errors.Is(err, services.ErrSpendLimitExceeded):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrHoldNotActive), errors.Is(err, services.ErrHoldExpired),
+		errors.Is(err, services.ErrAccountInactive), errors.Is(err, services.ErrCardHold):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInsufficientFunds), errors.Is(err, services.ErrCaptureExceedsHold),
+		errors.Is(err, services.ErrHoldTTLTooLong), errors.Is(err, services.ErrInvalidAmountPrecision):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type InterestHandler struct {
+	service services.InterestService
+}
+
+func NewInterestHandler(s services.InterestService) *InterestHandler {
+	return &InterestHandler{service: s}
+}
+
+// @Summary      Account interest
+// @Description  Returns the interest rate and day-count convention of an account and the interest accrued since the last capitalization
+// @Tags         Interest
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Account ID"
+// @Success      200 {object} entities.AccountInterest
+// @Failure      400 {object} entities.ErrorResponse "Invalid account ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Account not found or does not earn interest"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/accounts/{id}/interest [get]
+func (h *InterestHandler) Get(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	interest, err := h.service.AccountInterest(c.Request.Context(), userID, uint(accountID))
+	if err != nil {
+		if errors.Is(err, services.ErrAccountNotFound) || errors.Is(err, services.ErrNoInterest) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, interest)
+}
+
+// @Summary      Run interest job
+// @Description  Accrues interest for a finished business date and capitalizes it if the date is the last day of a month. Runs for the previous business date by default. Running the same date again changes nothing.
+// @Tags         Interest
+// @Security     BearerAuth
+// @Produce      json
+// @Param        date query string false "Business date, YYYY-MM-DD"
+// @Success      200 {object} entities.InterestRunResult
+// @Failure      400 {object} entities.ErrorResponse "Invalid date or the date is not over yet"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/interest/run [post]
+func (h *InterestHandler) Run(c *gin.Context) {
+	var date time.Time
+	if param := c.Query("date"); param != "" {
+		parsed, err := time.Parse(entities.BusinessDateLayout, param)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		date = parsed
+	}
+
+	result, err := h.service.RunDaily(c.Request.Context(), date)
+	if err != nil {
+		if errors.Is(err, services.ErrBusinessDateNotOver) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
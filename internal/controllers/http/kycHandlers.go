@@ -0,0 +1,157 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type KYCHandler struct {
+	service services.KYCService
+}
+
+func NewKYCHandler(s services.KYCService) *KYCHandler {
+	return &KYCHandler{service: s}
+}
+
+// @Summary      Get KYC status
+// @Description  Returns the authenticated user's KYC profile, status, tier and tier limits
+// @Tags         KYC
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} entities.KYCResponse
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/kyc [get]
+func (h *KYCHandler) Get(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.service.Get(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary      Submit KYC profile
+// @Description  Submits identity data for verification. Resubmission is allowed after a rejection.
+// @Tags         KYC
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.SubmitKYCRequest true "KYC data"
+// @Success      200 {object} entities.KYCResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      409 {object} entities.ErrorResponse "Profile is already pending or verified"
+// @Router       /auth/kyc [put]
+func (h *KYCHandler) Submit(c *gin.Context) {
+	var req entities.SubmitKYCRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.service.Submit(c.Request.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrKYCInvalidTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary      List KYC profiles
+// @Description  Returns KYC profiles, optionally filtered by status (e.g. the pending review queue)
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status query string false "KYC status (pending, verified, rejected)"
+// @Success      200 {array} entities.KYCResponse
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/kyc [get]
+func (h *KYCHandler) List(c *gin.Context) {
+	status := entities.KYCStatus(c.Query("status"))
+
+	resp, err := h.service.GetByStatus(c.Request.Context(), status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary      Review KYC profile
+// @Description  Moves a user's KYC profile to another status (verified, rejected or back to pending)
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        userId  path int                      true "User ID"
+// @Param        request body entities.ReviewKYCRequest true "Review decision"
+// @Success      200 {object} entities.KYCResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "KYC profile not found"
+// @Failure      409 {object} entities.ErrorResponse "Invalid status transition"
+// @Router       /admin/kyc/{userId}/status [patch]
+func (h *KYCHandler) Review(c *gin.Context) {
+	var req entities.ReviewKYCRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	reviewerID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.service.Review(c.Request.Context(), reviewerID, uint(userID), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrKYCNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrKYCInvalidTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// limitExceededCode — код ошибки в ответе, по которому клиент отличает превышение лимита
+const limitExceededCode = "limit_exceeded"
+
+type LimitsHandler struct {
+	service services.LimitsService
+}
+
+func NewLimitsHandler(s services.LimitsService) *LimitsHandler {
+	return &LimitsHandler{service: s}
+}
+
+// @Summary      Transfer limits
+// @Description  Returns the effective transfer limits of the authenticated user with used and remaining amounts for the current day and month. With account_id the rules for the account type are included.
+// @Tags         Limits
+// @Security     BearerAuth
+// @Produce      json
+// @Param        account_id query int false "Account ID"
+// @Success      200 {object} entities.LimitsStatus
+// @Failure      400 {object} entities.ErrorResponse "Invalid account ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/limits [get]
+func (h *LimitsHandler) Status(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var accountID uint64
+	if param := c.Query("account_id"); param != "" {
+		accountID, err = strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+			return
+		}
+	}
+
+	status, err := h.service.Status(c.Request.Context(), userID, uint(accountID))
+	if err != nil {
+		if errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// @Summary      List limit rules
+// @Description  Returns all transfer limit rules for KYC tiers, account types and users
+// @Tags         Limits
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} entities.LimitRule
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/limits [get]
+func (h *LimitsHandler) Rules(c *gin.Context) {
+	rules, err := h.service.Rules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Summary      Set limit rule
+// @Description  Creates or replaces the limit rule for a scope and value. Rules are applied from tier to account type to user; a more specific rule overrides the fields it sets.
+// @Tags         Limits
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.SetLimitRuleRequest true "Limit rule"
+// @Success      200 {object} entities.LimitRule
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Router       /admin/limits [put]
+func (h *LimitsHandler) SetRule(c *gin.Context) {
+	var req entities.SetLimitRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	rule, err := h.service.SetRule(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary      Delete limit rule
+// @Description  Deletes a transfer limit rule
+// @Tags         Limits
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Rule ID"
+// @Success      200 {object} entities.MessageResponse "Rule deleted"
+// @Failure      400 {object} entities.ErrorResponse "Invalid rule ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "Rule not found"
+// @Router       /admin/limits/{id} [delete]
+func (h *LimitsHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := h.service.DeleteRule(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, services.ErrLimitRuleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Rule deleted"})
+}
+
+// writeLimitExceeded отвечает 422 с кодом limit_exceeded, если err — превышение лимита
+func writeLimitExceeded(c *gin.Context, err error) bool {
+	var limitErr *services.LimitExceededError
+	if !errors.As(err, &limitErr) {
+		return false
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"error":     err.Error(),
+		"code":      limitExceededCode,
+		"limit":     limitErr.Limit,
+		"max":       limitErr.Max,
+		"remaining": limitErr.Remaining,
+	})
+	return true
+}
@@ -0,0 +1,286 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type LoansHandler struct {
+	service services.LoansService
+}
+
+func NewLoansHandler(s services.LoansService) *LoansHandler {
+	return &LoansHandler{service: s}
+}
+
+// @Summary      Apply for a loan
+// @Description  Creates a loan application at the current interest rate. Once approved by an admin, the amount is disbursed into the account and repaid from it by a monthly annuity or linear schedule. Requires the manage permission on the account.
+// @Tags         Loans
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.LoanApplicationRequest true "Loan application"
+// @Success      201 {object} entities.Loan
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data, amount or term"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed to manage the account"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      409 {object} entities.ErrorResponse "Account status does not allow credits"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/loans [post]
+func (h *LoansHandler) Apply(c *gin.Context) {
+	var req entities.LoanApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	loan, err := h.service.Apply(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, loan)
+}
+
+// @Summary      List loans
+// @Description  Returns the current user's loans and applications, newest first
+// @Tags         Loans
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} entities.Loan
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/loans [get]
+func (h *LoansHandler) List(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	loans, err := h.service.List(c.Request.Context(), userID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loans)
+}
+
+// @Summary      Get loan
+// @Description  Returns a loan of the current user with its repayment schedule
+// @Tags         Loans
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Loan ID"
+// @Success      200 {object} entities.Loan
+// @Failure      400 {object} entities.ErrorResponse "Invalid loan ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Loan not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/loans/{id} [get]
+func (h *LoansHandler) Get(c *gin.Context) {
+	userID, loanID, ok := h.params(c)
+	if !ok {
+		return
+	}
+
+	loan, err := h.service.Get(c.Request.Context(), userID, loanID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+// @Summary      Loan balance
+// @Description  Returns the outstanding principal, overdue amount, late fees and the next scheduled payment of a loan
+// @Tags         Loans
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Loan ID"
+// @Success      200 {object} entities.LoanBalance
+// @Failure      400 {object} entities.ErrorResponse "Invalid loan ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Loan not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/loans/{id}/balance [get]
+func (h *LoansHandler) Balance(c *gin.Context) {
+	userID, loanID, ok := h.params(c)
+	if !ok {
+		return
+	}
+
+	balance, err := h.service.Balance(c.Request.Context(), userID, loanID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+// @Summary      List loan applications
+// @Description  Returns loans by status, oldest first. Returns pending applications by default.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status query string false "Loan status (pending, rejected, active, repaid)"
+// @Success      200 {array} entities.Loan
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/loans [get]
+func (h *LoansHandler) Applications(c *gin.Context) {
+	loans, err := h.service.Applications(c.Request.Context(), entities.LoanStatus(c.Query("status")))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loans)
+}
+
+// @Summary      Approve loan
+// @Description  Approves a pending application: disburses the amount into the account as a loan_disbursement transaction and builds the repayment schedule
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                       true  "Loan ID"
+// @Param        request body entities.ReviewLoanRequest false "Review note"
+// @Success      200 {object} entities.Loan
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "Loan not found"
+// @Failure      409 {object} entities.ErrorResponse "Application already reviewed or account status does not allow credits"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/loans/{id}/approve [post]
+func (h *LoansHandler) Approve(c *gin.Context) {
+	h.review(c, h.service.Approve)
+}
+
+// @Summary      Reject loan
+// @Description  Rejects a pending application; a note with the reason is required
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                       true "Loan ID"
+// @Param        request body entities.ReviewLoanRequest true "Rejection reason"
+// @Success      200 {object} entities.Loan
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data or missing note"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "Loan not found"
+// @Failure      409 {object} entities.ErrorResponse "Application already reviewed"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/loans/{id}/reject [post]
+func (h *LoansHandler) Reject(c *gin.Context) {
+	h.review(c, h.service.Reject)
+}
+
+// @Summary      Run loan repayments
+// @Description  Debits installments due on or before a finished business date from the loan accounts. Installments the account cannot cover become overdue and are retried on later runs; a late fee is added once after the grace period. Runs for the previous business date by default.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        date query string false "Business date, YYYY-MM-DD"
+// @Success      200 {object} entities.LoanRepaymentResult
+// @Failure      400 {object} entities.ErrorResponse "Invalid date or the date is not over yet"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/loans/repayments/run [post]
+func (h *LoansHandler) RunRepayments(c *gin.Context) {
+	var date time.Time
+	if param := c.Query("date"); param != "" {
+		parsed, err := time.Parse(entities.BusinessDateLayout, param)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		date = parsed
+	}
+
+	result, err := h.service.RunRepayments(c.Request.Context(), date)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *LoansHandler) review(c *gin.Context, decide func(ctx context.Context, reviewerID, loanID uint, req *entities.ReviewLoanRequest) (*entities.Loan, error)) {
+	reviewerID, loanID, ok := h.params(c)
+	if !ok {
+		return
+	}
+
+	var req entities.ReviewLoanRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+			return
+		}
+	}
+
+	loan, err := decide(c.Request.Context(), reviewerID, loanID, &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+// params извлекает пользователя из токена и ID кредита из пути
+func (h *LoansHandler) params(c *gin.Context) (uint, uint, bool) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return 0, 0, false
+	}
+
+	loanID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return 0, 0, false
+	}
+	return userID, uint(loanID), true
+}
+
+func (h *LoansHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAccountNotFound), errors.Is(err, services.ErrLoanNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAccountForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAccountInactive), errors.Is(err, services.ErrLoanNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrLoanAmountInvalid), errors.Is(err, services.ErrInvalidAmountPrecision),
+		errors.Is(err, services.ErrLoanTermInvalid),
+		errors.Is(err, services.ErrLoanNoteRequired), errors.Is(err, services.ErrBusinessDateNotOver):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type NotificationsHandler struct {
+	service services.NotificationsService
+}
+
+func NewNotificationsHandler(s services.NotificationsService) *NotificationsHandler {
+	return &NotificationsHandler{service: s}
+}
+
+// @Summary      List notifications
+// @Description  Returns the authenticated user's in-app notifications, newest first
+// @Tags         Notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Param        unread query bool false "Only unread notifications"
+// @Param        page   query int  false "Page number"
+// @Param        limit  query int  false "Page size (max 100)"
+// @Success      200 {object} entities.NotificationsPage
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/notifications [get]
+func (h *NotificationsHandler) List(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.List(c.Request.Context(), helpers.BuildNotificationFilter(c, userID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary      Mark notification as read
+// @Description  Marks one of the authenticated user's notifications as read
+// @Tags         Notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Notification ID"
+// @Success      200 {object} entities.MessageResponse "Notification marked as read"
+// @Failure      400 {object} entities.ErrorResponse "Invalid notification ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Notification not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/notifications/{id}/read [post]
+func (h *NotificationsHandler) MarkRead(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	if err := h.service.MarkRead(c.Request.Context(), userID, uint(id)); err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+// @Summary      Mark all notifications as read
+// @Description  Marks all of the authenticated user's notifications as read
+// @Tags         Notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} entities.MessageResponse "All notifications marked as read"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/notifications/read-all [post]
+func (h *NotificationsHandler) MarkAllRead(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.MarkAllRead(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "All notifications marked as read"})
+}
+
+// @Summary      Get notification preferences
+// @Description  Returns which channels are enabled for each event type, including defaults
+// @Tags         Notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} entities.NotificationPreferencesResponse
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/notifications/preferences [get]
+func (h *NotificationsHandler) GetPreferences(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	prefs, err := h.service.Preferences(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// @Summary      Update notification preferences
+// @Description  Enables or disables delivery channels per event type
+// @Tags         Notifications
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.UpdateNotificationPreferencesRequest true "Preferences to change"
+// @Success      200 {object} entities.NotificationPreferencesResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/notifications/preferences [put]
+func (h *NotificationsHandler) UpdatePreferences(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req entities.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	prefs, err := h.service.UpdatePreferences(c.Request.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidNotificationPref) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
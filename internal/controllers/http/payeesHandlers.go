@@ -0,0 +1,200 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type PayeesHandler struct {
+	service services.PayeesService
+}
+
+func NewPayeesHandler(s services.PayeesService) *PayeesHandler {
+	return &PayeesHandler{service: s}
+}
+
+// @Summary      List payees
+// @Description  Returns the authenticated user's saved beneficiaries
+// @Tags         Payees
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} entities.Payee
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/payees [get]
+func (h *PayeesHandler) List(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	payees, err := h.service.List(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payees)
+}
+
+// @Summary      Get payee
+// @Description  Returns a saved beneficiary by ID
+// @Tags         Payees
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Payee ID"
+// @Success      200 {object} entities.Payee
+// @Failure      400 {object} entities.ErrorResponse "Invalid payee ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Payee not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/payees/{id} [get]
+func (h *PayeesHandler) Get(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payee ID"})
+		return
+	}
+
+	payee, err := h.service.Get(c.Request.Context(), userID, uint(id))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payee)
+}
+
+// @Summary      Create payee
+// @Description  Saves a beneficiary. The account must exist, be open and use the given currency.
+// @Tags         Payees
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body entities.CreatePayeeRequest true "Payee"
+// @Success      201 {object} entities.Payee
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Account not found, closed or in another currency"
+// @Failure      409 {object} entities.ErrorResponse "Payee already exists"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/payees [post]
+func (h *PayeesHandler) Create(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req entities.CreatePayeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	payee, err := h.service.Create(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, payee)
+}
+
+// @Summary      Rename payee
+// @Description  Changes the nickname of a saved beneficiary
+// @Tags         Payees
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                         true "Payee ID"
+// @Param        request body entities.UpdatePayeeRequest true "New nickname"
+// @Success      200 {object} entities.Payee
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Payee not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/payees/{id} [patch]
+func (h *PayeesHandler) Update(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payee ID"})
+		return
+	}
+
+	var req entities.UpdatePayeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	payee, err := h.service.Update(c.Request.Context(), userID, uint(id), &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payee)
+}
+
+// @Summary      Delete payee
+// @Description  Removes a saved beneficiary
+// @Tags         Payees
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Payee ID"
+// @Success      200 {object} entities.MessageResponse "Payee deleted"
+// @Failure      400 {object} entities.ErrorResponse "Invalid payee ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Payee not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/payees/{id} [delete]
+func (h *PayeesHandler) Delete(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payee ID"})
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), userID, uint(id)); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Payee deleted"})
+}
+
+func (h *PayeesHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrPayeeNotFound), errors.Is(err, services.ErrPayeeAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPayeeExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,250 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type PotsHandler struct {
+	service services.PotsService
+}
+
+func NewPotsHandler(s services.PotsService) *PotsHandler {
+	return &PotsHandler{service: s}
+}
+
+// @Summary      List pots
+// @Description  Returns the savings pots of an account
+// @Tags         Pots
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Account ID"
+// @Success      200 {array} entities.Pot
+// @Failure      400 {object} entities.ErrorResponse "Invalid account ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/accounts/{id}/pots [get]
+func (h *PotsHandler) List(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	pots, err := h.service.List(c.Request.Context(), userID, uint(accountID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pots)
+}
+
+// @Summary      Create pot
+// @Description  Creates a savings pot in an account with an optional target and saving rules. Only one pot of an account can have round-ups; enabling them turns them off in the other pots.
+// @Tags         Pots
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                       true "Account ID"
+// @Param        request body entities.CreatePotRequest true "Pot"
+// @Success      201 {object} entities.Pot
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed for the account role"
+// @Failure      404 {object} entities.ErrorResponse "Account not found"
+// @Failure      409 {object} entities.ErrorResponse "Account is closed"
+// @Router       /auth/accounts/{id}/pots [post]
+func (h *PotsHandler) Create(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var req entities.CreatePotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	pot, err := h.service.Create(c.Request.Context(), userID, uint(accountID), &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, pot)
+}
+
+// @Summary      Update pot
+// @Description  Changes the name, target or saving rules of a pot
+// @Tags         Pots
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                       true "Pot ID"
+// @Param        request body entities.UpdatePotRequest true "Changes"
+// @Success      200 {object} entities.Pot
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed for the account role"
+// @Failure      404 {object} entities.ErrorResponse "Pot not found"
+// @Router       /auth/pots/{id} [patch]
+func (h *PotsHandler) Update(c *gin.Context) {
+	userID, potID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req entities.UpdatePotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	pot, err := h.service.Update(c.Request.Context(), userID, potID, &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pot)
+}
+
+// @Summary      Delete pot
+// @Description  Deletes a pot. Its money stays on the account and becomes available again.
+// @Tags         Pots
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Pot ID"
+// @Success      200 {object} entities.MessageResponse "Pot deleted"
+// @Failure      400 {object} entities.ErrorResponse "Invalid pot ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed for the account role"
+// @Failure      404 {object} entities.ErrorResponse "Pot not found"
+// @Router       /auth/pots/{id} [delete]
+func (h *PotsHandler) Delete(c *gin.Context) {
+	userID, potID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), userID, potID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Pot deleted"})
+}
+
+// @Summary      Move money into pot
+// @Description  Sets money aside from the available balance of the account into the pot. Overdraft money cannot be put into a pot.
+// @Tags         Pots
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                         true "Pot ID"
+// @Param        request body entities.PotTransferRequest true "Amount"
+// @Success      200 {object} entities.Pot
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data, amount precision or insufficient funds"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed for the account role"
+// @Failure      404 {object} entities.ErrorResponse "Pot not found"
+// @Failure      409 {object} entities.ErrorResponse "Account status does not allow the operation"
+// @Router       /auth/pots/{id}/deposit [post]
+func (h *PotsHandler) Deposit(c *gin.Context) {
+	h.move(c, h.service.Deposit)
+}
+
+// @Summary      Move money out of pot
+// @Description  Returns money from the pot to the available balance of the account
+// @Tags         Pots
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                         true "Pot ID"
+// @Param        request body entities.PotTransferRequest true "Amount"
+// @Success      200 {object} entities.Pot
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data, amount precision or not enough money in the pot"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Not allowed for the account role"
+// @Failure      404 {object} entities.ErrorResponse "Pot not found"
+// @Failure      409 {object} entities.ErrorResponse "Account status does not allow the operation"
+// @Router       /auth/pots/{id}/withdraw [post]
+func (h *PotsHandler) Withdraw(c *gin.Context) {
+	h.move(c, h.service.Withdraw)
+}
+
+func (h *PotsHandler) move(c *gin.Context, move func(ctx context.Context, userID, potID uint, amount float64) (*entities.Pot, error)) {
+	userID, potID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req entities.PotTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	pot, err := move(c.Request.Context(), userID, potID, req.Amount)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pot)
+}
+
+// parseRequest извлекает пользователя и ID копилки; при ошибке ответ уже записан
+func (h *PotsHandler) parseRequest(c *gin.Context) (uint, uint, bool) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return 0, 0, false
+	}
+
+	potID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pot ID"})
+		return 0, 0, false
+	}
+
+	return userID, uint(potID), true
+}
+
+func (h *PotsHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAccountNotFound), errors.Is(err, services.ErrPotNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAccountForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAccountInactive):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInsufficientFunds), errors.Is(err, services.ErrPotInsufficientFunds),
+		errors.Is(err, services.ErrPotTargetDatePast), errors.Is(err, services.ErrInvalidAmountPrecision):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
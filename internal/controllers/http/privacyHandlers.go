@@ -0,0 +1,148 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type PrivacyHandler struct {
+	service services.PrivacyService
+}
+
+func NewPrivacyHandler(s services.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{service: s}
+}
+
+// @Summary      Export personal data
+// @Description  Returns everything stored about the authenticated user as one JSON archive
+// @Tags         Privacy
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} entities.PersonalDataExport
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/me/export [get]
+func (h *PrivacyHandler) Export(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	export, err := h.service.Export(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%d-export.json"`, userID))
+	c.JSON(http.StatusOK, export)
+}
+
+// @Summary      Request erasure
+// @Description  Requests erasure of the authenticated user's personal data. Accounts must hold no funds.
+// @Tags         Privacy
+// @Security     BearerAuth
+// @Produce      json
+// @Success      202 {object} entities.ErasureRequest
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      409 {object} entities.ErrorResponse "Request already pending or accounts hold funds, holds, cards or loans"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/me/erasure [post]
+func (h *PrivacyHandler) RequestErasure(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	req, err := h.service.RequestErasure(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, services.ErrErasureAlreadyPending) || errors.Is(err, services.ErrErasureNotAllowed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, req)
+}
+
+// @Summary      List erasure requests
+// @Description  Returns erasure requests, optionally filtered by status
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status query string false "Status (requested, completed, rejected)"
+// @Success      200 {array} entities.ErasureRequest
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/erasure-requests [get]
+func (h *PrivacyHandler) ListErasureRequests(c *gin.Context) {
+	reqs, err := h.service.GetErasureRequests(c.Request.Context(), entities.ErasureStatus(c.Query("status")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reqs)
+}
+
+// @Summary      Process erasure request
+// @Description  Executes (pseudonymizes the user's PII) or rejects an erasure request
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                            true "Erasure request ID"
+// @Param        request body entities.ProcessErasureRequest true "Decision"
+// @Success      200 {object} entities.ErasureRequest
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "Erasure request not found"
+// @Failure      409 {object} entities.ErrorResponse "Accounts hold funds, holds, cards or loans"
+// @Router       /admin/erasure-requests/{id} [post]
+func (h *PrivacyHandler) ProcessErasure(c *gin.Context) {
+	var input entities.ProcessErasureRequest
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid erasure request ID"})
+		return
+	}
+
+	staffID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	req, err := h.service.ProcessErasure(c.Request.Context(), staffID, uint(id), &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrErasureNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrErasureNotAllowed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, req)
+}
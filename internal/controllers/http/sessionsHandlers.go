@@ -0,0 +1,98 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type SessionsHandler struct {
+	service services.SessionsService
+}
+
+func NewSessionsHandler(s services.SessionsService) *SessionsHandler {
+	return &SessionsHandler{service: s}
+}
+
+// @Summary      List sessions
+// @Description  Returns the authenticated user's active sessions, one per device
+// @Tags         Sessions
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} entities.SessionResponse
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/sessions [get]
+func (h *SessionsHandler) List(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessions, err := h.service.List(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.SessionsToResponse(sessions, helpers.ExtractSessionID(c)))
+}
+
+// @Summary      Revoke session
+// @Description  Revokes one of the authenticated user's sessions
+// @Tags         Sessions
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Session ID"
+// @Success      200 {object} entities.MessageResponse "Session revoked"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      404 {object} entities.ErrorResponse "Session not found"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/sessions/{id} [delete]
+func (h *SessionsHandler) Revoke(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.service.Revoke(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// @Summary      Revoke all sessions
+// @Description  Revokes all sessions of the authenticated user, including the current one
+// @Tags         Sessions
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} entities.MessageResponse "All sessions revoked"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /auth/sessions [delete]
+func (h *SessionsHandler) RevokeAll(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.RevokeAll(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "All sessions revoked"})
+}
@@ -4,6 +4,7 @@ import (
 	"bank-app-backend/internal/controllers/http/helpers"
 	"bank-app-backend/internal/entities"
 	"bank-app-backend/internal/services"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -28,8 +29,13 @@ func NewTransactionsHandler(transferService services.TransfersService, txService
 // @Produce json
 // @Param transfer body entities.TransferRequest true "Transfer request"
 // @Success 200 {object} entities.Transaction "Transaction details"
+// @Success 202 {object} entities.TransferHeldResponse "Transfer held for manual review"
 // @Failure 400 {object} entities.ErrorResponse "Error processing transfer"
 // @Failure 401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed for the verification tier, account role or spend limit, payee in cooling-off period, or blocked by fraud screening"
+// @Failure 404 {object} entities.ErrorResponse "Account or payee not found"
+// @Failure 422 {object} entities.LimitExceededResponse "Transfer limit exceeded"
+// @Failure 409 {object} entities.ErrorResponse "Account status does not allow the operation"
 // @Router /auth/transfers/internal [post]
 func (h *TransactionsHandler) InternalTransfer(c *gin.Context) {
 	var req entities.TransferRequest
@@ -49,6 +55,22 @@ func (h *TransactionsHandler) InternalTransfer(c *gin.Context) {
 	req.Type = entities.InternalTransfer
 	tx, err := h.transfersService.ProcessTransfer(c.Request.Context(), req)
 	if err != nil {
+		if writeTransferScreening(c, err) || writeLimitExceeded(c, err) {
+			return
+		}
+		if errors.Is(err, services.ErrKYCRestricted) || errors.Is(err, services.ErrPayeeCoolingOff) ||
+			errors.Is(err, services.ErrAccountForbidden) || errors.Is(err, services.ErrSpendLimitExceeded) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrAccountInactive) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrPayeeNotFound) || errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -63,14 +85,20 @@ func (h *TransactionsHandler) InternalTransfer(c *gin.Context) {
 // @Produce json
 // @Param transfer body entities.TransferRequest true "Transfer request"
 // @Success 200 {object} entities.Transaction "Transaction details"
+// @Success 202 {object} entities.TransferHeldResponse "Transfer held for manual review"
 // @Failure 400 {object} entities.ErrorResponse "Error processing transfer"
 // @Failure 401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed for the verification tier, account role or spend limit, payee in cooling-off period, or blocked by fraud screening"
+// @Failure 404 {object} entities.ErrorResponse "Account or payee not found"
+// @Failure 422 {object} entities.LimitExceededResponse "Transfer limit exceeded"
+// @Failure 409 {object} entities.ErrorResponse "Account status does not allow the operation"
 // @Router /auth/transfers/external [post]
 func (h *TransactionsHandler) ExternalTransfer(c *gin.Context) {
 	var req entities.TransferRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	userID, err := helpers.ExtractUserID(c)
@@ -83,7 +111,28 @@ func (h *TransactionsHandler) ExternalTransfer(c *gin.Context) {
 	req.Type = entities.ExternalTransfer
 	tx, err := h.transfersService.ProcessTransfer(c.Request.Context(), req)
 	if err != nil {
+		if writeTransferScreening(c, err) || writeLimitExceeded(c, err) {
+			return
+		}
+		if errors.Is(err, services.ErrKYCRestricted) || errors.Is(err, services.ErrPayeeCoolingOff) ||
+			errors.Is(err, services.ErrAccountForbidden) || errors.Is(err, services.ErrSpendLimitExceeded) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrAccountInactive) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrPayeeNotFound) || errors.Is(err, services.ErrAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidAmountPrecision) || errors.Is(err, services.ErrInvalidTransferAmount) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
 	}
 
 	c.JSON(http.StatusOK, tx)
@@ -91,7 +140,7 @@ func (h *TransactionsHandler) ExternalTransfer(c *gin.Context) {
 
 // @Tags Transactions
 // @Summary Get a list of transactions
-// @Description Get a list of transactions for a user, with optional filters for pagination, date range, type, and amount
+// @Description Get a list of transactions created by the user or touching any account the user is a member of, with optional filters for pagination, date range, type, and amount
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number"
@@ -107,6 +156,10 @@ func (h *TransactionsHandler) ExternalTransfer(c *gin.Context) {
 // @Router /auth/transactions [get]
 func (h *TransactionsHandler) GetTransactions(c *gin.Context) {
 	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
 	filter := helpers.BuildTransactionFilter(c, userID)
 
 	txs, err := h.txService.GetTransactions(c.Request.Context(), filter)
@@ -120,15 +173,22 @@ func (h *TransactionsHandler) GetTransactions(c *gin.Context) {
 
 // @Tags Transactions
 // @Summary Get a transaction by ID
-// @Description Get transaction details by ID
+// @Description Get transaction details by ID if it was created by the user or touches one of the user's accounts
 // @Accept json
 // @Produce json
 // @Param id path int true "Transaction ID"
 // @Success 200 {object} entities.Transaction "Transaction details"
+// @Failure 401 {object} entities.ErrorResponse "Unauthorized"
 // @Failure 404 {object} entities.ErrorResponse "Transaction not found"
 // @Failure 400 {object} entities.ErrorResponse "Invalid transaction ID"
 // @Router /auth/transactions/{id} [get]
 func (h *TransactionsHandler) GetTransactionById(c *gin.Context) {
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
@@ -136,9 +196,14 @@ func (h *TransactionsHandler) GetTransactionById(c *gin.Context) {
 		return
 	}
 
-	tx, err := h.txService.GetTransactionByID(c.Request.Context(), uint(id))
+	tx, err := h.txService.GetTransactionByID(c.Request.Context(), userID, uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "transaction not found"})
+		if errors.Is(err, services.ErrTransactionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "transaction not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, tx)
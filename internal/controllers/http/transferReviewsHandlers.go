@@ -0,0 +1,145 @@
+package http
+
+import (
+	"bank-app-backend/internal/controllers/http/helpers"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/services"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type TransferReviewsHandler struct {
+	service services.TransfersService
+}
+
+func NewTransferReviewsHandler(s services.TransfersService) *TransferReviewsHandler {
+	return &TransferReviewsHandler{service: s}
+}
+
+// @Summary      List held transfers
+// @Description  Returns transfers held by fraud screening, optionally filtered by status (e.g. the pending review queue)
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status query string false "Review status (pending, approved, rejected)"
+// @Success      200 {array} entities.TransferReview
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      500 {object} entities.ErrorResponse "Internal server error"
+// @Router       /admin/transfer-reviews [get]
+func (h *TransferReviewsHandler) List(c *gin.Context) {
+	status := entities.TransferReviewStatus(c.Query("status"))
+
+	reviews, err := h.service.Reviews(c.Request.Context(), status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// @Summary      Approve held transfer
+// @Description  Executes a held transfer. Balance, limits and access are checked again; if the transfer fails the review stays pending. Staff cannot approve their own transfers.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                            true  "Review ID"
+// @Param        request body entities.ReviewTransferRequest false "Note"
+// @Success      200 {object} entities.TransferReview
+// @Failure      400 {object} entities.ErrorResponse "Transfer cannot be executed"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden or own transfer"
+// @Failure      404 {object} entities.ErrorResponse "Review not found"
+// @Failure      409 {object} entities.ErrorResponse "Review is already being processed"
+// @Router       /admin/transfer-reviews/{id}/approve [post]
+func (h *TransferReviewsHandler) Approve(c *gin.Context) {
+	h.review(c, h.service.ApproveReview)
+}
+
+// @Summary      Reject held transfer
+// @Description  Rejects a held transfer; the money is not moved. A note is required.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                            true "Review ID"
+// @Param        request body entities.ReviewTransferRequest true "Reason"
+// @Success      200 {object} entities.TransferReview
+// @Failure      400 {object} entities.ErrorResponse "Invalid input data"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "Review not found"
+// @Failure      409 {object} entities.ErrorResponse "Review is already being processed"
+// @Router       /admin/transfer-reviews/{id}/reject [post]
+func (h *TransferReviewsHandler) Reject(c *gin.Context) {
+	h.review(c, h.service.RejectReview)
+}
+
+type reviewDecision func(ctx context.Context, staffID, reviewID uint, req *entities.ReviewTransferRequest) (*entities.TransferReview, error)
+
+func (h *TransferReviewsHandler) review(c *gin.Context, decide reviewDecision) {
+	var req entities.ReviewTransferRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+			return
+		}
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	staffID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := decide(c.Request.Context(), staffID, uint(id), &req)
+	if err != nil {
+		if errors.Is(err, services.ErrTransferReviewNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrOwnTransferReview) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrTransferReviewClaimed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// writeTransferScreening отвечает 202, если перевод задержан антифродом, и 403, если заблокирован
+func writeTransferScreening(c *gin.Context, err error) bool {
+	var held *services.TransferHeldError
+	if errors.As(err, &held) {
+		c.JSON(http.StatusAccepted, entities.TransferHeldResponse{
+			Status:   "held",
+			ReviewID: held.Review.ID,
+			Reasons:  held.Review.Reasons,
+		})
+		return true
+	}
+
+	if errors.Is(err, services.ErrTransferBlocked) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return true
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"bank-app-backend/internal/controllers/http/helpers"
 	"bank-app-backend/internal/entities"
 	"bank-app-backend/internal/services"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -44,39 +45,82 @@ func (h *UsersHandler) Me(c *gin.Context) {
 		"id":       user.ID,
 		"email":    user.Email,
 		"username": user.Username,
+		"role":     user.Role,
 	})
 }
 
-// @Summary      Get all users
-// @Description  Retrieves all users
+// @Summary      Search users
+// @Description  Searches users by email or username with filters, sorting and pagination. Requires the admin role.
 // @Tags         Users
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
-// @Success      200 {array} entities.UserResponse "Users retrieved successfully"
+// @Param        query   query string false "Substring of email or username"
+// @Param        role    query string false "Role (customer, support, admin)"
+// @Param        blocked query bool   false "Only blocked (true) or only active (false) users"
+// @Param        sortBy  query string false "Sort field (id, email, username)"
+// @Param        order   query string false "Sort order (asc, desc)"
+// @Param        page    query int    false "Page number"
+// @Param        limit   query int    false "Page size (max 100)"
+// @Success      200 {object} entities.UsersPage "Users retrieved successfully"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
 // @Failure      500 {object} entities.ErrorResponse "Internal server error"
 // @Router       /users [get]
 func (h *UsersHandler) GetAll(c *gin.Context) {
-	users, err := h.service.GetAll(c.Request.Context())
+	page, err := h.service.Search(c.Request.Context(), helpers.BuildUserFilter(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
-	for _, user := range users {
-		user.Password = ""
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary      Get user details
+// @Description  Returns a user with block status, KYC status and accounts. Requires the admin role.
+// @Tags         Users
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "User ID"
+// @Success      200 {object} entities.UserDetailsResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid user ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "User not found"
+// @Router       /users/{id} [get]
+func (h *UsersHandler) GetByID(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
 	}
 
-	c.JSON(http.StatusOK, entities.UsersToResponse(users))
+	details, err := h.service.Details(c.Request.Context(), uint(userID))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
 }
 
 // @Summary      Update a user
-// @Description  Updates a user's information by ID
+// @Description  Updates a user's information by ID. Requires the admin role.
 // @Tags         Users
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        id    path      int                     true  "User ID"
 // @Param        user  body      entities.UpdateUserRequest true  "Updated user information"
 // @Success      200   {object}  entities.UserResponse   "User updated successfully"
 // @Failure      400   {object}  entities.ErrorResponse   "Invalid input or user ID"
+// @Failure      401   {object}  entities.ErrorResponse   "Unauthorized"
+// @Failure      403   {object}  entities.ErrorResponse   "Forbidden"
 // @Failure      404   {object}  entities.ErrorResponse   "User not found"
 // @Failure      500   {object}  entities.ErrorResponse   "Internal server error"
 // @Router       /users/{id} [patch]
@@ -106,3 +150,134 @@ func (h *UsersHandler) Update(c *gin.Context) {
 
 	c.JSON(http.StatusOK, user.ToResponse())
 }
+
+// @Summary      Change user role
+// @Description  Assigns a role (customer, support, admin) to a user and revokes the user's sessions. The last admin cannot be demoted. Requires the admin role.
+// @Tags         Users
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                        true  "User ID"
+// @Param        role  body      entities.UpdateRoleRequest true  "New role"
+// @Success      200   {object}  entities.UserResponse   "Role updated successfully"
+// @Failure      400   {object}  entities.ErrorResponse   "Invalid input or user ID"
+// @Failure      401   {object}  entities.ErrorResponse   "Unauthorized"
+// @Failure      403   {object}  entities.ErrorResponse   "Forbidden"
+// @Failure      404   {object}  entities.ErrorResponse   "User not found"
+// @Failure      409   {object}  entities.ErrorResponse   "Cannot demote the last admin"
+// @Failure      500   {object}  entities.ErrorResponse   "Internal server error"
+// @Router       /users/{id}/role [patch]
+func (h *UsersHandler) UpdateRole(c *gin.Context) {
+	var input entities.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	idParam := c.Param("id")
+	userID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	user, err := h.service.UpdateRole(c.Request.Context(), uint(userID), input.Role)
+	if err != nil {
+		if errors.Is(err, services.ErrLastAdmin) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user.ToResponse())
+}
+
+// @Summary      Block a user
+// @Description  Blocks a user: login, token refresh and authenticated requests are rejected. Requires the admin role.
+// @Tags         Users
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                       true "User ID"
+// @Param        request body entities.BlockUserRequest true "Block reason"
+// @Success      200 {object} entities.UserResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid input or user ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "User not found"
+// @Failure      409 {object} entities.ErrorResponse "The user is the last admin"
+// @Router       /users/{id}/block [post]
+func (h *UsersHandler) Block(c *gin.Context) {
+	var input entities.BlockUserRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	adminID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.Block(c.Request.Context(), adminID, uint(userID), input.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCannotBlockSelf):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrLastAdmin):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case strings.Contains(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to block user"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, user.ToResponse())
+}
+
+// @Summary      Unblock a user
+// @Description  Removes a block from a user. Requires the admin role.
+// @Tags         Users
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "User ID"
+// @Success      200 {object} entities.UserResponse
+// @Failure      400 {object} entities.ErrorResponse "Invalid user ID"
+// @Failure      401 {object} entities.ErrorResponse "Unauthorized"
+// @Failure      403 {object} entities.ErrorResponse "Forbidden"
+// @Failure      404 {object} entities.ErrorResponse "User not found"
+// @Router       /users/{id}/unblock [post]
+func (h *UsersHandler) Unblock(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	user, err := h.service.Unblock(c.Request.Context(), uint(userID))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unblock user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user.ToResponse())
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/lib/reqctx"
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error)
+}
+
+// APIKeyAuthMiddleware аутентифицирует запросы с заголовком X-API-Key.
+// Запросы без этого заголовка передаются в fallback (обычно JWTAuthMiddleware).
+func APIKeyAuthMiddleware(authenticator APIKeyAuthenticator, checker UserChecker, fallback gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(APIKeyHeader)
+		if rawKey == "" {
+			fallback(c)
+			return
+		}
+
+		key, err := authenticator.Authenticate(c.Request.Context(), rawKey)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired API key"})
+			return
+		}
+
+		if _, ok := allowUser(c, checker, key.UserID); !ok {
+			return
+		}
+
+		c.Set("userID", key.UserID)
+		c.Set("apiKey", key)
+		reqctx.SetActor(c.Request.Context(), key.UserID, "api_key")
+		c.Next()
+	}
+}
+
+// RequireScope пропускает запросы по JWT без ограничений, а запросы по API-ключу —
+// только если ключу выдан указанный scope
+func RequireScope(scope entities.APIScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, exists := c.Get("apiKey")
+		if !exists {
+			c.Next()
+			return
+		}
+
+		key, ok := value.(*entities.APIKey)
+		if !ok || !key.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key lacks required scope", "details": string(scope)})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RejectAPIKey закрывает endpoint для API-ключей (смена пароля, управление сессиями)
+func RejectAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get("apiKey"); exists {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Not available for API keys"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -1,13 +1,27 @@
 package middleware
 
 import (
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/lib/reqctx"
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"net/http"
 	"strings"
 )
 
-func JWTAuthMiddleware(secret []byte) gin.HandlerFunc {
+// UserChecker загружает пользователя запроса; роль и блокировка берутся из него, а не из токена
+type UserChecker interface {
+	Me(ctx context.Context, userID uint) (*entities.User, error)
+}
+
+type SessionChecker interface {
+	Exists(ctx context.Context, sessionID string) (bool, error)
+}
+
+// JWTAuthMiddleware пропускает запрос с действующим access-токеном, сессия которого
+// ещё не отозвана: после выхода, смены пароля или блокировки токен перестаёт работать сразу
+func JWTAuthMiddleware(secret []byte, checker UserChecker, sessions SessionChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
@@ -30,8 +44,46 @@ func JWTAuthMiddleware(secret []byte) gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
 			return
 		}
+		sub, ok := claims["sub"].(float64)
+		sessionID, _ := claims["sid"].(string)
+		if !ok || sessionID == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			return
+		}
 
-		c.Set("userID", uint(claims["sub"].(float64)))
+		active, err := sessions.Exists(c.Request.Context(), sessionID)
+		if err != nil || !active {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			return
+		}
+
+		userID := uint(sub)
+		user, ok := allowUser(c, checker, userID)
+		if !ok {
+			return
+		}
+
+		// Роль берётся из базы: понижение действует сразу, не дожидаясь истечения токена
+		role := string(user.Role)
+		c.Set("userID", userID)
+		c.Set("sessionID", sessionID)
+		c.Set("role", role)
+		reqctx.SetActor(c.Request.Context(), userID, role)
 		c.Next()
 	}
 }
+
+// allowUser возвращает пользователя запроса и прерывает запрос, если пользователь
+// заблокирован или не найден
+func allowUser(c *gin.Context, checker UserChecker, userID uint) (*entities.User, bool) {
+	user, err := checker.Me(c.Request.Context(), userID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return nil, false
+	}
+	if user.IsBlocked() {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "User is blocked"})
+		return nil, false
+	}
+	return user, true
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+const NetworkKeyHeader = "X-Network-Key"
+
+// RequireNetworkKey пропускает запросы платёжной сети (или её симулятора), только если
+// заголовок X-Network-Key совпадает с ключом из конфигурации. Пустой ключ закрывает доступ.
+func RequireNetworkKey(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader(NetworkKeyHeader)
+		if key == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(key)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid network key"})
+			return
+		}
+		c.Next()
+	}
+}
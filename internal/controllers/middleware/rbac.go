@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"bank-app-backend/internal/entities"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// RequireRoles пропускает запрос, только если текущая роль пользователя входит в список
+// разрешённых.
+// Должен подключаться после JWTAuthMiddleware.
+func RequireRoles(roles ...entities.Role) gin.HandlerFunc {
+	allowed := make(map[entities.Role]struct{}, len(roles))
+	for _, role := range roles {
+		allowed[role] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		role := entities.Role(c.GetString("role"))
+		if _, ok := allowed[role]; !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -18,9 +18,88 @@ func InitDB(path string) (*gorm.DB, error) {
 		lib.Log.Fatal("Could not connect to database", zap.Error(err))
 	}
 
-	if err := db.AutoMigrate(&entities.User{}, &entities.Account{}, &entities.Transaction{}); err != nil {
+	if err := db.AutoMigrate(
+		&entities.User{},
+		&entities.Account{},
+		&entities.Transaction{},
+		&entities.APIKey{},
+		&entities.KYCProfile{},
+		&entities.ErasureRequest{},
+		&entities.AuditEntry{},
+		&entities.Notification{},
+		&entities.NotificationPreference{},
+		&entities.Payee{},
+		&entities.AccountMember{},
+		&entities.AccountInvitation{},
+		&entities.LimitRule{},
+		&entities.LimitUsage{},
+		&entities.TransferReview{},
+		&entities.InterestAccrual{},
+		&entities.Hold{},
+		&entities.FeeRule{},
+		&entities.Pot{},
+		&entities.BalanceSnapshot{},
+		&entities.JobRun{},
+		&entities.Card{},
+		&entities.Loan{},
+		&entities.LoanInstallment{},
+	); err != nil {
 		lib.Log.Fatal("Could not migrate database", zap.Error(err))
 	}
 
+	if err := protectAuditLog(db); err != nil {
+		lib.Log.Fatal("Could not protect audit log", zap.Error(err))
+	}
+
+	if err := backfillAccountOwners(db); err != nil {
+		lib.Log.Fatal("Could not backfill account owners", zap.Error(err))
+	}
+
+	if err := dropCardCVVHashes(db); err != nil {
+		lib.Log.Fatal("Could not drop card CVV hashes", zap.Error(err))
+	}
+
 	return db, nil
 }
+
+// backfillAccountOwners создаёт членство владельца для счетов, открытых до появления
+// совместных счетов, так как доступ к счёту теперь проверяется только через участников
+func backfillAccountOwners(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO account_members (account_id, user_id, role, created_at)
+		SELECT a.id, a.user_id, ?, a.created_at FROM accounts a
+		WHERE NOT EXISTS (
+			SELECT 1 FROM account_members m WHERE m.account_id = a.id AND m.user_id = a.user_id
+		)`, entities.AccountRoleOwner).Error
+}
+
+// dropCardCVVHashes удаляет хеши CVV, которые хранились до того, как CVV стал
+// вычисляться из номера и срока действия карты
+func dropCardCVVHashes(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&entities.Card{}, "cvv_hash") {
+		return nil
+	}
+	return db.Migrator().DropColumn(&entities.Card{}, "cvv_hash")
+}
+
+// protectAuditLog запрещает UPDATE и DELETE в журнале аудита на уровне базы.
+// Изменения в обход триггера обнаруживаются проверкой цепочки хешей.
+func protectAuditLog(db *gorm.DB) error {
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_entries is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS audit_entries_append_only ON audit_entries`,
+		`CREATE TRIGGER audit_entries_append_only
+			BEFORE UPDATE OR DELETE ON audit_entries
+			FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only()`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package entities
+
+import "time"
+
+type AccountRole string
+
+const (
+	AccountRoleOwner   AccountRole = "owner"
+	AccountRoleCoOwner AccountRole = "co_owner"
+	AccountRoleViewer  AccountRole = "viewer"
+	AccountRoleSpender AccountRole = "spender"
+)
+
+type AccountPermission string
+
+const (
+	PermissionView    AccountPermission = "view"
+	PermissionDeposit AccountPermission = "deposit"
+	PermissionSpend   AccountPermission = "spend"
+	PermissionClose   AccountPermission = "close"
+	PermissionManage  AccountPermission = "manage"
+)
+
+var accountRolePermissions = map[AccountRole][]AccountPermission{
+	AccountRoleOwner:   {PermissionView, PermissionDeposit, PermissionSpend, PermissionClose, PermissionManage},
+	AccountRoleCoOwner: {PermissionView, PermissionDeposit, PermissionSpend},
+	AccountRoleSpender: {PermissionView, PermissionDeposit, PermissionSpend},
+	AccountRoleViewer:  {PermissionView},
+}
+
+// Can reports whether the role grants the permission.
+func (r AccountRole) Can(permission AccountPermission) bool {
+	for _, p := range accountRolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountMember grants a user access to an account. Every account has exactly one owner;
+// a spender may spend at most SpendLimit per transaction.
+// @Description Account member
+// @example { "account_id": 1, "user_id": 5, "role": "spender", "spend_limit": 5000, "created_at": "2025-01-01T10:00:00Z" }
+type AccountMember struct {
+	ID         uint        `gorm:"primaryKey" json:"-"`
+	AccountID  uint        `gorm:"uniqueIndex:idx_account_member;not null" json:"account_id"`
+	UserID     uint        `gorm:"uniqueIndex:idx_account_member;index;not null" json:"user_id"`
+	Role       AccountRole `gorm:"not null" json:"role"`
+	SpendLimit float64     `json:"spend_limit,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+	InvitationRevoked  InvitationStatus = "revoked"
+)
+
+// AccountInvitation is an offer to join an account, addressed to a user's email.
+// @Description Invitation to join an account
+// @example { "id": 1, "account_id": 1, "email": "partner@example.com", "role": "co_owner", "status": "pending", "expires_at": "2025-01-08T10:00:00Z" }
+type AccountInvitation struct {
+	ID          uint             `gorm:"primaryKey" json:"id"`
+	AccountID   uint             `gorm:"index;not null" json:"account_id"`
+	InvitedBy   uint             `gorm:"not null" json:"invited_by"`
+	Email       string           `gorm:"index;not null" json:"email"`
+	Role        AccountRole      `gorm:"not null" json:"role"`
+	SpendLimit  float64          `json:"spend_limit,omitempty"`
+	Status      InvitationStatus `gorm:"index;not null" json:"status"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	RespondedAt *time.Time       `json:"responded_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// InviteMemberRequest represents the payload to invite a user to an account.
+// @Description Invite a user by email. spend_limit is required for the spender role.
+// @example { "email": "partner@example.com", "role": "spender", "spend_limit": 5000 }
+type InviteMemberRequest struct {
+	Email      string      `json:"email" binding:"required,email"`
+	Role       AccountRole `json:"role" binding:"required,oneof=co_owner viewer spender"`
+	SpendLimit float64     `json:"spend_limit" binding:"gte=0"`
+}
+
+// UpdateMemberRequest represents the payload to change a member's role or limit.
+// @Description Change the role or spend limit of an account member.
+// @example { "role": "viewer", "spend_limit": 0 }
+type UpdateMemberRequest struct {
+	Role       AccountRole `json:"role" binding:"required,oneof=co_owner viewer spender"`
+	SpendLimit float64     `json:"spend_limit" binding:"gte=0"`
+}
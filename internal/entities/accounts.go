@@ -2,18 +2,74 @@ package entities
 
 import "time"
 
+type AccountStatus string
+
+const (
+	AccountActive  AccountStatus = "active"
+	AccountFrozen  AccountStatus = "frozen"
+	AccountDormant AccountStatus = "dormant"
+	AccountClosed  AccountStatus = "closed"
+)
+
+// accountTransitions lists the statuses an account can move to from each status.
+// Closed is final.
+var accountTransitions = map[AccountStatus][]AccountStatus{
+	AccountActive:  {AccountFrozen, AccountDormant, AccountClosed},
+	AccountDormant: {AccountActive, AccountFrozen, AccountClosed},
+	AccountFrozen:  {AccountActive},
+}
+
+// CanTransitionTo reports whether an account in this status can be moved to status to.
+func (s AccountStatus) CanTransitionTo(to AccountStatus) bool {
+	for _, allowed := range accountTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CanDebit reports whether money can be taken from an account in this status.
+func (s AccountStatus) CanDebit() bool {
+	return s == AccountActive
+}
+
+// CanCredit reports whether money can be paid into an account in this status.
+// Dormant accounts still receive incoming payments.
+func (s AccountStatus) CanCredit() bool {
+	return s == AccountActive || s == AccountDormant
+}
+
+// AccountTypeBank is the type of the bank's own accounts, such as fee revenue accounts.
+// Customers cannot open accounts of this type.
+const AccountTypeBank = "bank"
+
 // Account represents the database model for a user's account.
 // @Description Account entity containing balance, currency, and status information.
 // @example { "id": 1, "user_id": 2, "type": "deposit", "currency": "RUB", "balance": 1000.50, "status": "active" }
 type Account struct {
-	ID        uint    `gorm:"primary_key;auto_increment"`
-	UserID    uint    `gorm:"primary_key;not null"`
-	Type      string  `gorm:"not null"`
-	Currency  string  `gorm:"not null"`
-	Balance   float64 `gorm:"not null"`
-	Status    string  `gorm:"not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID       uint    `gorm:"primary_key;auto_increment"`
+	UserID   uint    `gorm:"primary_key;not null"`
+	Type     string  `gorm:"not null"`
+	Currency string  `gorm:"not null"`
+	Balance  float64 `gorm:"not null"`
+	// OverdraftLimit is how far below zero the balance may go; 0 means no overdraft.
+	OverdraftLimit float64 `gorm:"not null;default:0"`
+	// Held is the total of active holds on the account.
+	Held float64 `gorm:"not null;default:0"`
+	// Pots is the money set aside in the account's savings pots; it is part of Balance.
+	Pots            float64       `gorm:"not null;default:0"`
+	Status          AccountStatus `gorm:"not null"`
+	StatusReason    string
+	StatusChangedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Available returns the amount that can be spent from the account: the balance plus
+// the overdraft, less the money reserved by holds and set aside in pots.
+func (a *Account) Available() float64 {
+	return a.Balance + a.OverdraftLimit - a.Held - a.Pots
 }
 
 // CreateAccountRequest represents the payload required to create a new account.
@@ -35,14 +91,21 @@ type UpdateAccount struct {
 
 // AccountResponse represents the public response structure of an account.
 // @Description Response returned when retrieving account information.
-// @example { "id": 1, "user_id": 2, "type": "deposit", "currency": "RUB", "balance": 1000.50, "status": "active" }
+// Balance is the ledger balance including pots; AvailableBalance also accounts for the
+// overdraft, holds and pots.
+// @example { "id": 1, "user_id": 2, "type": "deposit", "currency": "RUB", "balance": 1000, "overdraft_limit": 5000, "held_amount": 1500, "pots_amount": 500, "available_balance": 4000, "status": "active" }
 type AccountResponse struct {
-	ID       uint    `json:"id"`
-	UserID   uint    `json:"user_id"`
-	Type     string  `json:"type"`
-	Currency string  `json:"currency"`
-	Balance  float64 `json:"balance"`
-	Status   string  `json:"status"`
+	ID               uint          `json:"id"`
+	UserID           uint          `json:"user_id"`
+	Type             string        `json:"type"`
+	Currency         string        `json:"currency"`
+	Balance          float64       `json:"balance"`
+	OverdraftLimit   float64       `json:"overdraft_limit"`
+	HeldAmount       float64       `json:"held_amount"`
+	PotsAmount       float64       `json:"pots_amount"`
+	AvailableBalance float64       `json:"available_balance"`
+	Status           AccountStatus `json:"status"`
+	StatusReason     string        `json:"status_reason,omitempty"`
 }
 
 // DepositRequest представляет тело запроса для пополнения счёта.
@@ -53,6 +116,28 @@ type DepositRequest struct {
 	Amount    float64 `json:"amount" binding:"required,gt=0"`
 }
 
+// WithdrawRequest представляет тело запроса для снятия денег со счёта.
+// @Description Запрос для вывода денег со счёта за пределы банка.
+// @example { "account_id": 1, "amount": 500 }
+type WithdrawRequest struct {
+	AccountID uint    `json:"account_id" binding:"required"`
+	Amount    float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// SetOverdraftRequest sets the overdraft limit of an account.
+// @Description Request to set the overdraft limit of an account; 0 turns the overdraft off
+// @example { "limit": 5000 }
+type SetOverdraftRequest struct {
+	Limit float64 `json:"limit" binding:"gte=0"`
+}
+
+// AccountStatusRequest freezes or unfreezes an account.
+// @Description Request to change an account status; the reason is stored on the account and in the audit log
+// @example { "reason": "Подозрение на мошенничество" }
+type AccountStatusRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
 // MessageResponse represents a success message response.
 // @Description Success message response
 // @example { "message": "Account closed successfully" }
@@ -62,12 +147,17 @@ type MessageResponse struct {
 
 func (a *Account) ToResponse() *AccountResponse {
 	return &AccountResponse{
-		ID:       a.ID,
-		UserID:   a.UserID,
-		Type:     a.Type,
-		Currency: a.Currency,
-		Balance:  a.Balance,
-		Status:   a.Status,
+		ID:               a.ID,
+		UserID:           a.UserID,
+		Type:             a.Type,
+		Currency:         a.Currency,
+		Balance:          a.Balance,
+		OverdraftLimit:   a.OverdraftLimit,
+		HeldAmount:       a.Held,
+		PotsAmount:       a.Pots,
+		AvailableBalance: a.Available(),
+		Status:           a.Status,
+		StatusReason:     a.StatusReason,
 	}
 }
 
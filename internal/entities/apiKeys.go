@@ -0,0 +1,104 @@
+package entities
+
+import "time"
+
+type APIScope string
+
+const (
+	ScopeProfileRead      APIScope = "profile:read"
+	ScopeAccountsRead     APIScope = "accounts:read"
+	ScopeAccountsWrite    APIScope = "accounts:write"
+	ScopeTransactionsRead APIScope = "transactions:read"
+	ScopeTransfersWrite   APIScope = "transfers:write"
+)
+
+// APIScopes lists all scopes that can be granted to an API key.
+var APIScopes = []APIScope{
+	ScopeProfileRead,
+	ScopeAccountsRead,
+	ScopeAccountsWrite,
+	ScopeTransactionsRead,
+	ScopeTransfersWrite,
+}
+
+// APIKey represents a machine-to-machine credential acting on behalf of a user.
+// Only the SHA-256 hash of the key is stored; Prefix identifies the key in logs and lists.
+type APIKey struct {
+	ID         uint       `gorm:"primaryKey"`
+	Name       string     `gorm:"not null"`
+	Prefix     string     `gorm:"uniqueIndex;not null"`
+	KeyHash    string     `gorm:"not null"`
+	UserID     uint       `gorm:"index;not null"`
+	CreatedBy  uint       `gorm:"not null"`
+	Scopes     []APIScope `gorm:"serializer:json"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// CreateAPIKeyRequest represents the payload required to issue an API key.
+// @Description Request payload for issuing an API key for a user.
+// @example { "name": "batch-payouts", "user_id": 2, "scopes": ["accounts:read", "transfers:write"], "expires_at": "2026-01-01T00:00:00Z" }
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	UserID    uint       `json:"user_id" binding:"required"`
+	Scopes    []APIScope `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyResponse represents the public view of an API key.
+// @Description API key metadata. The secret itself is never returned after creation.
+// @example { "id": 1, "name": "batch-payouts", "prefix": "a1b2c3d4", "user_id": 2, "scopes": ["accounts:read"], "expires_at": null, "last_used_at": null, "revoked_at": null, "created_at": "2025-01-01T10:00:00Z" }
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	UserID     uint       `json:"user_id"`
+	Scopes     []APIScope `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse is returned once, when the key is issued.
+// @Description Issued API key. The key value is shown only once.
+// @example { "key": "bk_a1b2c3d4_...", "api_key": { "id": 1, "name": "batch-payouts", "prefix": "a1b2c3d4" } }
+type CreateAPIKeyResponse struct {
+	Key    string          `json:"key"`
+	APIKey *APIKeyResponse `json:"api_key"`
+}
+
+// HasScope reports whether the key grants the scope.
+func (k *APIKey) HasScope(scope APIScope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *APIKey) ToResponse() *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		UserID:     k.UserID,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+// APIKeysToResponse converts a list of APIKeys to a list of APIKeyResponses.
+func APIKeysToResponse(keys []*APIKey) []*APIKeyResponse {
+	responses := make([]*APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		responses = append(responses, key.ToResponse())
+	}
+	return responses
+}
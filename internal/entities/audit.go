@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditRegister        AuditAction = "auth.register"
+	AuditLogin           AuditAction = "auth.login"
+	AuditPasswordChange  AuditAction = "auth.password_change"
+	AuditUserUpdate      AuditAction = "user.update"
+	AuditUserBlock       AuditAction = "user.block"
+	AuditUserUnblock     AuditAction = "user.unblock"
+	AuditUserRoleUpdate  AuditAction = "user.role_update"
+	AuditAccountCreate   AuditAction = "account.create"
+	AuditAccountClose    AuditAction = "account.close"
+	AuditOverdraftSet    AuditAction = "account.overdraft_set"
+	AuditAccountStatus   AuditAction = "account.status_change"
+	AuditDeposit         AuditAction = "account.deposit"
+	AuditWithdrawal      AuditAction = "account.withdraw"
+	AuditTransfer        AuditAction = "transfer.create"
+	AuditTransferReview  AuditAction = "transfer.review"
+	AuditMemberInvite    AuditAction = "account_member.invite"
+	AuditMemberAccept    AuditAction = "account_member.accept"
+	AuditMemberUpdate    AuditAction = "account_member.update"
+	AuditMemberRemove    AuditAction = "account_member.remove"
+	AuditPayeeCreate     AuditAction = "payee.create"
+	AuditPayeeUpdate     AuditAction = "payee.update"
+	AuditPayeeDelete     AuditAction = "payee.delete"
+	AuditHoldCreate      AuditAction = "hold.create"
+	AuditHoldCapture     AuditAction = "hold.capture"
+	AuditHoldRelease     AuditAction = "hold.release"
+	AuditPotCreate       AuditAction = "pot.create"
+	AuditPotUpdate       AuditAction = "pot.update"
+	AuditPotDelete       AuditAction = "pot.delete"
+	AuditPotMove         AuditAction = "pot.move"
+	AuditCardIssue       AuditAction = "card.issue"
+	AuditCardStatus      AuditAction = "card.status_change"
+	AuditCardLimits      AuditAction = "card.limits_update"
+	AuditCardAuthorize   AuditAction = "card.authorize"
+	AuditLoanApply       AuditAction = "loan.apply"
+	AuditLoanReview      AuditAction = "loan.review"
+	AuditLoanRepay       AuditAction = "loan.repay"
+	AuditFeeSet          AuditAction = "fee.set"
+	AuditFeeDelete       AuditAction = "fee.delete"
+	AuditLimitSet        AuditAction = "limit.set"
+	AuditLimitDelete     AuditAction = "limit.delete"
+	AuditInterestRun     AuditAction = "interest.run"
+	AuditBalanceSnapshot AuditAction = "balance.snapshot"
+	AuditKYCReview       AuditAction = "kyc.review"
+	AuditAPIKeyCreate    AuditAction = "api_key.create"
+	AuditAPIKeyRevoke    AuditAction = "api_key.revoke"
+	AuditErasure         AuditAction = "erasure.process"
+	AuditLoginUnlock     AuditAction = "login.unlock"
+)
+
+type AuditResult string
+
+const (
+	AuditSuccess AuditResult = "success"
+	AuditFailure AuditResult = "failure"
+)
+
+// AuditChange is the value of a single field before and after an operation.
+type AuditChange struct {
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// AuditChanges maps a field name to its change.
+type AuditChanges map[string]AuditChange
+
+// AuditEntry is an append-only audit log record. Each entry stores the hash of the
+// previous one, so modifying or deleting a record breaks the chain.
+// @Description Audit log entry
+type AuditEntry struct {
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	CreatedAt    time.Time    `gorm:"index;not null" json:"created_at"`
+	ActorID      uint         `gorm:"index" json:"actor_id"`
+	ActorRole    string       `json:"actor_role,omitempty"`
+	IP           string       `json:"ip,omitempty"`
+	RequestID    string       `gorm:"index" json:"request_id,omitempty"`
+	Action       AuditAction  `gorm:"index;not null" json:"action"`
+	ResourceType string       `gorm:"index:idx_audit_resource" json:"resource_type"`
+	ResourceID   string       `gorm:"index:idx_audit_resource" json:"resource_id,omitempty"`
+	Changes      AuditChanges `gorm:"serializer:json" json:"changes,omitempty"`
+	Result       AuditResult  `gorm:"not null" json:"result"`
+	Error        string       `json:"error,omitempty"`
+	PrevHash     string       `gorm:"not null" json:"prev_hash"`
+	Hash         string       `gorm:"uniqueIndex;not null" json:"hash"`
+}
+
+// ComputeHash returns the SHA-256 of the entry contents chained with PrevHash.
+// ID is not included because it is assigned by the database after hashing.
+func (e *AuditEntry) ComputeHash() string {
+	changes, _ := json.Marshal(e.Changes)
+
+	parts := []string{
+		e.PrevHash,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		fmt.Sprint(e.ActorID),
+		e.ActorRole,
+		e.IP,
+		e.RequestID,
+		string(e.Action),
+		e.ResourceType,
+		e.ResourceID,
+		string(changes),
+		string(e.Result),
+		e.Error,
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditEvent describes an operation to be written to the audit log.
+// Before and After are snapshots of the resource; only changed fields are stored.
+// ActorID overrides the authenticated user of the request, e.g. for login and registration.
+type AuditEvent struct {
+	ActorID      uint
+	Action       AuditAction
+	ResourceType string
+	ResourceID   interface{}
+	Before       interface{}
+	After        interface{}
+	Err          error
+}
+
+// AuditFilter is used to search the audit log.
+// @Description AuditFilter is used to filter audit entries by actor, action, resource, result and time.
+type AuditFilter struct {
+	ActorID      *uint        `json:"actor_id"`
+	Action       *AuditAction `json:"action"`
+	ResourceType string       `json:"resource_type"`
+	ResourceID   string       `json:"resource_id"`
+	RequestID    string       `json:"request_id"`
+	Result       *AuditResult `json:"result"`
+	FromDate     *time.Time   `json:"from_date"`
+	ToDate       *time.Time   `json:"to_date"`
+	Page         int          `json:"page"`
+	Limit        int          `json:"limit"`
+}
+
+// AuditPage is a page of audit entries with the total number of matches.
+// @Description Paginated list of audit entries.
+// @example { "items": [], "total": 0, "page": 1, "limit": 50 }
+type AuditPage struct {
+	Items []*AuditEntry `json:"items"`
+	Total int64         `json:"total"`
+	Page  int           `json:"page"`
+	Limit int           `json:"limit"`
+}
+
+// AuditVerification is the result of checking the audit hash chain.
+// LastHash can be stored externally to detect truncation of the log tail.
+// @Description Result of audit log integrity verification.
+// @example { "valid": false, "checked": 120, "broken_at": 57, "reason": "hash mismatch" }
+type AuditVerification struct {
+	Valid    bool   `json:"valid"`
+	Checked  int64  `json:"checked"`
+	BrokenAt *uint  `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	LastHash string `json:"last_hash,omitempty"`
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func auditEntry() AuditEntry {
+	return AuditEntry{
+		CreatedAt:    time.Date(2025, time.March, 1, 12, 30, 0, 123456000, time.UTC),
+		ActorID:      7,
+		ActorRole:    "admin",
+		IP:           "3f1c",
+		RequestID:    "req-1",
+		Action:       AuditUserBlock,
+		ResourceType: "user",
+		ResourceID:   "42",
+		Changes:      AuditChanges{"blocked": {Before: false, After: true}},
+		Result:       AuditSuccess,
+		PrevHash:     "abc",
+	}
+}
+
+func TestAuditEntryComputeHash(t *testing.T) {
+	entry := auditEntry()
+	hash := entry.ComputeHash()
+	if len(hash) != 64 {
+		t.Fatalf("hash %q is not a hex SHA-256", hash)
+	}
+	if again := entry.ComputeHash(); again != hash {
+		t.Errorf("hash is not stable: %s and %s", hash, again)
+	}
+
+	same := entry
+	same.ID = 99
+	same.Hash = hash
+	same.CreatedAt = entry.CreatedAt.In(time.FixedZone("MSK", 3*60*60))
+	if got := same.ComputeHash(); got != hash {
+		t.Error("hash depends on the ID, the stored hash or the time zone")
+	}
+
+	changes := map[string]func(e *AuditEntry){
+		"prev hash":     func(e *AuditEntry) { e.PrevHash = "abd" },
+		"created at":    func(e *AuditEntry) { e.CreatedAt = e.CreatedAt.Add(time.Microsecond) },
+		"actor":         func(e *AuditEntry) { e.ActorID = 8 },
+		"actor role":    func(e *AuditEntry) { e.ActorRole = "user" },
+		"ip":            func(e *AuditEntry) { e.IP = "3f1d" },
+		"request id":    func(e *AuditEntry) { e.RequestID = "req-2" },
+		"action":        func(e *AuditEntry) { e.Action = AuditUserUnblock },
+		"resource type": func(e *AuditEntry) { e.ResourceType = "account" },
+		"resource id":   func(e *AuditEntry) { e.ResourceID = "43" },
+		"changes":       func(e *AuditEntry) { e.Changes = AuditChanges{"blocked": {Before: true, After: false}} },
+		"result":        func(e *AuditEntry) { e.Result = AuditFailure },
+		"error":         func(e *AuditEntry) { e.Error = "denied" },
+	}
+	for field, change := range changes {
+		t.Run(field, func(t *testing.T) {
+			modified := auditEntry()
+			change(&modified)
+			if modified.ComputeHash() == hash {
+				t.Errorf("changing the %s does not change the hash", field)
+			}
+		})
+	}
+}
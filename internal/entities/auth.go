@@ -17,18 +17,32 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// ClientInfo describes the client that made the request
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// @Description UnlockLoginRequest model
+// @example { "email": "user@example.com", "ip": "10.0.0.1" }
+type UnlockLoginRequest struct {
+	Email string `json:"email" binding:"omitempty,email"`
+	IP    string `json:"ip" binding:"omitempty,ip"`
+}
+
+// @Description ChangePasswordRequest model
+// @example { "current_password": "OldPassw0rd", "new_password": "NewPassw0rd" }
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
 // @Description RefreshTokenRequest model
 // @example { "refresh_token": "old_refresh_token_value" }
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
-// @Description LogoutRequest model
-// @example { "userId": 123 }
-type LogoutRequest struct {
-	UserID uint `json:"userId" binding:"required"`
-}
-
 // @Description RefreshTokenRequest model
 // @example { "refresh_token": "old_refresh_token_value" }
 type RefreshToken struct {
@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// BalanceSnapshot is the closing balance of an account for a business date. ClosedAt is
+// the end of the business date in the jobs timezone: the snapshot includes every
+// transaction created before it.
+// @Description Closing balance of an account for a business date
+// @example { "id": 1, "account_id": 1, "business_date": "2025-01-31T00:00:00Z", "closed_at": "2025-01-31T21:00:00Z", "balance": 15000, "currency": "RUB" }
+type BalanceSnapshot struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	AccountID    uint      `gorm:"uniqueIndex:idx_balance_snapshot;not null" json:"account_id"`
+	BusinessDate time.Time `gorm:"uniqueIndex:idx_balance_snapshot;type:date;not null" json:"business_date"`
+	ClosedAt     time.Time `gorm:"index;not null" json:"closed_at"`
+	Balance      float64   `gorm:"not null" json:"balance"`
+	Currency     string    `gorm:"not null" json:"currency"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// BalanceSnapshotResult describes one run of the end-of-day snapshot job.
+// @Description Result of the balance snapshot job for a business date
+// @example { "business_date": "2025-01-31", "accounts": 240 }
+type BalanceSnapshotResult struct {
+	BusinessDate string `json:"business_date"`
+	Accounts     int64  `json:"accounts"`
+}
+
+// AccountBalanceAt is the balance of an account at a point in time, computed from the
+// latest snapshot before it and the transactions after the snapshot.
+// @Description Balance of an account at a point in time
+// @example { "account_id": 1, "at": "2025-02-03T12:00:00Z", "balance": 14250, "currency": "RUB", "snapshot_date": "2025-02-02" }
+type AccountBalanceAt struct {
+	AccountID    uint      `json:"account_id"`
+	At           time.Time `json:"at"`
+	Balance      float64   `json:"balance"`
+	Currency     string    `json:"currency"`
+	SnapshotDate string    `json:"snapshot_date,omitempty"`
+}
+
+// JobRun is the last business date a daily job has finished. After downtime the job
+// catches up on every day after it.
+type JobRun struct {
+	Job          string    `gorm:"primaryKey"`
+	BusinessDate time.Time `gorm:"type:date;not null"`
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,146 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type CardStatus string
+
+const (
+	CardActive    CardStatus = "active"
+	CardFrozen    CardStatus = "frozen"
+	CardCancelled CardStatus = "cancelled"
+)
+
+// Card is a virtual debit card linked to an account. The full PAN and the CVV are
+// never stored: PANHash is a keyed hash used to find the card on authorization, Token
+// identifies the card outside the bank and the CVV is derived from the PAN and expiry
+// with a secret key. Both are shown to the cardholder only once, when the card is issued.
+type Card struct {
+	ID        uint   `gorm:"primaryKey"`
+	AccountID uint   `gorm:"index;not null"`
+	UserID    uint   `gorm:"index;not null"`
+	Token     string `gorm:"uniqueIndex;not null"`
+	PANHash   string `gorm:"uniqueIndex;not null"`
+	Last4     string `gorm:"not null"`
+	// ExpiryMonth and ExpiryYear are the last month the card is valid, e.g. 12 and 2028.
+	ExpiryMonth int `gorm:"not null"`
+	ExpiryYear  int `gorm:"not null"`
+	Name        string
+	Status      CardStatus `gorm:"not null"`
+	// LimitPerTransaction and LimitDaily cap card payments; 0 means no card limit.
+	LimitPerTransaction float64 `gorm:"not null;default:0"`
+	LimitDaily          float64 `gorm:"not null;default:0"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// MaskedPAN returns the card number with all but the last four digits hidden.
+func (c *Card) MaskedPAN() string {
+	return "**** **** **** " + c.Last4
+}
+
+// Expired reports whether the card is past the end of its expiry month at now.
+func (c *Card) Expired(now time.Time) bool {
+	end := time.Date(c.ExpiryYear, time.Month(c.ExpiryMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+	return !now.Before(end)
+}
+
+func (c *Card) ToResponse() *CardResponse {
+	return &CardResponse{
+		ID:                  c.ID,
+		AccountID:           c.AccountID,
+		UserID:              c.UserID,
+		Token:               c.Token,
+		MaskedPAN:           c.MaskedPAN(),
+		Expiry:              fmt.Sprintf("%02d/%02d", c.ExpiryMonth, c.ExpiryYear%100),
+		Name:                c.Name,
+		Status:              c.Status,
+		LimitPerTransaction: c.LimitPerTransaction,
+		LimitDaily:          c.LimitDaily,
+		CreatedAt:           c.CreatedAt,
+	}
+}
+
+// CardResponse is the public view of a card.
+// @Description Virtual card with a masked number
+// @example { "id": 1, "account_id": 1, "user_id": 2, "token": "card_3f9a1c0b7d2e4f68", "masked_pan": "**** **** **** 4242", "expiry": "12/28", "name": "Подписки", "status": "active", "limit_per_transaction": 5000, "limit_daily": 20000, "created_at": "2025-12-01T12:00:00Z" }
+type CardResponse struct {
+	ID                  uint       `json:"id"`
+	AccountID           uint       `json:"account_id"`
+	UserID              uint       `json:"user_id"`
+	Token               string     `json:"token"`
+	MaskedPAN           string     `json:"masked_pan"`
+	Expiry              string     `json:"expiry"`
+	Name                string     `json:"name,omitempty"`
+	Status              CardStatus `json:"status"`
+	LimitPerTransaction float64    `json:"limit_per_transaction"`
+	LimitDaily          float64    `json:"limit_daily"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// IssueCardRequest issues a virtual card on an account to the requesting user.
+// @Description Request to issue a virtual card; zero limits mean no card limit
+// @example { "name": "Подписки", "limit_per_transaction": 5000, "limit_daily": 20000 }
+type IssueCardRequest struct {
+	Name                string  `json:"name" binding:"max=50"`
+	LimitPerTransaction float64 `json:"limit_per_transaction" binding:"gte=0"`
+	LimitDaily          float64 `json:"limit_daily" binding:"gte=0"`
+}
+
+// IssuedCardResponse is returned once, when the card is issued.
+// @Description Issued card. The full number and CVV are shown only once.
+// @example { "pan": "4000001234564242", "cvv": "123", "card": { "id": 1, "masked_pan": "**** **** **** 4242", "expiry": "12/28" } }
+type IssuedCardResponse struct {
+	PAN  string        `json:"pan"`
+	CVV  string        `json:"cvv"`
+	Card *CardResponse `json:"card"`
+}
+
+// CardLimitsRequest sets the spending limits of a card.
+// @Description Card spending limits; 0 removes a limit
+// @example { "limit_per_transaction": 5000, "limit_daily": 20000 }
+type CardLimitsRequest struct {
+	LimitPerTransaction float64 `json:"limit_per_transaction" binding:"gte=0"`
+	LimitDaily          float64 `json:"limit_daily" binding:"gte=0"`
+}
+
+type CardDeclineReason string
+
+const (
+	DeclineInvalidCard       CardDeclineReason = "invalid_card"
+	DeclineExpiredCard       CardDeclineReason = "expired_card"
+	DeclineInvalidCVV        CardDeclineReason = "invalid_cvv"
+	DeclineCVVLocked         CardDeclineReason = "cvv_attempts_exceeded"
+	DeclineCardFrozen        CardDeclineReason = "card_frozen"
+	DeclineCurrencyMismatch  CardDeclineReason = "currency_mismatch"
+	DeclineInvalidAmount     CardDeclineReason = "invalid_amount"
+	DeclineCardLimit         CardDeclineReason = "card_limit_exceeded"
+	DeclineInsufficientFunds CardDeclineReason = "insufficient_funds"
+	DeclineAccountInactive   CardDeclineReason = "account_inactive"
+	DeclineLimitExceeded     CardDeclineReason = "limit_exceeded"
+)
+
+// CardAuthorizationRequest is a card payment authorization from the card network.
+// @Description Card payment authorization request from the card network
+// @example { "pan": "4000001234564242", "expiry_month": 12, "expiry_year": 2028, "cvv": "123", "amount": 1490, "currency": "RUB", "merchant": "Кинотеатр" }
+type CardAuthorizationRequest struct {
+	PAN         string  `json:"pan" binding:"required,numeric,len=16"`
+	ExpiryMonth int     `json:"expiry_month" binding:"required,min=1,max=12"`
+	ExpiryYear  int     `json:"expiry_year" binding:"required,min=2000,max=2099"`
+	CVV         string  `json:"cvv" binding:"required,numeric,len=3"`
+	Amount      float64 `json:"amount" binding:"required,gt=0"`
+	Currency    string  `json:"currency" binding:"required,len=3"`
+	Merchant    string  `json:"merchant" binding:"max=255"`
+}
+
+// CardAuthorizationResponse is the decision on an authorization. An approved payment
+// reserves the amount on the account as a hold.
+// @Description Card authorization decision
+// @example { "approved": true, "hold_id": 17 }
+type CardAuthorizationResponse struct {
+	Approved      bool              `json:"approved"`
+	HoldID        *uint             `json:"hold_id,omitempty"`
+	DeclineReason CardDeclineReason `json:"decline_reason,omitempty"`
+}
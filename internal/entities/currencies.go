@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"math"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency. MinorUnits is the number of decimal places an
+// amount in the currency may have. Enabled reports whether accounts can be opened in
+// it in this deployment.
+// @Description ISO 4217 currency
+// @example { "code": "RUB", "numeric_code": "643", "minor_units": 2, "name": "Российский рубль", "enabled": true }
+type Currency struct {
+	Code        string `json:"code"`
+	NumericCode string `json:"numeric_code"`
+	MinorUnits  int    `json:"minor_units"`
+	Name        string `json:"name"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// Currencies is the ISO 4217 registry of currencies the bank can hold. Which of them
+// are enabled is set by the deployment configuration.
+var Currencies = []Currency{
+	{Code: "RUB", NumericCode: "643", MinorUnits: 2, Name: "Российский рубль"},
+	{Code: "USD", NumericCode: "840", MinorUnits: 2, Name: "Доллар США"},
+	{Code: "EUR", NumericCode: "978", MinorUnits: 2, Name: "Евро"},
+	{Code: "GBP", NumericCode: "826", MinorUnits: 2, Name: "Фунт стерлингов"},
+	{Code: "CHF", NumericCode: "756", MinorUnits: 2, Name: "Швейцарский франк"},
+	{Code: "CNY", NumericCode: "156", MinorUnits: 2, Name: "Китайский юань"},
+	{Code: "HKD", NumericCode: "344", MinorUnits: 2, Name: "Гонконгский доллар"},
+	{Code: "JPY", NumericCode: "392", MinorUnits: 0, Name: "Японская иена"},
+	{Code: "KRW", NumericCode: "410", MinorUnits: 0, Name: "Южнокорейская вона"},
+	{Code: "INR", NumericCode: "356", MinorUnits: 2, Name: "Индийская рупия"},
+	{Code: "TRY", NumericCode: "949", MinorUnits: 2, Name: "Турецкая лира"},
+	{Code: "AED", NumericCode: "784", MinorUnits: 2, Name: "Дирхам ОАЭ"},
+	{Code: "KZT", NumericCode: "398", MinorUnits: 2, Name: "Казахстанский тенге"},
+	{Code: "BYN", NumericCode: "933", MinorUnits: 2, Name: "Белорусский рубль"},
+	{Code: "UZS", NumericCode: "860", MinorUnits: 2, Name: "Узбекский сум"},
+	{Code: "KGS", NumericCode: "417", MinorUnits: 2, Name: "Киргизский сом"},
+	{Code: "AMD", NumericCode: "051", MinorUnits: 2, Name: "Армянский драм"},
+	{Code: "AZN", NumericCode: "944", MinorUnits: 2, Name: "Азербайджанский манат"},
+	{Code: "GEL", NumericCode: "981", MinorUnits: 2, Name: "Грузинский лари"},
+	{Code: "TJS", NumericCode: "972", MinorUnits: 2, Name: "Таджикский сомони"},
+	{Code: "CAD", NumericCode: "124", MinorUnits: 2, Name: "Канадский доллар"},
+	{Code: "AUD", NumericCode: "036", MinorUnits: 2, Name: "Австралийский доллар"},
+	{Code: "SEK", NumericCode: "752", MinorUnits: 2, Name: "Шведская крона"},
+	{Code: "NOK", NumericCode: "578", MinorUnits: 2, Name: "Норвежская крона"},
+	{Code: "PLN", NumericCode: "985", MinorUnits: 2, Name: "Польский злотый"},
+	{Code: "CZK", NumericCode: "203", MinorUnits: 2, Name: "Чешская крона"},
+	{Code: "BHD", NumericCode: "048", MinorUnits: 3, Name: "Бахрейнский динар"},
+	{Code: "KWD", NumericCode: "414", MinorUnits: 3, Name: "Кувейтский динар"},
+	{Code: "OMR", NumericCode: "512", MinorUnits: 3, Name: "Оманский риал"},
+}
+
+// MinorUnitsOf returns the number of decimal places of a currency in the registry, or 2
+// for a code that is not in it.
+func MinorUnitsOf(code string) int {
+	for _, currency := range Currencies {
+		if strings.EqualFold(currency.Code, code) {
+			return currency.MinorUnits
+		}
+	}
+	return 2
+}
+
+// RoundAmount rounds an amount to the given number of decimal places, e.g. to cents
+// for 2 and to whole units for 0.
+func RoundAmount(amount float64, minorUnits int) float64 {
+	scale := math.Pow10(minorUnits)
+	return math.Round(amount*scale) / scale
+}
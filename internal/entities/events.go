@@ -0,0 +1,40 @@
+package entities
+
+// Kafka topics with domain events.
+const (
+	TopicAccountCreated       = "account.created"
+	TopicTransactionCompleted = "transaction.completed"
+	TopicAccountOverdrawn     = "account.overdrawn"
+)
+
+// AccountCreatedEvent is published to account.created when an account is opened.
+type AccountCreatedEvent struct {
+	AccountID uint   `json:"account_id"`
+	UserID    uint   `json:"user_id"`
+	Type      string `json:"type"`
+	Currency  string `json:"currency"`
+	Status    string `json:"status"`
+}
+
+// TransactionCompletedEvent is published to transaction.completed after a transaction is stored.
+// UserID is the user who made the transaction and RecipientID is the owner of the credited
+// account; RecipientID is 0 when the money leaves the bank.
+type TransactionCompletedEvent struct {
+	TransactionID uint         `json:"transaction_id"`
+	UserID        uint         `json:"user_id"`
+	RecipientID   uint         `json:"recipient_id,omitempty"`
+	FromAccountID uint         `json:"from_account_id"`
+	ToAccountID   uint         `json:"to_account_id"`
+	Amount        float64      `json:"amount"`
+	Type          TransferType `json:"type"`
+	Description   string       `json:"description,omitempty"`
+}
+
+// AccountOverdrawnEvent is published to account.overdrawn when a debit takes the balance below zero.
+type AccountOverdrawnEvent struct {
+	AccountID      uint    `json:"account_id"`
+	UserID         uint    `json:"user_id"`
+	Currency       string  `json:"currency"`
+	Balance        float64 `json:"balance"`
+	OverdraftLimit float64 `json:"overdraft_limit"`
+}
@@ -0,0 +1,121 @@
+package entities
+
+type FeeKind string
+
+const (
+	FeeFlat       FeeKind = "flat"
+	FeePercentage FeeKind = "percentage"
+	FeeTiered     FeeKind = "tiered"
+)
+
+// FeeTier is a band of a tiered fee. The band covers amounts up to UpTo
+// inclusive; UpTo 0 means no upper bound.
+// @Description Band of a tiered fee
+// @example { "up_to": 10000, "flat": 0, "percent": 1 }
+type FeeTier struct {
+	UpTo    float64 `json:"up_to" binding:"gte=0"`
+	Flat    float64 `json:"flat" binding:"gte=0"`
+	Percent float64 `json:"percent" binding:"gte=0,lte=100"`
+}
+
+// FeeRule is a fee schedule for a transfer type, an account product and a currency.
+// Empty fields match any value; the rule with the most matching fields wins, the
+// transfer type counting most and the currency least.
+// @Description Fee schedule
+// @example { "id": 1, "transfer_type": "external", "product": "", "currency": "RUB", "kind": "percentage", "percent": 1, "min": 30, "max": 500 }
+type FeeRule struct {
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	TransferType TransferType `gorm:"uniqueIndex:idx_fee_rule;not null;default:''" json:"transfer_type"`
+	Product      string       `gorm:"uniqueIndex:idx_fee_rule;not null;default:''" json:"product"`
+	Currency     string       `gorm:"uniqueIndex:idx_fee_rule;not null;default:''" json:"currency"`
+	Kind         FeeKind      `gorm:"not null" json:"kind"`
+	Amount       float64      `json:"amount,omitempty"`
+	Percent      float64      `json:"percent,omitempty"`
+	Min          float64      `json:"min,omitempty"`
+	Max          float64      `json:"max,omitempty"`
+	Tiers        []FeeTier    `gorm:"serializer:json" json:"tiers,omitempty"`
+}
+
+// Matches reports whether the rule applies to the transfer type, product and currency.
+func (r *FeeRule) Matches(transferType TransferType, product, currency string) bool {
+	return (r.TransferType == "" || r.TransferType == transferType) &&
+		(r.Product == "" || r.Product == product) &&
+		(r.Currency == "" || r.Currency == currency)
+}
+
+// Specificity ranks matching rules: a rule for a transfer type beats any rule for all
+// types, then the product decides, then the currency.
+func (r *FeeRule) Specificity() int {
+	score := 0
+	if r.TransferType != "" {
+		score += 4
+	}
+	if r.Product != "" {
+		score += 2
+	}
+	if r.Currency != "" {
+		score++
+	}
+	return score
+}
+
+// Calculate returns the fee for amount rounded to the minor units of the currency.
+func (r *FeeRule) Calculate(amount float64, minorUnits int) float64 {
+	var fee float64
+	switch r.Kind {
+	case FeeFlat:
+		fee = r.Amount
+	case FeePercentage:
+		fee = amount * r.Percent / 100
+		if fee < r.Min {
+			fee = r.Min
+		}
+		if r.Max > 0 && fee > r.Max {
+			fee = r.Max
+		}
+	case FeeTiered:
+		for _, tier := range r.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee = tier.Flat + amount*tier.Percent/100
+				break
+			}
+		}
+	}
+	return RoundAmount(fee, minorUnits)
+}
+
+// SetFeeRuleRequest creates or replaces the fee rule for a transfer type, product and currency.
+// Tiers must be ordered by up_to, with the open-ended tier (up_to 0) last.
+// @Description Create or replace a fee rule
+// @example { "transfer_type": "withdrawal", "currency": "RUB", "kind": "tiered", "tiers": [ { "up_to": 50000, "flat": 0 }, { "up_to": 0, "percent": 1 } ] }
+type SetFeeRuleRequest struct {
+	TransferType TransferType `json:"transfer_type" binding:"omitempty,oneof=internal external withdrawal capture"`
+	Product      string       `json:"product"`
+	Currency     string       `json:"currency" binding:"omitempty,len=3"`
+	Kind         FeeKind      `json:"kind" binding:"required,oneof=flat percentage tiered"`
+	Amount       float64      `json:"amount" binding:"gte=0"`
+	Percent      float64      `json:"percent" binding:"gte=0,lte=100"`
+	Min          float64      `json:"min" binding:"gte=0"`
+	Max          float64      `json:"max" binding:"gte=0"`
+	Tiers        []FeeTier    `json:"tiers" binding:"dive"`
+}
+
+// FeePreviewRequest asks for the fee of an outgoing operation before it is made.
+// @Description Request to preview the fee of a transfer or withdrawal
+// @example { "from_account_id": 1, "type": "external", "amount": 15000 }
+type FeePreviewRequest struct {
+	FromAccountID uint         `json:"from_account_id" binding:"required"`
+	Type          TransferType `json:"type" binding:"required,oneof=internal external withdrawal"`
+	Amount        float64      `json:"amount" binding:"required,gt=0"`
+}
+
+// FeeQuote is the fee of an operation and the total taken from the account.
+// @Description Fee of an operation
+// @example { "amount": 15000, "fee": 150, "total": 15150, "currency": "RUB", "rule_id": 1 }
+type FeeQuote struct {
+	Amount   float64 `json:"amount"`
+	Fee      float64 `json:"fee"`
+	Total    float64 `json:"total"`
+	Currency string  `json:"currency"`
+	RuleID   *uint   `json:"rule_id,omitempty"`
+}
@@ -0,0 +1,70 @@
+package entities
+
+import "testing"
+
+func TestFeeRuleCalculate(t *testing.T) {
+	tiers := []FeeTier{{UpTo: 10000, Flat: 50, Percent: 0.5}, {UpTo: 50000, Flat: 0}, {UpTo: 0, Percent: 1}}
+
+	tests := []struct {
+		name       string
+		rule       FeeRule
+		amount     float64
+		minorUnits int
+		want       float64
+	}{
+		{name: "flat", rule: FeeRule{Kind: FeeFlat, Amount: 25}, amount: 100000, minorUnits: 2, want: 25},
+		{name: "percentage", rule: FeeRule{Kind: FeePercentage, Percent: 1, Min: 30, Max: 500}, amount: 10000, minorUnits: 2, want: 100},
+		{name: "percentage below min", rule: FeeRule{Kind: FeePercentage, Percent: 1, Min: 30, Max: 500}, amount: 1000, minorUnits: 2, want: 30},
+		{name: "percentage above max", rule: FeeRule{Kind: FeePercentage, Percent: 1, Min: 30, Max: 500}, amount: 100000, minorUnits: 2, want: 500},
+		{name: "percentage without max", rule: FeeRule{Kind: FeePercentage, Percent: 1}, amount: 100000, minorUnits: 2, want: 1000},
+		{name: "rounded to cents", rule: FeeRule{Kind: FeePercentage, Percent: 1.5}, amount: 1234.56, minorUnits: 2, want: 18.52},
+		{name: "rounded to whole units", rule: FeeRule{Kind: FeePercentage, Percent: 1.5}, amount: 1234.56, minorUnits: 0, want: 19},
+		{name: "rounded to three places", rule: FeeRule{Kind: FeePercentage, Percent: 1.5}, amount: 1234.56, minorUnits: 3, want: 18.518},
+		{name: "first tier", rule: FeeRule{Kind: FeeTiered, Tiers: tiers}, amount: 2000, minorUnits: 2, want: 60},
+		{name: "tier upper bound inclusive", rule: FeeRule{Kind: FeeTiered, Tiers: tiers}, amount: 50000, minorUnits: 2, want: 0},
+		{name: "open-ended tier", rule: FeeRule{Kind: FeeTiered, Tiers: tiers}, amount: 60000, minorUnits: 2, want: 600},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Calculate(tt.amount, tt.minorUnits); got != tt.want {
+				t.Errorf("Calculate(%v) = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeeRuleMatches(t *testing.T) {
+	rule := FeeRule{TransferType: ExternalTransfer, Currency: "RUB"}
+
+	if !rule.Matches(ExternalTransfer, "savings", "RUB") {
+		t.Error("rule does not match its transfer type and currency")
+	}
+	if rule.Matches(InternalTransfer, "savings", "RUB") {
+		t.Error("rule matches another transfer type")
+	}
+	if rule.Matches(ExternalTransfer, "savings", "USD") {
+		t.Error("rule matches another currency")
+	}
+	if !(&FeeRule{}).Matches(Withdrawal, "checking", "USD") {
+		t.Error("empty rule does not match everything")
+	}
+}
+
+func TestFeeRuleSpecificity(t *testing.T) {
+	// The transfer type outweighs product and currency together; the product outweighs the currency.
+	ordered := []FeeRule{
+		{},
+		{Currency: "RUB"},
+		{Product: "savings"},
+		{Product: "savings", Currency: "RUB"},
+		{TransferType: ExternalTransfer},
+		{TransferType: ExternalTransfer, Currency: "RUB"},
+		{TransferType: ExternalTransfer, Product: "savings"},
+		{TransferType: ExternalTransfer, Product: "savings", Currency: "RUB"},
+	}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i].Specificity() <= ordered[i-1].Specificity() {
+			t.Errorf("rule %+v does not beat %+v", ordered[i], ordered[i-1])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package entities
+
+import "time"
+
+type FraudDecision string
+
+const (
+	FraudAllow FraudDecision = "allow"
+	FraudHold  FraudDecision = "hold"
+	FraudBlock FraudDecision = "block"
+)
+
+// Severity orders decisions: block is stronger than hold, hold than allow.
+func (d FraudDecision) Severity() int {
+	switch d {
+	case FraudBlock:
+		return 2
+	case FraudHold:
+		return 1
+	}
+	return 0
+}
+
+// FraudResult is the outcome of screening a transfer; Reasons lists the triggered rules.
+// @Description Fraud screening result
+// @example { "decision": "hold", "reasons": ["new_payee", "night_time"] }
+type FraudResult struct {
+	Decision FraudDecision `json:"decision"`
+	Reasons  []string      `json:"reasons,omitempty"`
+}
+
+type TransferReviewStatus string
+
+const (
+	TransferReviewPending TransferReviewStatus = "pending"
+	// TransferReviewApproving marks a review claimed by a staff member while the transfer is posted.
+	TransferReviewApproving TransferReviewStatus = "approving"
+	TransferReviewApproved  TransferReviewStatus = "approved"
+	TransferReviewRejected  TransferReviewStatus = "rejected"
+)
+
+// TransferReview is a transfer held by fraud screening until staff approve or reject it.
+// Money is not moved while the review is pending.
+// @Description Transfer held for manual review
+// @example { "id": 1, "user_id": 2, "from_account_id": 1, "to_account_id": 7, "amount": 90000, "type": "external", "reasons": ["unusual_amount"], "status": "pending", "created_at": "2025-01-01T03:12:00Z" }
+type TransferReview struct {
+	ID            uint                 `gorm:"primaryKey" json:"id"`
+	UserID        uint                 `gorm:"index;not null" json:"user_id"`
+	FromAccountID uint                 `gorm:"not null" json:"from_account_id"`
+	ToAccountID   uint                 `gorm:"not null" json:"to_account_id"`
+	Amount        float64              `gorm:"not null" json:"amount"`
+	Description   string               `json:"description,omitempty"`
+	Type          TransferType         `gorm:"not null" json:"type"`
+	Reasons       []string             `gorm:"serializer:json" json:"reasons"`
+	Status        TransferReviewStatus `gorm:"index;not null" json:"status"`
+	ReviewerID    *uint                `json:"reviewer_id,omitempty"`
+	Note          string               `json:"note,omitempty"`
+	TransactionID *uint                `json:"transaction_id,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	ReviewedAt    *time.Time           `json:"reviewed_at,omitempty"`
+}
+
+// Request returns the transfer request that was held.
+func (r *TransferReview) Request() TransferRequest {
+	return TransferRequest{
+		UserID:        r.UserID,
+		FromAccountID: r.FromAccountID,
+		ToAccountID:   r.ToAccountID,
+		Amount:        r.Amount,
+		Description:   r.Description,
+		Type:          r.Type,
+	}
+}
+
+// ReviewTransferRequest is the staff decision on a held transfer.
+// @Description Note for approving or rejecting a held transfer; required on rejection
+// @example { "note": "Confirmed with the customer by phone" }
+type ReviewTransferRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// TransferHeldResponse is returned with status 202 when a transfer is held for review.
+// @Description Transfer accepted but held for manual review
+// @example { "status": "held", "review_id": 12, "reasons": ["velocity"] }
+type TransferHeldResponse struct {
+	Status   string   `json:"status"`
+	ReviewID uint     `json:"review_id"`
+	Reasons  []string `json:"reasons"`
+}
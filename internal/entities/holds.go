@@ -0,0 +1,55 @@
+package entities
+
+import "time"
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "active"
+	HoldCaptured HoldStatus = "captured"
+	HoldReleased HoldStatus = "released"
+	HoldExpired  HoldStatus = "expired"
+)
+
+// Hold reserves money on an account without moving it, for example for a card
+// authorization. The held amount is not available for spending until the hold is
+// captured into a transaction, released or expires.
+// @Description Funds hold on an account
+// @example { "id": 1, "account_id": 1, "user_id": 2, "amount": 1500, "captured_amount": 0, "description": "Бронь отеля", "status": "active", "expires_at": "2025-01-08T12:00:00Z", "created_at": "2025-01-01T12:00:00Z" }
+type Hold struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	AccountID      uint       `gorm:"index;not null" json:"account_id"`
+	UserID         uint       `gorm:"not null" json:"user_id"`
+	Amount         float64    `gorm:"not null" json:"amount"`
+	CapturedAmount float64    `gorm:"not null;default:0" json:"captured_amount"`
+	Description    string     `json:"description,omitempty"`
+	Status         HoldStatus `gorm:"index;not null" json:"status"`
+	ExpiresAt      time.Time  `gorm:"index;not null" json:"expires_at"`
+	TransactionID  *uint      `json:"transaction_id,omitempty"`
+	CardID         *uint      `gorm:"index" json:"card_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// CreateHoldRequest reserves money on an account. ExpiresIn is in seconds;
+// when omitted the configured default is used.
+// @Description Request to place a funds hold
+// @example { "amount": 1500, "description": "Бронь отеля", "expires_in": 604800 }
+type CreateHoldRequest struct {
+	Amount      float64 `json:"amount" binding:"required,gt=0"`
+	Description string  `json:"description" binding:"max=255"`
+	ExpiresIn   int64   `json:"expires_in" binding:"gte=0"`
+	// CardID is set by the cards service for holds placed by card authorizations.
+	CardID *uint `json:"-"`
+	// CardDailyLimit is the card's daily spending limit counted from CardDayStart; 0 means no limit.
+	CardDailyLimit float64   `json:"-"`
+	CardDayStart   time.Time `json:"-"`
+}
+
+// CaptureHoldRequest captures a hold. Amount may be less than the hold; the rest
+// is released. Zero captures the whole hold.
+// @Description Request to capture all or part of a hold
+// @example { "amount": 1200 }
+type CaptureHoldRequest struct {
+	Amount float64 `json:"amount" binding:"gte=0"`
+}
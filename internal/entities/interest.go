@@ -0,0 +1,76 @@
+package entities
+
+import "time"
+
+// BusinessDateLayout is the format of business dates in requests and responses.
+const BusinessDateLayout = "2006-01-02"
+
+type DayCount string
+
+const (
+	DayCountAct365 DayCount = "ACT/365"
+	DayCountAct360 DayCount = "ACT/360"
+	DayCountActAct DayCount = "ACT/ACT"
+	DayCount30360  DayCount = "30/360"
+)
+
+// YearFraction returns the share of a year that one calendar day counts for.
+// Under 30/360 every month counts as 30 days: the 31st counts for nothing and the
+// last day of February makes up the rest of the month.
+func (c DayCount) YearFraction(day time.Time) float64 {
+	switch c {
+	case DayCountAct360:
+		return 1.0 / 360
+	case DayCountActAct:
+		return 1.0 / float64(time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay())
+	case DayCount30360:
+		if day.Day() == 31 {
+			return 0
+		}
+		if day.Month() == time.February && day.AddDate(0, 0, 1).Month() != time.February {
+			return float64(31-day.Day()) / 360
+		}
+		return 1.0 / 360
+	}
+	return 1.0 / 365
+}
+
+// InterestAccrual is the interest earned by an account for one business date.
+// Interest charged on an overdrawn balance is negative. Accruals are summed up
+// and settled with the account at the end of the month.
+// @Description Daily interest accrual
+type InterestAccrual struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	AccountID     uint       `gorm:"uniqueIndex:idx_interest_accrual;not null" json:"account_id"`
+	BusinessDate  time.Time  `gorm:"uniqueIndex:idx_interest_accrual;type:date;not null" json:"business_date"`
+	Balance       float64    `gorm:"not null" json:"balance"`
+	Rate          float64    `gorm:"not null" json:"rate"`
+	DayCount      DayCount   `gorm:"not null" json:"day_count"`
+	Amount        float64    `gorm:"not null" json:"amount"`
+	TransactionID *uint      `gorm:"index" json:"transaction_id,omitempty"`
+	CapitalizedAt *time.Time `json:"capitalized_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// InterestRunResult describes one run of the daily interest job.
+// @Description Result of the interest job for a business date
+// @example { "business_date": "2025-01-31", "accrued": 120, "skipped": 3, "capitalized": 118 }
+type InterestRunResult struct {
+	BusinessDate string `json:"business_date"`
+	Accrued      int    `json:"accrued"`
+	Skipped      int    `json:"skipped"`
+	Capitalized  int    `json:"capitalized"`
+}
+
+// AccountInterest is the interest terms of an account and the amount accrued but not yet paid.
+// @Description Interest terms and accrued interest of an account
+// @example { "account_id": 1, "product": "savings", "rate": 0.08, "day_count": "ACT/365", "overdraft_rate": 0.25, "accrued": 12.3456, "last_accrual_date": "2025-01-15" }
+type AccountInterest struct {
+	AccountID       uint     `json:"account_id"`
+	Product         string   `json:"product"`
+	Rate            float64  `json:"rate"`
+	DayCount        DayCount `json:"day_count"`
+	OverdraftRate   float64  `json:"overdraft_rate,omitempty"`
+	Accrued         float64  `json:"accrued"`
+	LastAccrualDate string   `json:"last_accrual_date,omitempty"`
+}
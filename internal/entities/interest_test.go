@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDayCountYearFraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		dayCount DayCount
+		day      time.Time
+		want     float64
+	}{
+		{name: "ACT/365", dayCount: DayCountAct365, day: date(2024, time.March, 1), want: 1.0 / 365},
+		{name: "ACT/360", dayCount: DayCountAct360, day: date(2025, time.March, 1), want: 1.0 / 360},
+		{name: "ACT/ACT leap year", dayCount: DayCountActAct, day: date(2024, time.March, 1), want: 1.0 / 366},
+		{name: "ACT/ACT common year", dayCount: DayCountActAct, day: date(2025, time.March, 1), want: 1.0 / 365},
+		{name: "30/360 ordinary day", dayCount: DayCount30360, day: date(2025, time.January, 15), want: 1.0 / 360},
+		{name: "30/360 31st", dayCount: DayCount30360, day: date(2025, time.January, 31), want: 0},
+		{name: "30/360 30th", dayCount: DayCount30360, day: date(2025, time.April, 30), want: 1.0 / 360},
+		{name: "30/360 end of february", dayCount: DayCount30360, day: date(2025, time.February, 28), want: 3.0 / 360},
+		{name: "30/360 end of february in a leap year", dayCount: DayCount30360, day: date(2024, time.February, 29), want: 2.0 / 360},
+		{name: "30/360 february 28th in a leap year", dayCount: DayCount30360, day: date(2024, time.February, 28), want: 1.0 / 360},
+		{name: "unknown convention", dayCount: DayCount("BUS/252"), day: date(2025, time.March, 1), want: 1.0 / 365},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dayCount.YearFraction(tt.day); got != tt.want {
+				t.Errorf("YearFraction(%s) = %v, want %v", tt.day.Format(time.DateOnly), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayCountFullYear(t *testing.T) {
+	// Under ACT/ACT and 30/360 a whole year of daily accruals adds up to exactly one year
+	for _, year := range []int{2024, 2025} {
+		for _, dayCount := range []DayCount{DayCountActAct, DayCount30360} {
+			total := 0.0
+			for day := date(year, time.January, 1); day.Year() == year; day = day.AddDate(0, 0, 1) {
+				total += dayCount.YearFraction(day)
+			}
+			if math.Abs(total-1) > 1e-9 {
+				t.Errorf("%s over %d adds up to %v, want 1", dayCount, year, total)
+			}
+		}
+	}
+}
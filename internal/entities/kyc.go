@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type KYCStatus string
+
+const (
+	KYCStatusNotSubmitted KYCStatus = "not_submitted"
+	KYCStatusPending      KYCStatus = "pending"
+	KYCStatusVerified     KYCStatus = "verified"
+	KYCStatusRejected     KYCStatus = "rejected"
+)
+
+type KYCTier string
+
+const (
+	KYCTierBasic    KYCTier = "basic"
+	KYCTierVerified KYCTier = "verified"
+)
+
+// Tier returns the verification tier granted by the status.
+func (s KYCStatus) Tier() KYCTier {
+	if s == KYCStatusVerified {
+		return KYCTierVerified
+	}
+	return KYCTierBasic
+}
+
+// KYCProfile represents the identity data submitted by a user for verification.
+// @Description KYC profile with legal identity data and verification status.
+type KYCProfile struct {
+	ID              uint      `gorm:"primaryKey"`
+	UserID          uint      `gorm:"uniqueIndex;not null"`
+	LegalName       string    `gorm:"not null"`
+	DateOfBirth     time.Time `gorm:"type:date;not null"`
+	AddressLine     string    `gorm:"not null"`
+	City            string    `gorm:"not null"`
+	PostalCode      string    `gorm:"not null"`
+	Country         string    `gorm:"size:2;not null"`
+	DocumentType    string    `gorm:"not null"`
+	DocumentNumber  string    `gorm:"not null"`
+	Status          KYCStatus `gorm:"index;not null"`
+	RejectionReason string
+	ReviewedBy      *uint
+	ReviewedAt      *time.Time
+	SubmittedAt     time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// SubmitKYCRequest represents the payload to submit or resubmit KYC data.
+// @Description Request payload with identity data for KYC verification.
+// @example { "legal_name": "Ivan Ivanov", "date_of_birth": "1990-05-17", "address_line": "Tverskaya 1", "city": "Moscow", "postal_code": "125009", "country": "RU", "document_type": "passport", "document_number": "4510 123456" }
+type SubmitKYCRequest struct {
+	LegalName      string `json:"legal_name" binding:"required"`
+	DateOfBirth    string `json:"date_of_birth" binding:"required,datetime=2006-01-02"`
+	AddressLine    string `json:"address_line" binding:"required"`
+	City           string `json:"city" binding:"required"`
+	PostalCode     string `json:"postal_code" binding:"required"`
+	Country        string `json:"country" binding:"required,iso3166_1_alpha2"`
+	DocumentType   string `json:"document_type" binding:"required,oneof=passport id_card driver_license"`
+	DocumentNumber string `json:"document_number" binding:"required"`
+}
+
+// ReviewKYCRequest represents an admin decision on a KYC profile.
+// @Description Request payload to move a KYC profile to another status.
+// @example { "status": "rejected", "reason": "Document is expired" }
+type ReviewKYCRequest struct {
+	Status KYCStatus `json:"status" binding:"required,oneof=pending verified rejected"`
+	Reason string    `json:"reason"`
+}
+
+// KYCTierLimits describes what a user on a tier may do.
+// @Description Limits applied to the user's verification tier.
+type KYCTierLimits struct {
+	MaxAccounts       int     `json:"max_accounts"`
+	MaxDepositAmount  float64 `json:"max_deposit_amount"`
+	MaxTransferAmount float64 `json:"max_transfer_amount"`
+	ExternalTransfers bool    `json:"external_transfers"`
+}
+
+// KYCResponse represents the public view of a user's KYC state.
+// @Description KYC status, tier, limits and submitted profile of a user.
+// @example { "user_id": 1, "status": "pending", "tier": "basic", "limits": { "max_accounts": 1, "max_deposit_amount": 15000, "max_transfer_amount": 15000, "external_transfers": false } }
+type KYCResponse struct {
+	UserID          uint           `json:"user_id"`
+	Status          KYCStatus      `json:"status"`
+	Tier            KYCTier        `json:"tier"`
+	Limits          KYCTierLimits  `json:"limits"`
+	RejectionReason string         `json:"rejection_reason,omitempty"`
+	Profile         *KYCProfileDTO `json:"profile,omitempty"`
+	SubmittedAt     *time.Time     `json:"submitted_at,omitempty"`
+	ReviewedAt      *time.Time     `json:"reviewed_at,omitempty"`
+}
+
+// KYCProfileDTO is the submitted identity data as shown in API responses.
+// @Description Submitted KYC identity data.
+type KYCProfileDTO struct {
+	LegalName      string `json:"legal_name"`
+	DateOfBirth    string `json:"date_of_birth"`
+	AddressLine    string `json:"address_line"`
+	City           string `json:"city"`
+	PostalCode     string `json:"postal_code"`
+	Country        string `json:"country"`
+	DocumentType   string `json:"document_type"`
+	DocumentNumber string `json:"document_number"`
+}
+
+// Pseudonymize removes identity data from the profile while keeping its status history.
+func (p *KYCProfile) Pseudonymize() {
+	p.LegalName = fmt.Sprintf("erased-user-%d", p.UserID)
+	p.DateOfBirth = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.AddressLine = ""
+	p.City = ""
+	p.PostalCode = ""
+	p.DocumentNumber = ""
+}
+
+func (p *KYCProfile) ToDTO() *KYCProfileDTO {
+	return &KYCProfileDTO{
+		LegalName:      p.LegalName,
+		DateOfBirth:    p.DateOfBirth.Format("2006-01-02"),
+		AddressLine:    p.AddressLine,
+		City:           p.City,
+		PostalCode:     p.PostalCode,
+		Country:        p.Country,
+		DocumentType:   p.DocumentType,
+		DocumentNumber: p.DocumentNumber,
+	}
+}
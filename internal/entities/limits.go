@@ -0,0 +1,130 @@
+package entities
+
+import "time"
+
+type LimitScope string
+
+const (
+	LimitScopeTier        LimitScope = "tier"
+	LimitScopeAccountType LimitScope = "account_type"
+	LimitScopeUser        LimitScope = "user"
+)
+
+// LimitScopes lists scopes from the least to the most specific; a more specific
+// rule overrides the fields it sets.
+var LimitScopes = []LimitScope{LimitScopeTier, LimitScopeAccountType, LimitScopeUser}
+
+// TransferLimits are the limits on outgoing money: transfers and withdrawals.
+// Zero means the limit is not set.
+// @Description Transfer limits; 0 means not set
+// @example { "max_per_transaction": 50000, "daily_amount": 100000, "daily_count": 20, "monthly_amount": 1000000, "monthly_count": 300 }
+type TransferLimits struct {
+	MaxPerTransaction float64 `json:"max_per_transaction"`
+	DailyAmount       float64 `json:"daily_amount"`
+	DailyCount        int     `json:"daily_count"`
+	MonthlyAmount     float64 `json:"monthly_amount"`
+	MonthlyCount      int     `json:"monthly_count"`
+}
+
+// Overlay returns the limits with every non-zero field of other applied on top.
+func (l TransferLimits) Overlay(other TransferLimits) TransferLimits {
+	if other.MaxPerTransaction > 0 {
+		l.MaxPerTransaction = other.MaxPerTransaction
+	}
+	if other.DailyAmount > 0 {
+		l.DailyAmount = other.DailyAmount
+	}
+	if other.DailyCount > 0 {
+		l.DailyCount = other.DailyCount
+	}
+	if other.MonthlyAmount > 0 {
+		l.MonthlyAmount = other.MonthlyAmount
+	}
+	if other.MonthlyCount > 0 {
+		l.MonthlyCount = other.MonthlyCount
+	}
+	return l
+}
+
+// LimitRule sets transfer limits for a KYC tier, an account type or a single user.
+// Value is the tier name, the account type or the user ID.
+// @Description Transfer limit rule
+// @example { "id": 1, "scope": "tier", "value": "basic", "daily_amount": 100000, "daily_count": 20 }
+type LimitRule struct {
+	ID    uint       `gorm:"primaryKey" json:"id"`
+	Scope LimitScope `gorm:"uniqueIndex:idx_limit_rule;not null" json:"scope"`
+	Value string     `gorm:"uniqueIndex:idx_limit_rule;not null" json:"value"`
+	TransferLimits
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SetLimitRuleRequest creates or replaces the rule for a scope and value.
+// @Description Create or replace a transfer limit rule
+// @example { "scope": "account_type", "value": "savings", "max_per_transaction": 10000, "monthly_count": 5 }
+type SetLimitRuleRequest struct {
+	Scope             LimitScope `json:"scope" binding:"required,oneof=tier account_type user"`
+	Value             string     `json:"value" binding:"required"`
+	MaxPerTransaction float64    `json:"max_per_transaction" binding:"gte=0"`
+	DailyAmount       float64    `json:"daily_amount" binding:"gte=0"`
+	DailyCount        int        `json:"daily_count" binding:"gte=0"`
+	MonthlyAmount     float64    `json:"monthly_amount" binding:"gte=0"`
+	MonthlyCount      int        `json:"monthly_count" binding:"gte=0"`
+}
+
+type LimitPeriod string
+
+const (
+	LimitPeriodDay   LimitPeriod = "day"
+	LimitPeriodMonth LimitPeriod = "month"
+)
+
+// LimitUsage is the amount and number of outgoing operations of a user in a period.
+type LimitUsage struct {
+	ID          uint        `gorm:"primaryKey"`
+	UserID      uint        `gorm:"uniqueIndex:idx_limit_usage;not null"`
+	Period      LimitPeriod `gorm:"uniqueIndex:idx_limit_usage;not null"`
+	PeriodStart time.Time   `gorm:"uniqueIndex:idx_limit_usage;type:date;not null"`
+	Amount      float64     `gorm:"not null"`
+	Count       int         `gorm:"not null"`
+}
+
+// LimitReservation is the usage taken by an operation before it is executed.
+// It is released if the operation fails. A Partial reservation returns only part of
+// the amount: the operation went ahead and still counts towards the count limits.
+type LimitReservation struct {
+	UserID  uint
+	Amount  float64
+	At      time.Time
+	Partial bool
+}
+
+// LimitPeriodStatus shows the used and remaining part of a period's limits.
+// Remaining values are omitted when the limit is not set.
+// @Description Used and remaining limits for a period
+type LimitPeriodStatus struct {
+	UsedAmount      float64  `json:"used_amount"`
+	UsedCount       int      `json:"used_count"`
+	RemainingAmount *float64 `json:"remaining_amount,omitempty"`
+	RemainingCount  *int     `json:"remaining_count,omitempty"`
+	ResetsAt        string   `json:"resets_at"`
+}
+
+// LimitsStatus is the effective limits of a user and how much of them is left.
+// @Description Effective transfer limits with used and remaining amounts
+// @example { "limits": { "max_per_transaction": 15000, "daily_amount": 50000, "daily_count": 10 }, "daily": { "used_amount": 12000, "used_count": 2, "remaining_amount": 38000, "remaining_count": 8, "resets_at": "2025-01-02T00:00:00Z" }, "monthly": { "used_amount": 12000, "used_count": 2, "resets_at": "2025-02-01T00:00:00Z" } }
+type LimitsStatus struct {
+	Limits  TransferLimits    `json:"limits"`
+	Daily   LimitPeriodStatus `json:"daily"`
+	Monthly LimitPeriodStatus `json:"monthly"`
+}
+
+// LimitExceededResponse is returned with status 422 when an operation breaks a limit.
+// @Description Limit breach error; code is always limit_exceeded
+// @example { "error": "transfer limit exceeded: daily_amount limit is 50000.00, remaining 2000.00", "code": "limit_exceeded", "limit": "daily_amount", "max": 50000, "remaining": 2000 }
+type LimitExceededResponse struct {
+	Error     string  `json:"error"`
+	Code      string  `json:"code"`
+	Limit     string  `json:"limit"`
+	Max       float64 `json:"max"`
+	Remaining float64 `json:"remaining"`
+}
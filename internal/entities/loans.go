@@ -0,0 +1,177 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+type LoanStatus string
+
+const (
+	LoanPending  LoanStatus = "pending"
+	LoanRejected LoanStatus = "rejected"
+	LoanActive   LoanStatus = "active"
+	LoanRepaid   LoanStatus = "repaid"
+)
+
+type LoanScheduleType string
+
+const (
+	// ScheduleAnnuity repays the loan in equal monthly payments.
+	ScheduleAnnuity LoanScheduleType = "annuity"
+	// ScheduleLinear repays equal parts of the principal plus interest on the remaining debt.
+	ScheduleLinear LoanScheduleType = "linear"
+)
+
+// Loan is a loan disbursed into and repaid from an account. InterestRate is annual,
+// e.g. 0.15 for 15%; Outstanding is the principal not yet repaid.
+// @Description Loan with its repayment schedule
+// @example { "id": 1, "user_id": 2, "account_id": 1, "principal": 120000, "interest_rate": 0.15, "term_months": 12, "schedule_type": "annuity", "status": "active", "outstanding": 110669.18, "purpose": "Ремонт", "disbursed_at": "2025-01-10T12:00:00Z", "created_at": "2025-01-09T12:00:00Z" }
+type Loan struct {
+	ID            uint              `gorm:"primaryKey" json:"id"`
+	UserID        uint              `gorm:"index;not null" json:"user_id"`
+	AccountID     uint              `gorm:"index;not null" json:"account_id"`
+	Principal     float64           `gorm:"not null" json:"principal"`
+	InterestRate  float64           `gorm:"not null" json:"interest_rate"`
+	TermMonths    int               `gorm:"not null" json:"term_months"`
+	ScheduleType  LoanScheduleType  `gorm:"not null" json:"schedule_type"`
+	Status        LoanStatus        `gorm:"index;not null" json:"status"`
+	Outstanding   float64           `gorm:"not null;default:0" json:"outstanding"`
+	Purpose       string            `json:"purpose,omitempty"`
+	ReviewedBy    *uint             `json:"reviewed_by,omitempty"`
+	ReviewNote    string            `json:"review_note,omitempty"`
+	TransactionID *uint             `json:"transaction_id,omitempty"`
+	DisbursedAt   *time.Time        `json:"disbursed_at,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	Installments  []LoanInstallment `gorm:"foreignKey:LoanID" json:"installments,omitempty"`
+}
+
+type InstallmentStatus string
+
+const (
+	InstallmentDue     InstallmentStatus = "due"
+	InstallmentOverdue InstallmentStatus = "overdue"
+	InstallmentPaid    InstallmentStatus = "paid"
+)
+
+// LoanInstallment is one scheduled repayment of a loan. LateFee is added once the
+// installment is overdue for longer than the grace period.
+// @Description Scheduled loan repayment
+// @example { "id": 1, "loan_id": 1, "number": 1, "due_date": "2025-02-10T00:00:00Z", "principal": 9330.82, "interest": 1500, "late_fee": 0, "status": "paid", "paid_at": "2025-02-11T09:00:00Z", "transaction_id": 42 }
+type LoanInstallment struct {
+	ID            uint              `gorm:"primaryKey" json:"id"`
+	LoanID        uint              `gorm:"uniqueIndex:idx_loan_installment;not null" json:"loan_id"`
+	Number        int               `gorm:"uniqueIndex:idx_loan_installment;not null" json:"number"`
+	DueDate       time.Time         `gorm:"index;type:date;not null" json:"due_date"`
+	Principal     float64           `gorm:"not null" json:"principal"`
+	Interest      float64           `gorm:"not null" json:"interest"`
+	LateFee       float64           `gorm:"not null;default:0" json:"late_fee"`
+	Status        InstallmentStatus `gorm:"index;not null" json:"status"`
+	PaidAt        *time.Time        `json:"paid_at,omitempty"`
+	TransactionID *uint             `json:"transaction_id,omitempty"`
+}
+
+// Amount returns the total to be paid for the installment.
+func (i *LoanInstallment) Amount() float64 {
+	return RoundCents(i.Principal + i.Interest + i.LateFee)
+}
+
+// BuildLoanSchedule splits principal into monthly installments, the first due a month
+// after start and each on start's day of month or the last day of a shorter month.
+// Amounts are rounded to minorUnits decimal places; the last installment takes the
+// rounding difference so the principal parts add up exactly.
+func BuildLoanSchedule(principal, annualRate float64, months int, scheduleType LoanScheduleType, start time.Time, minorUnits int) []LoanInstallment {
+	rate := annualRate / 12
+	payment := principal / float64(months)
+	if scheduleType == ScheduleAnnuity && rate > 0 {
+		payment = principal * rate / (1 - math.Pow(1+rate, -float64(months)))
+	}
+
+	installments := make([]LoanInstallment, 0, months)
+	remaining := principal
+	for n := 1; n <= months; n++ {
+		interest := RoundAmount(remaining*rate, minorUnits)
+
+		part := RoundAmount(principal/float64(months), minorUnits)
+		if scheduleType == ScheduleAnnuity {
+			part = RoundAmount(payment-interest, minorUnits)
+		}
+		if n == months {
+			part = RoundAmount(remaining, minorUnits)
+		}
+		remaining = RoundAmount(remaining-part, minorUnits)
+
+		installments = append(installments, LoanInstallment{
+			Number:    n,
+			DueDate:   addMonthsClamped(start, n),
+			Principal: part,
+			Interest:  interest,
+			Status:    InstallmentDue,
+		})
+	}
+	return installments
+}
+
+// addMonthsClamped returns the date n months after start on start's day of month, or on
+// the last day of the month when it is shorter: a loan taken on 31 January is due on
+// 28 February, 31 March, 30 April and so on. Unlike time.AddDate, the day never spills
+// over into the next month.
+func addMonthsClamped(start time.Time, n int) time.Time {
+	first := time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	day := start.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// RoundCents rounds an amount to cents.
+func RoundCents(amount float64) float64 {
+	return RoundAmount(amount, 2)
+}
+
+// LoanApplicationRequest applies for a loan paid into and repaid from an account.
+// @Description Loan application
+// @example { "account_id": 1, "amount": 120000, "term_months": 12, "schedule_type": "annuity", "purpose": "Ремонт" }
+type LoanApplicationRequest struct {
+	AccountID    uint             `json:"account_id" binding:"required"`
+	Amount       float64          `json:"amount" binding:"required,gt=0"`
+	TermMonths   int              `json:"term_months" binding:"required,gt=0"`
+	ScheduleType LoanScheduleType `json:"schedule_type" binding:"required,oneof=annuity linear"`
+	Purpose      string           `json:"purpose" binding:"max=255"`
+}
+
+// ReviewLoanRequest is the staff decision on a loan application; a note is required on rejection.
+// @Description Note for approving or rejecting a loan application
+// @example { "note": "Недостаточный доход" }
+type ReviewLoanRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// LoanBalance is what is owed on a loan. RemainingAmount is everything still to be paid
+// under the schedule, including interest and late fees.
+// @Description Outstanding amounts of a loan
+// @example { "loan_id": 1, "status": "active", "outstanding_principal": 110669.18, "overdue_amount": 0, "late_fees": 0, "remaining_amount": 119969.84, "next_due_date": "2025-03-10T00:00:00Z", "next_payment": 10830.82, "paid_installments": 1, "total_installments": 12 }
+type LoanBalance struct {
+	LoanID               uint       `json:"loan_id"`
+	Status               LoanStatus `json:"status"`
+	OutstandingPrincipal float64    `json:"outstanding_principal"`
+	OverdueAmount        float64    `json:"overdue_amount"`
+	LateFees             float64    `json:"late_fees"`
+	RemainingAmount      float64    `json:"remaining_amount"`
+	NextDueDate          *time.Time `json:"next_due_date,omitempty"`
+	NextPayment          float64    `json:"next_payment"`
+	PaidInstallments     int        `json:"paid_installments"`
+	TotalInstallments    int        `json:"total_installments"`
+}
+
+// LoanRepaymentResult describes one run of the loan repayment job.
+// @Description Result of the loan repayment job for a business date
+// @example { "business_date": "2025-02-10", "paid": 57, "overdue": 3, "late_fees": 1 }
+type LoanRepaymentResult struct {
+	BusinessDate string `json:"business_date"`
+	Paid         int    `json:"paid"`
+	Overdue      int    `json:"overdue"`
+	LateFees     int    `json:"late_fees"`
+}
@@ -0,0 +1,131 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBuildLoanScheduleDueDates(t *testing.T) {
+	tests := []struct {
+		name  string
+		start time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "mid month",
+			start: time.Date(2025, time.January, 10, 15, 30, 0, 0, time.UTC),
+			want:  []time.Time{date(2025, time.February, 10), date(2025, time.March, 10), date(2025, time.April, 10)},
+		},
+		{
+			name:  "end of january",
+			start: date(2025, time.January, 31),
+			want:  []time.Time{date(2025, time.February, 28), date(2025, time.March, 31), date(2025, time.April, 30), date(2025, time.May, 31)},
+		},
+		{
+			name:  "leap year",
+			start: date(2024, time.January, 30),
+			want:  []time.Time{date(2024, time.February, 29), date(2024, time.March, 30)},
+		},
+		{
+			name:  "across the year",
+			start: date(2024, time.October, 31),
+			want:  []time.Time{date(2024, time.November, 30), date(2024, time.December, 31), date(2025, time.January, 31), date(2025, time.February, 28)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := BuildLoanSchedule(1000, 0.12, len(tt.want), ScheduleAnnuity, tt.start, 2)
+			for i, installment := range schedule {
+				if !installment.DueDate.Equal(tt.want[i]) {
+					t.Errorf("installment %d due %s, want %s", installment.Number, installment.DueDate.Format(time.DateOnly), tt.want[i].Format(time.DateOnly))
+				}
+			}
+		})
+	}
+}
+
+func TestBuildLoanScheduleAmounts(t *testing.T) {
+	tests := []struct {
+		name         string
+		principal    float64
+		rate         float64
+		months       int
+		scheduleType LoanScheduleType
+		principals   []float64
+		interests    []float64
+	}{
+		{
+			name:         "zero rate annuity",
+			principal:    1000,
+			months:       3,
+			scheduleType: ScheduleAnnuity,
+			principals:   []float64{333.33, 333.33, 333.34},
+			interests:    []float64{0, 0, 0},
+		},
+		{
+			name:         "zero rate linear",
+			principal:    100,
+			months:       3,
+			scheduleType: ScheduleLinear,
+			principals:   []float64{33.33, 33.33, 33.34},
+			interests:    []float64{0, 0, 0},
+		},
+		{
+			name:         "linear",
+			principal:    1200,
+			rate:         0.12,
+			months:       3,
+			scheduleType: ScheduleLinear,
+			principals:   []float64{400, 400, 400},
+			interests:    []float64{12, 8, 4},
+		},
+		{
+			name:         "annuity",
+			principal:    1000,
+			rate:         0.12,
+			months:       3,
+			scheduleType: ScheduleAnnuity,
+			principals:   []float64{330.02, 333.32, 336.66},
+			interests:    []float64{10, 6.7, 3.37},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := BuildLoanSchedule(tt.principal, tt.rate, tt.months, tt.scheduleType, date(2025, time.January, 10), 2)
+			if len(schedule) != tt.months {
+				t.Fatalf("got %d installments, want %d", len(schedule), tt.months)
+			}
+			for i, installment := range schedule {
+				if installment.Number != i+1 {
+					t.Errorf("installment %d has number %d", i+1, installment.Number)
+				}
+				if installment.Principal != tt.principals[i] || installment.Interest != tt.interests[i] {
+					t.Errorf("installment %d = %.2f + %.2f, want %.2f + %.2f", installment.Number,
+						installment.Principal, installment.Interest, tt.principals[i], tt.interests[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildLoanScheduleRepaysPrincipal(t *testing.T) {
+	for _, scheduleType := range []LoanScheduleType{ScheduleAnnuity, ScheduleLinear} {
+		schedule := BuildLoanSchedule(120000, 0.15, 12, scheduleType, date(2025, time.January, 10), 2)
+		total := 0.0
+		for _, installment := range schedule {
+			total = RoundCents(total + installment.Principal)
+		}
+		if total != 120000 {
+			t.Errorf("%s: principal parts add up to %.2f, want 120000", scheduleType, total)
+		}
+	}
+
+	schedule := BuildLoanSchedule(120000, 0.15, 12, ScheduleAnnuity, date(2025, time.January, 10), 2)
+	if first := schedule[0]; first.Principal != 9331 || first.Interest != 1500 {
+		t.Errorf("first annuity installment = %.2f + %.2f, want 9331.00 + 1500.00", first.Principal, first.Interest)
+	}
+}
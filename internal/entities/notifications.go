@@ -0,0 +1,90 @@
+package entities
+
+import "time"
+
+type NotificationEvent string
+
+const (
+	NotificationAccountCreated       NotificationEvent = "account.created"
+	NotificationTransactionCompleted NotificationEvent = "transaction.completed"
+	NotificationAccountOverdrawn     NotificationEvent = "account.overdrawn"
+)
+
+// NotificationEvents lists event types a user can configure preferences for.
+var NotificationEvents = []NotificationEvent{
+	NotificationAccountCreated,
+	NotificationTransactionCompleted,
+	NotificationAccountOverdrawn,
+}
+
+type NotificationChannel string
+
+const (
+	ChannelInApp NotificationChannel = "in_app"
+	ChannelEmail NotificationChannel = "email"
+	ChannelSMS   NotificationChannel = "sms"
+	ChannelPush  NotificationChannel = "push"
+)
+
+// NotificationChannels lists all delivery channels.
+var NotificationChannels = []NotificationChannel{
+	ChannelInApp,
+	ChannelEmail,
+	ChannelSMS,
+	ChannelPush,
+}
+
+// Notification is an in-app inbox message.
+// @Description In-app notification
+// @example { "id": 1, "event": "account.created", "title": "Счёт открыт", "body": "Открыт счёт №3 в RUB", "read": false, "created_at": "2025-01-01T10:00:00Z" }
+type Notification struct {
+	ID        uint              `gorm:"primaryKey" json:"id"`
+	UserID    uint              `gorm:"index;not null" json:"-"`
+	Event     NotificationEvent `gorm:"not null" json:"event"`
+	Title     string            `gorm:"not null" json:"title"`
+	Body      string            `json:"body"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+	CreatedAt time.Time         `gorm:"index" json:"created_at"`
+}
+
+// NotificationPreference overrides the default delivery of an event through a channel.
+type NotificationPreference struct {
+	ID      uint                `gorm:"primaryKey" json:"-"`
+	UserID  uint                `gorm:"uniqueIndex:idx_notification_pref;not null" json:"-"`
+	Event   NotificationEvent   `gorm:"uniqueIndex:idx_notification_pref;not null" json:"event"`
+	Channel NotificationChannel `gorm:"uniqueIndex:idx_notification_pref;not null" json:"channel"`
+	Enabled bool                `gorm:"not null" json:"enabled"`
+}
+
+// NotificationFilter is used to list the inbox.
+type NotificationFilter struct {
+	UserID     uint
+	UnreadOnly bool
+	Page       int
+	Limit      int
+}
+
+// NotificationsPage is a page of the inbox.
+// @Description Paginated inbox with the number of unread notifications.
+// @example { "items": [], "total": 0, "unread": 0, "page": 1, "limit": 20 }
+type NotificationsPage struct {
+	Items  []*Notification `json:"items"`
+	Total  int64           `json:"total"`
+	Unread int64           `json:"unread"`
+	Page   int             `json:"page"`
+	Limit  int             `json:"limit"`
+}
+
+// UpdateNotificationPreferencesRequest changes delivery preferences.
+// @Description Enable or disable channels per event type.
+// @example { "preferences": [{ "event": "transaction.completed", "channel": "sms", "enabled": true }] }
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreference `json:"preferences" binding:"required,min=1"`
+}
+
+// NotificationPreferencesResponse is the effective delivery matrix of a user.
+// @Description Effective channels per event type, including defaults.
+// @example { "preferences": [{ "event": "account.created", "channel": "in_app", "enabled": true }] }
+type NotificationPreferencesResponse struct {
+	Preferences []NotificationPreference `json:"preferences"`
+}
@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+// Payee is a saved transfer beneficiary of a user.
+// Transfers to the payee are allowed only after ActiveFrom (the cooling-off period).
+// @Description Saved beneficiary
+// @example { "id": 1, "nickname": "Мама", "account_id": 42, "currency": "RUB", "active_from": "2025-01-02T10:00:00Z", "created_at": "2025-01-01T10:00:00Z" }
+type Payee struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"uniqueIndex:idx_payee_account;not null" json:"-"`
+	Nickname   string    `gorm:"not null" json:"nickname"`
+	AccountID  uint      `gorm:"uniqueIndex:idx_payee_account;not null" json:"account_id"`
+	Currency   string    `gorm:"not null" json:"currency"`
+	ActiveFrom time.Time `json:"active_from"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CreatePayeeRequest represents the payload required to save a payee.
+// @Description Request payload for saving a beneficiary. account_id is the destination account number.
+// @example { "nickname": "Мама", "account_id": 42, "currency": "RUB" }
+type CreatePayeeRequest struct {
+	Nickname  string `json:"nickname" binding:"required,max=64"`
+	AccountID uint   `json:"account_id" binding:"required"`
+	Currency  string `json:"currency" binding:"required,len=3"`
+}
+
+// UpdatePayeeRequest represents the payload to rename a payee.
+// @Description Request payload for renaming a beneficiary.
+// @example { "nickname": "Мама (вклад)" }
+type UpdatePayeeRequest struct {
+	Nickname string `json:"nickname" binding:"required,max=64"`
+}
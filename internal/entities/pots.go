@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// Pot is money set aside inside an account for a goal. The pot balance stays part of
+// the account balance but is not available for spending until it is moved back out.
+// WeeklyAmount is moved into the pot every week on WeeklyDay; when RoundUp is set,
+// every debit from the account is rounded up to a multiple of it and the difference
+// goes to the pot.
+// @Description Savings pot inside an account
+// @example { "id": 1, "account_id": 1, "user_id": 2, "name": "Отпуск", "balance": 12000, "target_amount": 100000, "target_date": "2025-07-01T00:00:00Z", "weekly_amount": 2000, "weekly_day": 1, "round_up": 100, "created_at": "2025-01-01T12:00:00Z" }
+type Pot struct {
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	AccountID    uint         `gorm:"index;not null" json:"account_id"`
+	UserID       uint         `gorm:"not null" json:"user_id"`
+	Name         string       `gorm:"not null" json:"name"`
+	Balance      float64      `gorm:"not null;default:0" json:"balance"`
+	TargetAmount float64      `gorm:"not null;default:0" json:"target_amount"`
+	TargetDate   *time.Time   `gorm:"type:date" json:"target_date,omitempty"`
+	WeeklyAmount float64      `gorm:"not null;default:0" json:"weekly_amount"`
+	WeeklyDay    time.Weekday `gorm:"not null;default:0" json:"weekly_day"`
+	RoundUp      float64      `gorm:"not null;default:0" json:"round_up"`
+	// LastWeeklyOn is the business date of the last weekly saving, so a day is not saved twice.
+	LastWeeklyOn *time.Time `gorm:"type:date" json:"last_weekly_on,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// RoundUpOf returns how much a debit of amount adds to the pot when rounded up to a
+// multiple of RoundUp; 0 when round-ups are off or the amount is already round.
+func (p *Pot) RoundUpOf(amount float64) float64 {
+	if p.RoundUp <= 0 {
+		return 0
+	}
+	cents := math.Round(amount * 100)
+	step := math.Round(p.RoundUp * 100)
+	return (math.Ceil(cents/step)*step - cents) / 100
+}
+
+// CreatePotRequest creates a pot in an account. TargetDate is a date in the format
+// 2006-01-02; WeeklyDay is 0 for Sunday through 6 for Saturday.
+// @Description Request to create a savings pot
+// @example { "name": "Отпуск", "target_amount": 100000, "target_date": "2025-07-01", "weekly_amount": 2000, "weekly_day": 1, "round_up": 100 }
+type CreatePotRequest struct {
+	Name         string  `json:"name" binding:"required,max=100"`
+	TargetAmount float64 `json:"target_amount" binding:"gte=0"`
+	TargetDate   string  `json:"target_date" binding:"omitempty,datetime=2006-01-02"`
+	WeeklyAmount float64 `json:"weekly_amount" binding:"gte=0"`
+	WeeklyDay    int     `json:"weekly_day" binding:"gte=0,lte=6"`
+	RoundUp      float64 `json:"round_up" binding:"gte=0"`
+}
+
+// UpdatePotRequest changes a pot. Omitted fields are left as they are; an empty
+// target_date removes the target date and zero amounts turn the rules off.
+// @Description Request to update a savings pot and its saving rules
+// @example { "weekly_amount": 3000, "round_up": 0 }
+type UpdatePotRequest struct {
+	Name         *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	TargetAmount *float64 `json:"target_amount" binding:"omitempty,gte=0"`
+	TargetDate   *string  `json:"target_date" binding:"omitempty"`
+	WeeklyAmount *float64 `json:"weekly_amount" binding:"omitempty,gte=0"`
+	WeeklyDay    *int     `json:"weekly_day" binding:"omitempty,gte=0,lte=6"`
+	RoundUp      *float64 `json:"round_up" binding:"omitempty,gte=0"`
+}
+
+// PotTransferRequest moves money between a pot and its account.
+// @Description Amount to move into or out of a pot
+// @example { "amount": 5000 }
+type PotTransferRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "testing"
+
+func TestPotRoundUpOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		roundUp float64
+		amount  float64
+		want    float64
+	}{
+		{name: "round-ups off", roundUp: 0, amount: 1490, want: 0},
+		{name: "up to hundreds", roundUp: 100, amount: 1490, want: 10},
+		{name: "already round", roundUp: 100, amount: 1500, want: 0},
+		{name: "cents up to a whole unit", roundUp: 1, amount: 12.34, want: 0.66},
+		{name: "one cent short", roundUp: 10, amount: 99.99, want: 0.01},
+		{name: "fractional step", roundUp: 0.5, amount: 3.2, want: 0.3},
+		{name: "amount below the step", roundUp: 50, amount: 0.01, want: 49.99},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pot := &Pot{RoundUp: tt.roundUp}
+			if got := pot.RoundUpOf(tt.amount); got != tt.want {
+				t.Errorf("RoundUpOf(%v) with step %v = %v, want %v", tt.amount, tt.roundUp, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package entities
+
+import "time"
+
+type ErasureStatus string
+
+const (
+	ErasureStatusRequested ErasureStatus = "requested"
+	ErasureStatusCompleted ErasureStatus = "completed"
+	ErasureStatusRejected  ErasureStatus = "rejected"
+)
+
+// ErasureRequest represents a user's request to erase personal data.
+// @Description Erasure request processed by staff; financial records are retained.
+type ErasureRequest struct {
+	ID          uint          `gorm:"primaryKey" json:"id"`
+	UserID      uint          `gorm:"index;not null" json:"user_id"`
+	Status      ErasureStatus `gorm:"index;not null" json:"status"`
+	Reason      string        `json:"reason,omitempty"`
+	ProcessedBy *uint         `json:"processed_by,omitempty"`
+	ProcessedAt *time.Time    `json:"processed_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// ProcessErasureRequest represents a staff decision on an erasure request.
+// @Description Approve (execute) or reject an erasure request.
+// @example { "approve": false, "reason": "Open accounts with balance" }
+type ProcessErasureRequest struct {
+	Approve bool   `json:"approve"`
+	Reason  string `json:"reason"`
+}
+
+// PersonalDataExport is the archive with all data held about a user.
+// @Description Export of the user's profile, KYC data, accounts, transactions, sessions, API keys, notifications, payees, cards and loans.
+type PersonalDataExport struct {
+	GeneratedAt             time.Time                 `json:"generated_at"`
+	Profile                 *UserResponse             `json:"profile"`
+	KYC                     *KYCProfileDTO            `json:"kyc,omitempty"`
+	Accounts                []*AccountResponse        `json:"accounts"`
+	Transactions            []Transaction             `json:"transactions"`
+	Sessions                []*SessionResponse        `json:"sessions"`
+	APIKeys                 []*APIKeyResponse         `json:"api_keys"`
+	Notifications           []*Notification           `json:"notifications"`
+	NotificationPreferences []*NotificationPreference `json:"notification_preferences"`
+	Payees                  []*Payee                  `json:"payees"`
+	Cards                   []*CardResponse           `json:"cards"`
+	Loans                   []*Loan                   `json:"loans"`
+}
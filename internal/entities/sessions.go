@@ -0,0 +1,47 @@
+package entities
+
+import "time"
+
+// Session represents a single device session of a user.
+// @Description Session is stored in Redis and bound to one refresh token.
+type Session struct {
+	ID               string    `json:"id"`
+	UserID           uint      `json:"user_id"`
+	RefreshTokenHash string    `json:"refresh_token_hash"`
+	UserAgent        string    `json:"user_agent"`
+	IP               string    `json:"ip"`
+	CreatedAt        time.Time `json:"created_at"`
+	LastUsedAt       time.Time `json:"last_used_at"`
+}
+
+// SessionResponse represents the public view of a session.
+// @Description Active session of the authenticated user.
+// @example { "id": "9f2c...", "user_agent": "Mozilla/5.0", "ip": "10.0.0.1", "created_at": "2025-01-01T10:00:00Z", "last_used_at": "2025-01-02T10:00:00Z", "current": true }
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
+
+func (s *Session) ToResponse(currentSessionID string) *SessionResponse {
+	return &SessionResponse{
+		ID:         s.ID,
+		UserAgent:  s.UserAgent,
+		IP:         s.IP,
+		CreatedAt:  s.CreatedAt,
+		LastUsedAt: s.LastUsedAt,
+		Current:    s.ID == currentSessionID,
+	}
+}
+
+// SessionsToResponse converts a list of Sessions to a list of SessionResponses.
+func SessionsToResponse(sessions []*Session, currentSessionID string) []*SessionResponse {
+	responses := make([]*SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, session.ToResponse(currentSessionID))
+	}
+	return responses
+}
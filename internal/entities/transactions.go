@@ -8,16 +8,30 @@ const (
 	InternalTransfer TransferType = "internal"
 	ExternalTransfer TransferType = "external"
 	Deposit          TransferType = "deposit"
+	Withdrawal       TransferType = "withdrawal"
+	Interest         TransferType = "interest"
+	// OverdraftInterest is interest charged on a negative balance.
+	OverdraftInterest TransferType = "overdraft_interest"
+	// Capture is money taken from an account by capturing a hold.
+	Capture TransferType = "capture"
+	// Fee is a fee charged for another transaction, linked to it by ParentID.
+	Fee TransferType = "fee"
+	// LoanDisbursement pays an approved loan into the borrower's account.
+	LoanDisbursement TransferType = "loan_disbursement"
+	// LoanRepayment is a scheduled loan installment taken from the borrower's account.
+	LoanRepayment TransferType = "loan_repayment"
 )
 
 // TransferRequest represents a request to initiate a transfer between accounts.
+// Either ToAccountID or PayeeID (a saved beneficiary) identifies the destination.
 // @Description TransferRequest is used to initiate a transfer between two accounts.
 // @Model
 type TransferRequest struct {
 	UserID        uint         `json:"user_id"`
 	FromAccountID uint         `json:"from_account_id"`
 	ToAccountID   uint         `json:"to_account_id"`
-	Amount        float64      `json:"amount"`
+	PayeeID       uint         `json:"payee_id,omitempty"`
+	Amount        float64      `json:"amount" binding:"required,gt=0"`
 	Description   string       `json:"description,omitempty"`
 	Type          TransferType `json:"type"`
 }
@@ -33,6 +47,7 @@ type Transaction struct {
 	Amount        float64      `json:"amount"`
 	Description   string       `json:"description"`
 	Type          TransferType `json:"type"`
+	ParentID      *uint        `gorm:"index" json:"parent_id,omitempty"`
 	CreatedAt     time.Time    `json:"created_at"`
 }
 
@@ -40,12 +55,13 @@ type Transaction struct {
 // @Description TransactionFilter is used to filter transactions based on criteria like date, amount, and type.
 // @Model
 type TransactionFilter struct {
-	UserID    uint       `json:"user_id"`
-	FromDate  *time.Time `json:"from_date"`
-	ToDate    *time.Time `json:"to_date"`
-	Type      *string    `json:"type"`
-	MinAmount *float64   `json:"min_amount"`
-	MaxAmount *float64   `json:"max_amount"`
-	Page      int        `json:"page"`
-	Limit     int        `json:"limit"`
+	UserID     uint       `json:"user_id"`
+	AccountIDs []uint     `json:"-"`
+	FromDate   *time.Time `json:"from_date"`
+	ToDate     *time.Time `json:"to_date"`
+	Type       *string    `json:"type"`
+	MinAmount  *float64   `json:"min_amount"`
+	MaxAmount  *float64   `json:"max_amount"`
+	Page       int        `json:"page"`
+	Limit      int        `json:"limit"`
 }
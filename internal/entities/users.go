@@ -1,22 +1,81 @@
 package entities
 
+import (
+	"fmt"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleSupport  Role = "support"
+	RoleAdmin    Role = "admin"
+)
+
 // User represents a user in the system.
 // @Description User model
-// @example { "id": 1, "email": "user@example.com", "username": "user1", password: "123456" }
+// @example { "id": 1, "email": "user@example.com", "username": "user1", password: "123456", "role": "customer" }
 type User struct {
-	ID       uint   `gorm:"primaryKey"`
-	Email    string `gorm:"unique"`
-	Username string
-	Password string
+	ID          uint   `gorm:"primaryKey"`
+	Email       string `gorm:"unique"`
+	Username    string
+	Password    string `cache:"-"`
+	Role        Role   `gorm:"not null;default:customer"`
+	BlockedAt   *time.Time
+	BlockReason string
+	ErasedAt    *time.Time
 }
 
 // UserResponse represents the public view of a user, safe to be returned in API responses.
 // @Description Public user information without sensitive fields like password.
-// @example { "id": 1, "email": "user@example.com", "username": "user1" }
+// @example { "id": 1, "email": "user@example.com", "username": "user1", "role": "customer", "blocked": false }
 type UserResponse struct {
 	ID       uint   `json:"id"`
 	Email    string `json:"email"`
 	Username string `json:"username"`
+	Role     Role   `json:"role"`
+	Blocked  bool   `json:"blocked"`
+}
+
+// UserFilter is used to search and paginate users.
+// @Description UserFilter is used to search users by email or username with sorting and pagination.
+type UserFilter struct {
+	Query   string `json:"query"`
+	Role    *Role  `json:"role"`
+	Blocked *bool  `json:"blocked"`
+	SortBy  string `json:"sort_by"`
+	Desc    bool   `json:"desc"`
+	Page    int    `json:"page"`
+	Limit   int    `json:"limit"`
+}
+
+// UsersPage is a page of users with the total number of matches.
+// @Description Paginated list of users.
+// @example { "items": [], "total": 0, "page": 1, "limit": 20 }
+type UsersPage struct {
+	Items []*UserResponse `json:"items"`
+	Total int64           `json:"total"`
+	Page  int             `json:"page"`
+	Limit int             `json:"limit"`
+}
+
+// UserDetailsResponse is the admin view of a user with accounts and status.
+// @Description Admin view of a user including block status, KYC status and accounts.
+type UserDetailsResponse struct {
+	User        *UserResponse      `json:"user"`
+	BlockedAt   *time.Time         `json:"blocked_at,omitempty"`
+	BlockReason string             `json:"block_reason,omitempty"`
+	ErasedAt    *time.Time         `json:"erased_at,omitempty"`
+	KYCStatus   KYCStatus          `json:"kyc_status"`
+	Accounts    []*AccountResponse `json:"accounts"`
+}
+
+// BlockUserRequest is used to block a user.
+// @Description Block user model
+// @example { "reason": "Suspicious activity" }
+type BlockUserRequest struct {
+	Reason string `json:"reason" binding:"required"`
 }
 
 // UpdateUserRequest is used to update user fields.
@@ -27,14 +86,36 @@ type UpdateUserRequest struct {
 	Username *string `json:"username,omitempty"`
 }
 
+// UpdateRoleRequest is used to change a user's role.
+// @Description Update user role model
+// @example { "role": "support" }
+type UpdateRoleRequest struct {
+	Role Role `json:"role" binding:"required,oneof=customer support admin"`
+}
+
+// Pseudonymize replaces personal data with placeholders derived from the user ID.
+func (u *User) Pseudonymize(at time.Time) {
+	u.Email = fmt.Sprintf("erased-%d@erased.invalid", u.ID)
+	u.Username = fmt.Sprintf("erased-user-%d", u.ID)
+	u.Password = ""
+	u.ErasedAt = &at
+}
+
 func (u *User) ToResponse() *UserResponse {
 	return &UserResponse{
 		ID:       u.ID,
 		Email:    u.Email,
 		Username: u.Username,
+		Role:     u.Role,
+		Blocked:  u.IsBlocked(),
 	}
 }
 
+// IsBlocked reports whether the user has been blocked by an administrator.
+func (u *User) IsBlocked() bool {
+	return u.BlockedAt != nil
+}
+
 // UsersToResponse converts a list of Users to a list of UserResponses.
 func UsersToResponse(users []*User) []*UserResponse {
 	responses := make([]*UserResponse, 0, len(users))
@@ -0,0 +1,136 @@
+package cache
+
+import (
+	lib "bank-app-backend/internal/lib/logger"
+	redis "bank-app-backend/internal/lib/redis"
+	"context"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// minResubscribeDelay и maxResubscribeDelay — пределы паузы перед повторной подпиской
+	minResubscribeDelay = time.Second
+	maxResubscribeDelay = time.Minute
+	// sweepInterval — как часто из памяти удаляются истёкшие локальные записи
+	sweepInterval = time.Minute
+)
+
+// evicter — локальный (in-process) уровень кеша, который можно сбросить по ключу или целиком
+type evicter interface {
+	evictLocal(key string)
+	evictAll()
+	sweep(now time.Time)
+}
+
+// Bus рассылает инвалидации через Redis pub/sub, чтобы остальные инстансы
+// сервиса сбросили свои локальные копии
+type Bus struct {
+	client  *redis.Client
+	channel string
+
+	mu     sync.RWMutex
+	caches map[string]evicter
+}
+
+func NewBus(client *redis.Client, channel string) *Bus {
+	return &Bus{
+		client:  client,
+		channel: channel,
+		caches:  make(map[string]evicter),
+	}
+}
+
+// Run подписывается на канал инвалидаций и блокируется до отмены ctx. Оборвавшуюся
+// подписку Run возобновляет с растущей паузой и сбрасывает локальные копии: инвалидации,
+// отправленные за время обрыва, до инстанса не дошли. Пока Run работает, истёкшие
+// локальные записи периодически удаляются из памяти.
+func (b *Bus) Run(ctx context.Context) {
+	go b.sweep(ctx)
+
+	delay := minResubscribeDelay
+	for {
+		started := time.Now()
+		err := b.client.Subscribe(ctx, b.channel, b.handle)
+		if ctx.Err() != nil {
+			return
+		}
+
+		b.evictAll()
+		if time.Since(started) > maxResubscribeDelay {
+			delay = minResubscribeDelay
+		}
+		lib.Log.Error("Cache invalidation subscription stopped, resubscribing",
+			zap.String("channel", b.channel), zap.Duration("delay", delay), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxResubscribeDelay)
+	}
+}
+
+func (b *Bus) handle(key string) {
+	prefix, _, found := strings.Cut(key, ":")
+	if !found {
+		return
+	}
+
+	b.mu.RLock()
+	c, ok := b.caches[prefix]
+	b.mu.RUnlock()
+
+	if ok {
+		c.evictLocal(key)
+	}
+}
+
+func (b *Bus) evictAll() {
+	for _, c := range b.registered() {
+		c.evictAll()
+	}
+}
+
+// sweep раз в sweepInterval удаляет истёкшие локальные записи всех кешей до отмены ctx
+func (b *Bus) sweep(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, c := range b.registered() {
+				c.sweep(now)
+			}
+		}
+	}
+}
+
+func (b *Bus) registered() []evicter {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	caches := make([]evicter, 0, len(b.caches))
+	for _, c := range b.caches {
+		caches = append(caches, c)
+	}
+	return caches
+}
+
+func (b *Bus) register(prefix string, c evicter) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.caches[prefix] = c
+}
+
+func (b *Bus) publish(ctx context.Context, key string) {
+	if err := b.client.Publish(ctx, b.channel, key); err != nil {
+		lib.Log.Error("Failed to publish cache invalidation", zap.String("key", key), zap.Error(err))
+	}
+}
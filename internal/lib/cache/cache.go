@@ -0,0 +1,217 @@
+package cache
+
+import (
+	lib "bank-app-backend/internal/lib/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Options задаёт время жизни записей сущности
+type Options struct {
+	// TTL — время жизни записи в Redis
+	TTL time.Duration
+	// LocalTTL — время жизни копии в памяти инстанса; 0 отключает локальный уровень
+	LocalTTL time.Duration
+}
+
+type localEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// Cache — двухуровневый кеш сущности T: локальная память инстанса и Redis.
+// Чтение идёт через кеш (read-through), запись обновляет Redis (write-through),
+// а инвалидация рассылается остальным инстансам через Bus.
+// Поля с тегом `cache:"-"` в кеш не попадают.
+type Cache[T any] struct {
+	bus    *Bus
+	prefix string
+	opts   Options
+
+	mu    sync.RWMutex
+	local map[string]localEntry
+	// generation растёт при каждом сбросе локальных копий. Get запоминает её до чтения и
+	// не кеширует значение, если за время загрузки кеш сбрасывали: оно могло устареть.
+	generation uint64
+}
+
+func New[T any](bus *Bus, prefix string, opts Options) *Cache[T] {
+	c := &Cache[T]{
+		bus:    bus,
+		prefix: prefix,
+		opts:   opts,
+		local:  make(map[string]localEntry),
+	}
+	bus.register(prefix, c)
+	return c
+}
+
+// Get возвращает значение из кеша, а при промахе загружает его через load
+// и сохраняет в кеш. Возвращаемое значение всегда без чувствительных полей.
+func (c *Cache[T]) Get(ctx context.Context, id interface{}, load func(ctx context.Context) (*T, error)) (*T, error) {
+	key := c.key(id)
+	generation := c.currentGeneration()
+
+	if data, ok := c.getLocal(key); ok {
+		if value, err := decode[T](data); err == nil {
+			return value, nil
+		}
+	}
+
+	if cached, err := c.bus.client.Get(ctx, key); err == nil {
+		if value, err := decode[T]([]byte(cached)); err == nil {
+			c.setLocal(key, []byte(cached), generation)
+			return value, nil
+		}
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sanitized := sanitize(value)
+	if c.currentGeneration() != generation {
+		return sanitized, nil
+	}
+	if err := c.store(ctx, key, sanitized, generation); err != nil {
+		lib.Log.Error("Failed to cache value", zap.String("key", key), zap.Error(err))
+	}
+
+	return sanitized, nil
+}
+
+// Set записывает новое значение в Redis и сбрасывает локальные копии на других инстансах
+func (c *Cache[T]) Set(ctx context.Context, id interface{}, value *T) error {
+	key := c.key(id)
+
+	if err := c.store(ctx, key, sanitize(value), c.currentGeneration()); err != nil {
+		return err
+	}
+
+	c.bus.publish(ctx, key)
+	return nil
+}
+
+// Invalidate удаляет значение из Redis и из локальной памяти всех инстансов
+func (c *Cache[T]) Invalidate(ctx context.Context, id interface{}) error {
+	key := c.key(id)
+
+	c.evictLocal(key)
+	if err := c.bus.client.Del(ctx, key); err != nil {
+		return err
+	}
+
+	c.bus.publish(ctx, key)
+	return nil
+}
+
+// store записывает значение в Redis и в локальную память; локальная копия не сохраняется,
+// если с момента generation кеш сбрасывали
+func (c *Cache[T]) store(ctx context.Context, key string, value *T, generation uint64) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if err := c.bus.client.Set(ctx, key, data, c.opts.TTL); err != nil {
+		return err
+	}
+
+	c.setLocal(key, data, generation)
+	return nil
+}
+
+func (c *Cache[T]) key(id interface{}) string {
+	return fmt.Sprintf("%s:%v", c.prefix, id)
+}
+
+func (c *Cache[T]) getLocal(key string) ([]byte, bool) {
+	if c.opts.LocalTTL <= 0 {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	entry, ok := c.local[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.data, true
+}
+
+func (c *Cache[T]) setLocal(key string, data []byte, generation uint64) {
+	if c.opts.LocalTTL <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.local[key] = localEntry{data: data, expiresAt: time.Now().Add(c.opts.LocalTTL)}
+	}
+	c.mu.Unlock()
+}
+
+func (c *Cache[T]) currentGeneration() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation
+}
+
+func (c *Cache[T]) evictLocal(key string) {
+	c.mu.Lock()
+	delete(c.local, key)
+	c.generation++
+	c.mu.Unlock()
+}
+
+func (c *Cache[T]) evictAll() {
+	c.mu.Lock()
+	c.local = make(map[string]localEntry)
+	c.generation++
+	c.mu.Unlock()
+}
+
+// sweep удаляет из памяти истёкшие локальные записи, которые больше никто не запросил
+func (c *Cache[T]) sweep(now time.Time) {
+	c.mu.Lock()
+	for key, entry := range c.local {
+		if now.After(entry.expiresAt) {
+			delete(c.local, key)
+		}
+	}
+	c.mu.Unlock()
+}
+
+func decode[T any](data []byte) (*T, error) {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// sanitize возвращает копию значения с обнулёнными полями, помеченными `cache:"-"`
+func sanitize[T any](value *T) *T {
+	copied := *value
+
+	v := reflect.ValueOf(&copied).Elem()
+	if v.Kind() != reflect.Struct {
+		return &copied
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("cache") == "-" && v.Field(i).CanSet() {
+			v.Field(i).Set(reflect.Zero(t.Field(i).Type))
+		}
+	}
+
+	return &copied
+}
@@ -2,25 +2,60 @@ package kafka
 
 import (
 	lib "bank-app-backend/internal/lib/logger"
+	"context"
+	"errors"
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"go.uber.org/zap"
+	"time"
 )
 
-func RunConsumer(brokers, topic, groupID string) {
-	c, _ := kafka.NewConsumer(&kafka.ConfigMap{
+// MessageHandler обрабатывает сообщение из топика topic
+type MessageHandler func(ctx context.Context, topic string, key, value []byte) error
+
+// pollTimeout — как часто консьюмер проверяет отмену ctx, пока нет сообщений
+const pollTimeout = time.Second
+
+// RunConsumer читает сообщения из топиков и передаёт их в handler, пока не отменён ctx.
+// Ошибка обработки логируется, сообщение не перечитывается.
+func RunConsumer(ctx context.Context, brokers string, topics []string, groupID string, handler MessageHandler) error {
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
 		"bootstrap.servers": brokers,
 		"group.id":          groupID,
 		"auto.offset.reset": "earliest",
 	})
-	err := c.SubscribeTopics([]string{topic}, nil)
 	if err != nil {
-		return
+		return err
 	}
+	defer c.Close()
 
-	for {
-		msg, err := c.ReadMessage(-1)
-		if err == nil {
-			lib.Log.Info("Received message", zap.String("value", string(msg.Value)))
+	if err := c.SubscribeTopics(topics, nil); err != nil {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		msg, err := c.ReadMessage(pollTimeout)
+		if err != nil {
+			var kafkaErr kafka.Error
+			if errors.As(err, &kafkaErr) && kafkaErr.Code() == kafka.ErrTimedOut {
+				continue
+			}
+			lib.Log.Error("Kafka read error", zap.Error(err))
+			continue
+		}
+
+		topic := ""
+		if msg.TopicPartition.Topic != nil {
+			topic = *msg.TopicPartition.Topic
+		}
+
+		if err := handler(ctx, topic, msg.Key, msg.Value); err != nil {
+			lib.Log.Error("Failed to handle Kafka message",
+				zap.String("topic", topic),
+				zap.String("value", string(msg.Value)),
+				zap.Error(err),
+			)
 		}
 	}
+
+	return ctx.Err()
 }
@@ -0,0 +1,75 @@
+package notify
+
+import (
+	lib "bank-app-backend/internal/lib/logger"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Message — уведомление, отправляемое во внешний канал
+type Message struct {
+	UserID  uint
+	To      string
+	Event   string
+	Subject string
+	Body    string
+}
+
+// Sender доставляет сообщения через конкретный канал (email, SMS, push).
+// Реальные провайдеры подключаются реализацией этого интерфейса.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogSender пишет сообщения в лог приложения; используется вместо реального провайдера
+type LogSender struct {
+	Channel string
+}
+
+func (s LogSender) Send(_ context.Context, msg Message) error {
+	lib.Log.Info("Notification sent",
+		zap.String("channel", s.Channel),
+		zap.Uint("user_id", msg.UserID),
+		zap.String("to", msg.To),
+		zap.String("event", msg.Event),
+		zap.String("subject", msg.Subject),
+	)
+	return nil
+}
+
+// FileSender сохраняет каждое сообщение отдельным файлом в Dir/<channel>;
+// позволяет проверять исходящие письма локально
+type FileSender struct {
+	Channel string
+	Dir     string
+}
+
+func (s FileSender) Send(_ context.Context, msg Message) error {
+	dir := filepath.Join(s.Dir, s.Channel)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-user%d-%d.txt", now.Format("20060102T150405"), msg.UserID, now.UnixNano())
+	content := fmt.Sprintf("To: %s\nEvent: %s\nDate: %s\nSubject: %s\n\n%s\n",
+		msg.To, msg.Event, now.Format(time.RFC3339), msg.Subject, msg.Body)
+
+	return os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+}
+
+// NewSender создаёт заглушку канала по типу из конфигурации: "file" или "log"
+func NewSender(channel, kind, dir string) (Sender, error) {
+	switch kind {
+	case "file":
+		return FileSender{Channel: channel, Dir: dir}, nil
+	case "log", "":
+		return LogSender{Channel: channel}, nil
+	default:
+		return nil, fmt.Errorf("unknown sender %q for channel %s", kind, channel)
+	}
+}
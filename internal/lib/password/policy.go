@@ -0,0 +1,109 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+// commonPasswords — множество распространённых паролей из встроенного списка
+var commonPasswords = loadCommonPasswords(commonPasswordsList)
+
+// ErrWeakPassword возвращается, если пароль не удовлетворяет политике
+var ErrWeakPassword = errors.New("password does not meet policy requirements")
+
+// bcryptMaxBytes — наибольшая длина пароля в байтах, которую принимает bcrypt
+const bcryptMaxBytes = 72
+
+// Policy описывает требования к паролю пользователя. MinLength считается в символах,
+// MaxLength — в байтах UTF-8, как его ограничивает bcrypt.
+type Policy struct {
+	MinLength      int
+	MaxLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	RejectCommon   bool
+}
+
+// Validate проверяет пароль на соответствие политике и возвращает ошибку,
+// перечисляющую все нарушенные требования
+func (p Policy) Validate(password string) error {
+	var violations []string
+
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+	maxBytes := bcryptMaxBytes
+	if p.MaxLength > 0 && p.MaxLength < maxBytes {
+		maxBytes = p.MaxLength
+	}
+	if len(password) > maxBytes {
+		violations = append(violations, fmt.Sprintf("must be at most %d bytes long", maxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if p.RequireSpecial && !hasSpecial {
+		violations = append(violations, "must contain a special character")
+	}
+	if p.RejectCommon && IsCommon(password) {
+		violations = append(violations, "is too common")
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("%w: %s", ErrWeakPassword, strings.Join(violations, ", "))
+	}
+
+	return nil
+}
+
+// IsCommon сообщает, входит ли пароль в список распространённых паролей
+func IsCommon(password string) bool {
+	_, ok := commonPasswords[strings.ToLower(password)]
+	return ok
+}
+
+func loadCommonPasswords(list string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+
+	return passwords
+}
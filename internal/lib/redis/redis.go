@@ -40,3 +40,74 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 func (c *Client) Del(ctx context.Context, key string) error {
 	return c.rdb.Del(ctx, key).Err()
 }
+
+func (c *Client) Incr(ctx context.Context, key string) (int64, error) {
+	return c.rdb.Incr(ctx, key).Result()
+}
+
+// incrWithTTL увеличивает счётчик и задаёт срок жизни новому ключу или ключу, оставшемуся
+// без срока, одной атомарной операцией
+var incrWithTTL = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 or redis.call("PTTL", KEYS[1]) == -1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// IncrWithTTL увеличивает счётчик key; при создании ключ получает срок жизни ttl
+func (c *Client) IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrWithTTL.Run(ctx, c.rdb, []string{key}, ttl.Milliseconds()).Int64()
+}
+
+func (c *Client) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return c.rdb.Expire(ctx, key, ttl).Err()
+}
+
+func (c *Client) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return c.rdb.TTL(ctx, key).Result()
+}
+
+func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := c.rdb.Exists(ctx, key).Result()
+	return n > 0, err
+}
+
+func (c *Client) SAdd(ctx context.Context, key string, members ...interface{}) error {
+	return c.rdb.SAdd(ctx, key, members...).Err()
+}
+
+func (c *Client) SRem(ctx context.Context, key string, members ...interface{}) error {
+	return c.rdb.SRem(ctx, key, members...).Err()
+}
+
+func (c *Client) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.rdb.SMembers(ctx, key).Result()
+}
+
+func (c *Client) Publish(ctx context.Context, channel string, message interface{}) error {
+	return c.rdb.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe вызывает handler для каждого сообщения в канале, пока не отменён ctx
+func (c *Client) Subscribe(ctx context.Context, channel string, handler func(payload string)) error {
+	sub := c.rdb.Subscribe(ctx, channel)
+	defer sub.Close()
+
+	if _, err := sub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			handler(msg.Payload)
+		}
+	}
+}
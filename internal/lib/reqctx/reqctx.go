@@ -0,0 +1,37 @@
+package reqctx
+
+import "context"
+
+// Meta — сведения о текущем запросе, которые нужны за пределами HTTP-слоя
+// (например, для журнала аудита)
+type Meta struct {
+	RequestID string
+	IP        string
+	UserAgent string
+	ActorID   uint
+	ActorRole string
+}
+
+type metaKey struct{}
+
+// With кладёт Meta в контекст. Middleware аутентификации дополняет
+// её через SetActor, поэтому в контексте хранится указатель.
+func With(ctx context.Context, meta *Meta) context.Context {
+	return context.WithValue(ctx, metaKey{}, meta)
+}
+
+// From возвращает Meta запроса или пустую Meta, если контекст не из HTTP-запроса
+func From(ctx context.Context) Meta {
+	if meta, ok := ctx.Value(metaKey{}).(*Meta); ok && meta != nil {
+		return *meta
+	}
+	return Meta{}
+}
+
+// SetActor запоминает аутентифицированного пользователя запроса
+func SetActor(ctx context.Context, userID uint, role string) {
+	if meta, ok := ctx.Value(metaKey{}).(*Meta); ok && meta != nil {
+		meta.ActorID = userID
+		meta.ActorRole = role
+	}
+}
@@ -12,20 +12,28 @@ var (
 	RefreshSecret = []byte("jwt_refresh_secret")
 )
 
-func GenerateTokens(user *entities.User) (accessToken string, refreshToken string, err error) {
-	accessToken, err = generateJWT(user, AccessSecret, time.Minute*15)
+const (
+	AccessTokenTTL  = time.Minute * 15
+	RefreshTokenTTL = time.Hour * 24 * 7
+)
+
+// GenerateTokens выпускает пару токенов, привязанную к сессии sessionID
+func GenerateTokens(user *entities.User, sessionID string) (accessToken string, refreshToken string, err error) {
+	accessToken, err = generateJWT(user, sessionID, AccessSecret, AccessTokenTTL)
 	if err != nil {
 		return
 	}
-	refreshToken, err = generateJWT(user, RefreshSecret, time.Hour*24*7)
+	refreshToken, err = generateJWT(user, sessionID, RefreshSecret, RefreshTokenTTL)
 	return
 }
 
-func generateJWT(user *entities.User, secret []byte, ttl time.Duration) (string, error) {
+func generateJWT(user *entities.User, sessionID string, secret []byte, ttl time.Duration) (string, error) {
 	claims := jwt.MapClaims{
 		"sub":   user.ID,
+		"sid":   sessionID,
 		"exp":   time.Now().Add(ttl).Unix(),
 		"email": user.Email,
+		"role":  string(user.Role),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AccountMembersRepository interface {
+	FindMember(ctx context.Context, accountID, userID uint) (*entities.AccountMember, error)
+	FindByAccount(ctx context.Context, accountID uint) ([]*entities.AccountMember, error)
+	UpdateMember(ctx context.Context, member *entities.AccountMember) error
+	DeleteMember(ctx context.Context, accountID, userID uint) error
+	DeleteByUser(ctx context.Context, userID uint) error
+	CreateInvitation(ctx context.Context, invitation *entities.AccountInvitation) error
+	FindInvitation(ctx context.Context, id uint) (*entities.AccountInvitation, error)
+	FindInvitationsByAccount(ctx context.Context, accountID uint) ([]*entities.AccountInvitation, error)
+	FindPendingInvitations(ctx context.Context, email string, now time.Time) ([]*entities.AccountInvitation, error)
+	UpdateInvitation(ctx context.Context, invitation *entities.AccountInvitation) error
+	AcceptInvitation(ctx context.Context, invitation *entities.AccountInvitation, member *entities.AccountMember) error
+}
+
+type accountMembersRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountMembersRepository(db *gorm.DB) AccountMembersRepository {
+	return &accountMembersRepository{db: db}
+}
+
+func (r *accountMembersRepository) FindMember(ctx context.Context, accountID, userID uint) (*entities.AccountMember, error) {
+	var member entities.AccountMember
+	if err := r.db.WithContext(ctx).First(&member, "account_id = ? AND user_id = ?", accountID, userID).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *accountMembersRepository) FindByAccount(ctx context.Context, accountID uint) ([]*entities.AccountMember, error) {
+	var members []*entities.AccountMember
+	if err := r.db.WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("created_at asc").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *accountMembersRepository) UpdateMember(ctx context.Context, member *entities.AccountMember) error {
+	return r.db.WithContext(ctx).Save(member).Error
+}
+
+func (r *accountMembersRepository) DeleteMember(ctx context.Context, accountID, userID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("account_id = ? AND user_id = ?", accountID, userID).
+		Delete(&entities.AccountMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteByUser удаляет пользователя из чужих счетов; членство владельца сохраняется
+func (r *accountMembersRepository) DeleteByUser(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND role <> ?", userID, entities.AccountRoleOwner).
+		Delete(&entities.AccountMember{}).Error
+}
+
+func (r *accountMembersRepository) CreateInvitation(ctx context.Context, invitation *entities.AccountInvitation) error {
+	return r.db.WithContext(ctx).Create(invitation).Error
+}
+
+func (r *accountMembersRepository) FindInvitation(ctx context.Context, id uint) (*entities.AccountInvitation, error) {
+	var invitation entities.AccountInvitation
+	if err := r.db.WithContext(ctx).First(&invitation, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *accountMembersRepository) FindInvitationsByAccount(ctx context.Context, accountID uint) ([]*entities.AccountInvitation, error) {
+	var invitations []*entities.AccountInvitation
+	if err := r.db.WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("created_at desc").
+		Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *accountMembersRepository) FindPendingInvitations(ctx context.Context, email string, now time.Time) ([]*entities.AccountInvitation, error) {
+	var invitations []*entities.AccountInvitation
+	if err := r.db.WithContext(ctx).
+		Where("email = ? AND status = ? AND expires_at > ?", email, entities.InvitationPending, now).
+		Order("created_at desc").
+		Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *accountMembersRepository) UpdateInvitation(ctx context.Context, invitation *entities.AccountInvitation) error {
+	return r.db.WithContext(ctx).Save(invitation).Error
+}
+
+// AcceptInvitation атомарно отмечает приглашение принятым и добавляет участника счёта
+func (r *accountMembersRepository) AcceptInvitation(ctx context.Context, invitation *entities.AccountInvitation, member *entities.AccountMember) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(invitation).Error; err != nil {
+			return err
+		}
+		return tx.Create(member).Error
+	})
+}
@@ -2,55 +2,315 @@ package repository
 
 import (
 	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/lib/cache"
+	lib "bank-app-backend/internal/lib/logger"
 	"context"
+	"fmt"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"slices"
+	"time"
 )
 
 type AccountsRepository interface {
 	GetAll(ctx context.Context, userID uint) ([]*entities.Account, error)
+	GetOwned(ctx context.Context, userID uint) ([]*entities.Account, error)
 	GetByID(ctx context.Context, userID, accountID uint) (*entities.Account, error)
+	FindByID(ctx context.Context, accountID uint) (*entities.Account, error)
 	Create(ctx context.Context, account *entities.Account) error
 	Update(ctx context.Context, account *entities.Account) error
+	ChangeStatus(ctx context.Context, accountID uint, check func(account *entities.Account) error) (*entities.Account, error)
+	Invalidate(ctx context.Context, accountID uint) error
+	FindInactive(ctx context.Context, since time.Time) ([]*entities.Account, error)
+	Post(ctx context.Context, posting *Posting) (from, to *entities.Account, err error)
 }
 
+// Posting — движение денег по счетам банка вместе с транзакцией, которая его описывает.
+// Нулевой FromAccountID или ToAccountID транзакции означает сторону за пределами банка.
+type Posting struct {
+	Transaction *entities.Transaction
+	// Debit — сумма списания со счёта отправителя; 0 — сумма транзакции
+	Debit float64
+	// Check вызывается под блокировкой счетов; вместо внешней стороны передаётся nil.
+	// Check может сменить статус счёта — новый статус сохраняется вместе с проводкой.
+	Check func(from, to *entities.Account) error
+	// Limits — учёт операции в лимитах пользователя; nil — операция лимитами не ограничена
+	Limits *LimitCharge
+	// Fee строит транзакцию комиссии по созданной основной транзакции; комиссия зачисляется
+	// на счёт банка из ToAccountID. nil — операция без комиссии.
+	Fee func(parent *entities.Transaction) *entities.Transaction
+	// Review — заявка антифрода в статусе approving, одобряемая этой проводкой. Post
+	// проставляет ей транзакцию и сохраняет решение в той же транзакции БД.
+	Review *entities.TransferReview
+}
+
+// bankTransactionTypes — операции, которые банк проводит сам; они не считаются активностью клиента
+var bankTransactionTypes = []entities.TransferType{entities.Interest, entities.OverdraftInterest, entities.LoanRepayment}
+
 type accountsRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	cache *cache.Cache[entities.Account]
 }
 
-func NewAccountsRepository(db *gorm.DB) AccountsRepository {
-	return &accountsRepository{db: db}
+func NewAccountsRepository(db *gorm.DB, accountCache *cache.Cache[entities.Account]) AccountsRepository {
+	return &accountsRepository{
+		db:    db,
+		cache: accountCache,
+	}
 }
 
+// GetAll возвращает все счета, участником которых является пользователь, в том числе совместные
 func (r accountsRepository) GetAll(ctx context.Context, userID uint) ([]*entities.Account, error) {
 	var accounts []*entities.Account
 
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&accounts).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN account_members ON account_members.account_id = accounts.id").
+		Where("account_members.user_id = ?", userID).
+		Order("accounts.id").
+		Find(&accounts).Error; err != nil {
 		return nil, err
 	}
 
 	return accounts, nil
 }
 
+// GetOwned возвращает только счета, владельцем которых является пользователь
+func (r accountsRepository) GetOwned(ctx context.Context, userID uint) ([]*entities.Account, error) {
+	var accounts []*entities.Account
+
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// GetByID возвращает счёт через кеш account:<id> и проверяет, что пользователь — его участник
 func (r accountsRepository) GetByID(ctx context.Context, userID, accountID uint) (*entities.Account, error) {
-	var account entities.Account
+	account, err := r.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
 
+	var members int64
 	if err := r.db.WithContext(ctx).
-		Where("user_id = ? AND id = ?", userID, accountID).
-		First(&account).Error; err != nil {
+		Model(&entities.AccountMember{}).
+		Where("account_id = ? AND user_id = ?", accountID, userID).
+		Count(&members).Error; err != nil {
 		return nil, err
 	}
+	if members == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return account, nil
+}
 
-	return &account, nil
+// FindByID возвращает счёт любого пользователя, например счёт получателя перевода
+func (r accountsRepository) FindByID(ctx context.Context, accountID uint) (*entities.Account, error) {
+	return r.cache.Get(ctx, accountID, func(ctx context.Context) (*entities.Account, error) {
+		var account entities.Account
+		if err := r.db.WithContext(ctx).First(&account, "id = ?", accountID).Error; err != nil {
+			return nil, err
+		}
+		return &account, nil
+	})
 }
 
+// Create создаёт счёт вместе с членством владельца
 func (r accountsRepository) Create(ctx context.Context, account *entities.Account) error {
-	if err := r.db.WithContext(ctx).Create(&account).Error; err != nil {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&entities.AccountMember{
+			AccountID: account.ID,
+			UserID:    account.UserID,
+			Role:      entities.AccountRoleOwner,
+		}).Error
+	})
+}
+
+// Update сохраняет реквизиты и статус счёта и сбрасывает кеш. Остаток, холды и копилки
+// меняются только SQL-выражениями под блокировкой счёта, поэтому здесь не перезаписываются:
+// иначе прочитанный из кеша остаток затёр бы параллельные операции.
+func (r accountsRepository) Update(ctx context.Context, account *entities.Account) error {
+	if err := r.db.WithContext(ctx).Omit("balance", "held", "pots").Save(account).Error; err != nil {
 		return err
 	}
 
+	return r.cache.Invalidate(ctx, account.ID)
+}
+
+// ChangeStatus в одной транзакции блокирует счёт, вызывает check, который проверяет счёт
+// и меняет его статус, и сохраняет новый статус. Под той же блокировкой, что и проводки,
+// проверка не разойдётся с параллельным списанием или холдом.
+func (r accountsRepository) ChangeStatus(ctx context.Context, accountID uint, check func(account *entities.Account) error) (*entities.Account, error) {
+	var account *entities.Account
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if account, err = lockAccount(tx, accountID); err != nil {
+			return err
+		}
+		if err := check(account); err != nil {
+			return err
+		}
+		return tx.Model(account).Select("status", "status_reason", "status_changed_at").Updates(account).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.cache.Invalidate(ctx, accountID); err != nil {
+		lib.Log.Warn("Failed to invalidate account cache", zap.Uint("account_id", accountID), zap.Error(err))
+	}
+	return account, nil
+}
+
+// Post в одной транзакции блокирует счета проводки, вызывает check, учитывает операцию
+// в лимитах, списывает и зачисляет деньги SQL-выражениями и создаёт транзакцию вместе
+// с комиссией. Возвращает счета с остатками после проводки.
+func (r accountsRepository) Post(ctx context.Context, posting *Posting) (from, to *entities.Account, err error) {
+	transaction := posting.Transaction
+	debit := posting.Debit
+	if debit == 0 {
+		debit = transaction.Amount
+	}
+
+	var feeAccountID uint
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accounts, err := lockAccounts(tx, transaction.FromAccountID, transaction.ToAccountID)
+		if err != nil {
+			return err
+		}
+		from, to = accounts[transaction.FromAccountID], accounts[transaction.ToAccountID]
+
+		statuses := make(map[uint]entities.AccountStatus, len(accounts))
+		for id, account := range accounts {
+			statuses[id] = account.Status
+		}
+		if posting.Check != nil {
+			if err := posting.Check(from, to); err != nil {
+				return err
+			}
+		}
+		for id, account := range accounts {
+			if account.Status == statuses[id] {
+				continue
+			}
+			if err := tx.Model(account).Select("status", "status_reason", "status_changed_at").Updates(account).Error; err != nil {
+				return err
+			}
+		}
+
+		if posting.Limits != nil {
+			if err := chargeLimits(tx, posting.Limits); err != nil {
+				return err
+			}
+		}
+
+		if from != nil {
+			if err := tx.Model(from).Update("balance", gorm.Expr("balance - ?", debit)).Error; err != nil {
+				return err
+			}
+			from.Balance -= debit
+		}
+		if to != nil {
+			if err := tx.Model(to).Update("balance", gorm.Expr("balance + ?", transaction.Amount)).Error; err != nil {
+				return err
+			}
+			to.Balance += transaction.Amount
+		}
+
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+
+		if posting.Review != nil {
+			posting.Review.TransactionID = &transaction.ID
+			if err := closeTransferReview(tx, posting.Review, entities.TransferReviewApproving); err != nil {
+				return err
+			}
+		}
+
+		if posting.Fee == nil {
+			return nil
+		}
+		fee := posting.Fee(transaction)
+		feeAccountID = fee.ToAccountID
+		return postFee(tx, fee)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, accountID := range []uint{transaction.FromAccountID, transaction.ToAccountID, feeAccountID} {
+		if accountID == 0 {
+			continue
+		}
+		if err := r.cache.Invalidate(ctx, accountID); err != nil {
+			lib.Log.Warn("Failed to invalidate account cache", zap.Uint("account_id", accountID), zap.Error(err))
+		}
+	}
+	return from, to, nil
+}
+
+// lockAccounts блокирует счета в порядке возрастания id, чтобы встречные переводы не
+// взаимоблокировались; нулевые id пропускаются
+func lockAccounts(tx *gorm.DB, ids ...uint) (map[uint]*entities.Account, error) {
+	sorted := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !slices.Contains(sorted, id) {
+			sorted = append(sorted, id)
+		}
+	}
+	slices.Sort(sorted)
+
+	accounts := make(map[uint]*entities.Account, len(sorted))
+	for _, id := range sorted {
+		account, err := lockAccount(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = account
+	}
+	return accounts, nil
+}
+
+// postFee создаёт транзакцию комиссии и зачисляет её на счёт банка. Счёт комиссий не
+// блокируется: зачисления на него идут одновременно со многих счетов.
+func postFee(tx *gorm.DB, fee *entities.Transaction) error {
+	if err := tx.Create(fee).Error; err != nil {
+		return err
+	}
+	result := tx.Model(&entities.Account{}).
+		Where("id = ? AND type = ?", fee.ToAccountID, entities.AccountTypeBank).
+		Update("balance", gorm.Expr("balance + ?", fee.Amount))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("fee account %d: %w", fee.ToAccountID, gorm.ErrRecordNotFound)
+	}
 	return nil
 }
 
-func (r accountsRepository) Update(ctx context.Context, account *entities.Account) error {
-	return r.db.WithContext(ctx).Save(account).Error
+// Invalidate сбрасывает кеш счёта, изменённого в обход Update, например SQL-выражением
+func (r accountsRepository) Invalidate(ctx context.Context, accountID uint) error {
+	return r.cache.Invalidate(ctx, accountID)
+}
+
+// FindInactive возвращает активные счета, открытые до since, по которым с этого момента
+// не было ни одной клиентской операции
+func (r accountsRepository) FindInactive(ctx context.Context, since time.Time) ([]*entities.Account, error) {
+	var accounts []*entities.Account
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND created_at < ?", entities.AccountActive, since).
+		Where("NOT EXISTS (?)", r.db.Model(&entities.Transaction{}).
+			Select("1").
+			Where("(transactions.from_account_id = accounts.id OR transactions.to_account_id = accounts.id) AND transactions.created_at >= ? AND transactions.type NOT IN ?", since, bankTransactionTypes)).
+		Order("id").
+		Find(&accounts).Error
+	return accounts, err
 }
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type APIKeysRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	FindAll(ctx context.Context) ([]*entities.APIKey, error)
+	FindByID(ctx context.Context, id uint) (*entities.APIKey, error)
+	FindByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error)
+	Revoke(ctx context.Context, id uint) error
+	RevokeAllByUser(ctx context.Context, userID uint) error
+	FindByUser(ctx context.Context, userID uint) ([]*entities.APIKey, error)
+	TouchLastUsed(ctx context.Context, id uint, usedAt time.Time) error
+}
+
+type apiKeysRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeysRepository(db *gorm.DB) APIKeysRepository {
+	return &apiKeysRepository{db: db}
+}
+
+func (r *apiKeysRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+func (r *apiKeysRepository) FindAll(ctx context.Context) ([]*entities.APIKey, error) {
+	var keys []*entities.APIKey
+	if err := r.db.WithContext(ctx).Order("created_at desc").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *apiKeysRepository) FindByID(ctx context.Context, id uint) (*entities.APIKey, error) {
+	var key entities.APIKey
+	if err := r.db.WithContext(ctx).First(&key, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeysRepository) FindByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	var key entities.APIKey
+	if err := r.db.WithContext(ctx).First(&key, "prefix = ?", prefix).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeysRepository) Revoke(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).
+		Model(&entities.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *apiKeysRepository) RevokeAllByUser(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).
+		Model(&entities.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *apiKeysRepository) FindByUser(ctx context.Context, userID uint) ([]*entities.APIKey, error) {
+	var keys []*entities.APIKey
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *apiKeysRepository) TouchLastUsed(ctx context.Context, id uint, usedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&entities.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	Append(ctx context.Context, entry *entities.AuditEntry) error
+	Search(ctx context.Context, filter *entities.AuditFilter) ([]*entities.AuditEntry, int64, error)
+	Walk(ctx context.Context, batchSize int, fn func(entries []*entities.AuditEntry) error) error
+}
+
+// auditChainLock — ключ advisory-блокировки Postgres, сериализующей добавление записей в цепочку
+const auditChainLock = 7_305_001
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// Append связывает запись с последней записью журнала и сохраняет её.
+// Блокировка гарантирует, что две записи не получат один и тот же PrevHash.
+func (r *auditRepository) Append(ctx context.Context, entry *entities.AuditEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLock).Error; err != nil {
+			return err
+		}
+
+		var last entities.AuditEntry
+		err := tx.Order("id desc").Select("hash").First(&last).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		entry.PrevHash = last.Hash
+		entry.Hash = entry.ComputeHash()
+
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *auditRepository) Search(ctx context.Context, filter *entities.AuditFilter) ([]*entities.AuditEntry, int64, error) {
+	var entries []*entities.AuditEntry
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entities.AuditEntry{})
+
+	if filter.ActorID != nil {
+		db = db.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != nil {
+		db = db.Where("action = ?", *filter.Action)
+	}
+	if filter.ResourceType != "" {
+		db = db.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		db = db.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.RequestID != "" {
+		db = db.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.Result != nil {
+		db = db.Where("result = ?", *filter.Result)
+	}
+	if filter.FromDate != nil {
+		db = db.Where("created_at >= ?", *filter.FromDate)
+	}
+	if filter.ToDate != nil {
+		db = db.Where("created_at <= ?", *filter.ToDate)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("id desc").
+		Limit(filter.Limit).
+		Offset((filter.Page - 1) * filter.Limit).
+		Find(&entries).Error
+
+	return entries, total, err
+}
+
+// Walk обходит весь журнал по возрастанию id пачками по batchSize записей
+func (r *auditRepository) Walk(ctx context.Context, batchSize int, fn func(entries []*entities.AuditEntry) error) error {
+	var batch []*entities.AuditEntry
+
+	return r.db.WithContext(ctx).
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
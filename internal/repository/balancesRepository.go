@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type BalancesRepository interface {
+	Snapshot(ctx context.Context, businessDate, closedAt time.Time) (int64, error)
+	FindLatest(ctx context.Context, accountID uint, at time.Time) (*entities.BalanceSnapshot, error)
+	NetChange(ctx context.Context, accountID uint, from, through time.Time) (float64, error)
+}
+
+type balancesRepository struct {
+	db *gorm.DB
+}
+
+func NewBalancesRepository(db *gorm.DB) BalancesRepository {
+	return &balancesRepository{db: db}
+}
+
+// balanceAtSQL — остаток счёта a на момент, переданный параметром: текущий остаток за
+// вычетом транзакций, созданных начиная с этого момента
+const balanceAtSQL = `a.balance - COALESCE((
+	SELECT SUM(CASE WHEN t.to_account_id = a.id THEN t.amount ELSE 0 END) -
+		SUM(CASE WHEN t.from_account_id = a.id THEN t.amount ELSE 0 END)
+	FROM transactions t
+	WHERE (t.from_account_id = a.id OR t.to_account_id = a.id) AND t.created_at >= ?
+), 0)`
+
+// openAtSQL — счёт a открыт к моменту, переданному параметром, и не закрыт до него
+const openAtSQL = `a.created_at < ? AND (a.status <> ? OR a.status_changed_at >= ?)`
+
+// Snapshot сохраняет остатки на момент closedAt всех счетов, открытых к этому моменту и не
+// закрытых до него, и возвращает их число. Остаток считается как текущий за вычетом
+// транзакций начиная с closedAt, поэтому повторный запуск за тот же день, в том числе
+// позже, даёт тот же результат и перезаписывает снимок.
+func (r *balancesRepository) Snapshot(ctx context.Context, businessDate, closedAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO balance_snapshots (account_id, business_date, closed_at, balance, currency, created_at)
+		SELECT a.id, ?, ?, `+balanceAtSQL+`, a.currency, NOW()
+		FROM accounts a
+		WHERE `+openAtSQL+`
+		ON CONFLICT (account_id, business_date)
+		DO UPDATE SET balance = EXCLUDED.balance, closed_at = EXCLUDED.closed_at`,
+		businessDate, closedAt, closedAt, closedAt, entities.AccountClosed, closedAt)
+	return result.RowsAffected, result.Error
+}
+
+// FindLatest возвращает последний снимок счёта, закрытый не позже at, или nil
+func (r *balancesRepository) FindLatest(ctx context.Context, accountID uint, at time.Time) (*entities.BalanceSnapshot, error) {
+	var snapshots []*entities.BalanceSnapshot
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND closed_at <= ?", accountID, at).
+		Order("closed_at desc").
+		Limit(1).
+		Find(&snapshots).Error
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return snapshots[0], nil
+}
+
+// NetChange возвращает изменение остатка счёта по транзакциям, созданным начиная с from
+// и не позже through
+func (r *balancesRepository) NetChange(ctx context.Context, accountID uint, from, through time.Time) (float64, error) {
+	var change float64
+	err := r.db.WithContext(ctx).Model(&entities.Transaction{}).
+		Select(`COALESCE(SUM(CASE WHEN to_account_id = ? THEN amount ELSE 0 END) -
+			SUM(CASE WHEN from_account_id = ? THEN amount ELSE 0 END), 0)`, accountID, accountID).
+		Where("(from_account_id = ? OR to_account_id = ?) AND created_at >= ? AND created_at <= ?", accountID, accountID, from, through).
+		Scan(&change).Error
+	return change, err
+}
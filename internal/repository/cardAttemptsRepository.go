@@ -0,0 +1,42 @@
+package repository
+
+import (
+	redis "bank-app-backend/internal/lib/redis"
+	"context"
+	"fmt"
+	"time"
+)
+
+// CardAttemptsRepository хранит в Redis счётчики неверных CVV по картам и блокировки
+// авторизаций, наступающие после слишком многих ошибок
+type CardAttemptsRepository interface {
+	IncrementCVVFailures(ctx context.Context, cardID uint, window time.Duration) (int64, error)
+	ResetCVVFailures(ctx context.Context, cardID uint) error
+	LockCVV(ctx context.Context, cardID uint, duration time.Duration) error
+	CVVLocked(ctx context.Context, cardID uint) (bool, error)
+}
+
+type cardAttemptsRepository struct {
+	redis *redis.Client
+}
+
+func NewCardAttemptsRepository(redisClient *redis.Client) CardAttemptsRepository {
+	return &cardAttemptsRepository{redis: redisClient}
+}
+
+// IncrementCVVFailures увеличивает счётчик неверных CVV; окно window отсчитывается от первой ошибки
+func (r *cardAttemptsRepository) IncrementCVVFailures(ctx context.Context, cardID uint, window time.Duration) (int64, error) {
+	return r.redis.IncrWithTTL(ctx, fmt.Sprintf("card_cvv_attempts:%d", cardID), window)
+}
+
+func (r *cardAttemptsRepository) ResetCVVFailures(ctx context.Context, cardID uint) error {
+	return r.redis.Del(ctx, fmt.Sprintf("card_cvv_attempts:%d", cardID))
+}
+
+func (r *cardAttemptsRepository) LockCVV(ctx context.Context, cardID uint, duration time.Duration) error {
+	return r.redis.Set(ctx, fmt.Sprintf("card_cvv_lock:%d", cardID), time.Now().Unix(), duration)
+}
+
+func (r *cardAttemptsRepository) CVVLocked(ctx context.Context, cardID uint) (bool, error) {
+	return r.redis.Exists(ctx, fmt.Sprintf("card_cvv_lock:%d", cardID))
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type CardsRepository interface {
+	Create(ctx context.Context, card *entities.Card) error
+	FindByID(ctx context.Context, id uint) (*entities.Card, error)
+	FindByPANHash(ctx context.Context, panHash string) (*entities.Card, error)
+	FindByAccount(ctx context.Context, accountID uint) ([]*entities.Card, error)
+	FindByUser(ctx context.Context, userID uint) ([]*entities.Card, error)
+	Update(ctx context.Context, card *entities.Card) error
+}
+
+type cardsRepository struct {
+	db *gorm.DB
+}
+
+func NewCardsRepository(db *gorm.DB) CardsRepository {
+	return &cardsRepository{db: db}
+}
+
+func (r *cardsRepository) Create(ctx context.Context, card *entities.Card) error {
+	return r.db.WithContext(ctx).Create(card).Error
+}
+
+func (r *cardsRepository) FindByID(ctx context.Context, id uint) (*entities.Card, error) {
+	var card entities.Card
+	if err := r.db.WithContext(ctx).First(&card, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *cardsRepository) FindByPANHash(ctx context.Context, panHash string) (*entities.Card, error) {
+	var card entities.Card
+	if err := r.db.WithContext(ctx).First(&card, "pan_hash = ?", panHash).Error; err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *cardsRepository) FindByAccount(ctx context.Context, accountID uint) ([]*entities.Card, error) {
+	var cards []*entities.Card
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("created_at").Find(&cards).Error
+	return cards, err
+}
+
+// FindByUser возвращает карты, держателем которых является пользователь, на любых счетах
+func (r *cardsRepository) FindByUser(ctx context.Context, userID uint) ([]*entities.Card, error) {
+	var cards []*entities.Card
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Find(&cards).Error
+	return cards, err
+}
+
+func (r *cardsRepository) Update(ctx context.Context, card *entities.Card) error {
+	return r.db.WithContext(ctx).Save(card).Error
+}
+
+// cardSpentSince возвращает сумму оплат картой начиная с since: активные холды учитываются
+// целиком, списанные — на списанную сумму, снятые и истёкшие не учитываются
+func cardSpentSince(db *gorm.DB, cardID uint, since time.Time) (float64, error) {
+	var spent float64
+	err := db.Model(&entities.Hold{}).
+		Select("COALESCE(SUM(CASE WHEN status = ? THEN amount WHEN status = ? THEN captured_amount ELSE 0 END), 0)",
+			entities.HoldActive, entities.HoldCaptured).
+		Where("card_id = ? AND created_at >= ?", cardID, since).
+		Scan(&spent).Error
+	return spent, err
+}
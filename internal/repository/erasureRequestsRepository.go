@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+)
+
+type ErasureRequestsRepository interface {
+	Create(ctx context.Context, req *entities.ErasureRequest) error
+	FindByID(ctx context.Context, id uint) (*entities.ErasureRequest, error)
+	FindOpenByUser(ctx context.Context, userID uint) (*entities.ErasureRequest, error)
+	FindAll(ctx context.Context, status entities.ErasureStatus) ([]*entities.ErasureRequest, error)
+	Update(ctx context.Context, req *entities.ErasureRequest) error
+}
+
+type erasureRequestsRepository struct {
+	db *gorm.DB
+}
+
+func NewErasureRequestsRepository(db *gorm.DB) ErasureRequestsRepository {
+	return &erasureRequestsRepository{db: db}
+}
+
+func (r *erasureRequestsRepository) Create(ctx context.Context, req *entities.ErasureRequest) error {
+	return r.db.WithContext(ctx).Create(req).Error
+}
+
+func (r *erasureRequestsRepository) FindByID(ctx context.Context, id uint) (*entities.ErasureRequest, error) {
+	var req entities.ErasureRequest
+	if err := r.db.WithContext(ctx).First(&req, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *erasureRequestsRepository) FindOpenByUser(ctx context.Context, userID uint) (*entities.ErasureRequest, error) {
+	var req entities.ErasureRequest
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, entities.ErasureStatusRequested).
+		First(&req).Error; err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *erasureRequestsRepository) FindAll(ctx context.Context, status entities.ErasureStatus) ([]*entities.ErasureRequest, error) {
+	var reqs []*entities.ErasureRequest
+
+	db := r.db.WithContext(ctx).Order("created_at asc")
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	if err := db.Find(&reqs).Error; err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+func (r *erasureRequestsRepository) Update(ctx context.Context, req *entities.ErasureRequest) error {
+	return r.db.WithContext(ctx).Save(req).Error
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeesRepository interface {
+	FindRules(ctx context.Context) ([]*entities.FeeRule, error)
+	SaveRule(ctx context.Context, rule *entities.FeeRule) error
+	DeleteRule(ctx context.Context, id uint) (*entities.FeeRule, error)
+}
+
+type feesRepository struct {
+	db *gorm.DB
+}
+
+func NewFeesRepository(db *gorm.DB) FeesRepository {
+	return &feesRepository{db: db}
+}
+
+func (r *feesRepository) FindRules(ctx context.Context) ([]*entities.FeeRule, error) {
+	var rules []*entities.FeeRule
+	if err := r.db.WithContext(ctx).Order("transfer_type, product, currency").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// SaveRule создаёт правило или заменяет существующее для того же типа операции, продукта и валюты
+func (r *feesRepository) SaveRule(ctx context.Context, rule *entities.FeeRule) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "transfer_type"}, {Name: "product"}, {Name: "currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"kind", "amount", "percent", "min", "max", "tiers"}),
+	}).Create(rule).Error
+}
+
+func (r *feesRepository) DeleteRule(ctx context.Context, id uint) (*entities.FeeRule, error) {
+	var rule entities.FeeRule
+	if err := r.db.WithContext(ctx).First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.WithContext(ctx).Delete(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
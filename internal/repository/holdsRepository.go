@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type HoldsRepository interface {
+	Create(ctx context.Context, hold *entities.Hold, cardDayStart time.Time, check func(account *entities.Account, cardSpent float64) error) error
+	FindByID(ctx context.Context, id uint) (*entities.Hold, error)
+	FindByAccount(ctx context.Context, accountID uint, status entities.HoldStatus) ([]*entities.Hold, error)
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*entities.Hold, error)
+	Capture(ctx context.Context, id uint, amount float64, fee func(parent *entities.Transaction) *entities.Transaction, check func(hold *entities.Hold, account *entities.Account) error) (*entities.Hold, *entities.Transaction, error)
+	Release(ctx context.Context, id uint, status entities.HoldStatus, check func(hold *entities.Hold) error) (*entities.Hold, error)
+}
+
+type holdsRepository struct {
+	db *gorm.DB
+}
+
+func NewHoldsRepository(db *gorm.DB) HoldsRepository {
+	return &holdsRepository{db: db}
+}
+
+// Create под блокировкой счёта вызывает check и, если он не вернул ошибку, создаёт холд
+// и увеличивает сумму, удерживаемую на счёте. Для холда по карте check получает сумму
+// оплат картой с cardDayStart: все холды карты ставятся на её счёт, поэтому блокировка
+// счёта не даёт параллельным авторизациям вместе превысить дневной лимит.
+func (r *holdsRepository) Create(ctx context.Context, hold *entities.Hold, cardDayStart time.Time, check func(account *entities.Account, cardSpent float64) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		account, err := lockAccount(tx, hold.AccountID)
+		if err != nil {
+			return err
+		}
+
+		var cardSpent float64
+		if hold.CardID != nil {
+			if cardSpent, err = cardSpentSince(tx, *hold.CardID, cardDayStart); err != nil {
+				return err
+			}
+		}
+		if err := check(account, cardSpent); err != nil {
+			return err
+		}
+
+		if err := tx.Create(hold).Error; err != nil {
+			return err
+		}
+		return tx.Model(account).Update("held", gorm.Expr("held + ?", hold.Amount)).Error
+	})
+}
+
+func (r *holdsRepository) FindByID(ctx context.Context, id uint) (*entities.Hold, error) {
+	var hold entities.Hold
+	if err := r.db.WithContext(ctx).First(&hold, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// FindByAccount возвращает холды счёта, новые первыми; пустой status — в любом статусе
+func (r *holdsRepository) FindByAccount(ctx context.Context, accountID uint, status entities.HoldStatus) ([]*entities.Hold, error) {
+	query := r.db.WithContext(ctx).Where("account_id = ?", accountID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var holds []*entities.Hold
+	err := query.Order("created_at desc").Find(&holds).Error
+	return holds, err
+}
+
+// FindExpired возвращает до limit активных холдов, срок которых истёк к моменту now
+func (r *holdsRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entities.Hold, error) {
+	var holds []*entities.Hold
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", entities.HoldActive, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&holds).Error
+	return holds, err
+}
+
+// Capture в одной транзакции списывает amount со счёта, снимает весь холд и создаёт
+// транзакцию списания, а если fee не nil — и комиссию, как AccountsRepository.Post.
+// check вызывается под блокировкой холда и счёта. Возвращает транзакцию комиссии.
+func (r *holdsRepository) Capture(ctx context.Context, id uint, amount float64, fee func(parent *entities.Transaction) *entities.Transaction, check func(hold *entities.Hold, account *entities.Account) error) (*entities.Hold, *entities.Transaction, error) {
+	var hold entities.Hold
+	var feeTransaction *entities.Transaction
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&hold, "id = ?", id).Error; err != nil {
+			return err
+		}
+		account, err := lockAccount(tx, hold.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := check(&hold, account); err != nil {
+			return err
+		}
+
+		transaction := &entities.Transaction{
+			FromAccountID: hold.AccountID,
+			UserID:        hold.UserID,
+			Amount:        amount,
+			Description:   hold.Description,
+			Type:          entities.Capture,
+			CreatedAt:     time.Now(),
+		}
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+
+		debit := amount
+		if fee != nil {
+			feeTransaction = fee(transaction)
+			if err := postFee(tx, feeTransaction); err != nil {
+				return err
+			}
+			debit += feeTransaction.Amount
+		}
+
+		if err := tx.Model(account).Updates(map[string]interface{}{
+			"balance": gorm.Expr("balance - ?", debit),
+			"held":    gorm.Expr("held - ?", hold.Amount),
+		}).Error; err != nil {
+			return err
+		}
+
+		hold.Status = entities.HoldCaptured
+		hold.CapturedAmount = amount
+		hold.TransactionID = &transaction.ID
+		return tx.Save(&hold).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &hold, feeTransaction, nil
+}
+
+// Release снимает холд, переводя его в status, и возвращает сумму в доступный остаток.
+// check вызывается под блокировкой холда.
+func (r *holdsRepository) Release(ctx context.Context, id uint, status entities.HoldStatus, check func(hold *entities.Hold) error) (*entities.Hold, error) {
+	var hold entities.Hold
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&hold, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := check(&hold); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entities.Account{}).
+			Where("id = ?", hold.AccountID).
+			Update("held", gorm.Expr("held - ?", hold.Amount)).Error; err != nil {
+			return err
+		}
+
+		hold.Status = status
+		return tx.Save(&hold).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func lockAccount(tx *gorm.DB, accountID uint) (*entities.Account, error) {
+	var account entities.Account
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, "id = ?", accountID).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type InterestRepository interface {
+	FindAccounts(ctx context.Context, types []string, closedAt time.Time) ([]*entities.Account, error)
+	FindOverdrawn(ctx context.Context, closedAt time.Time) ([]*entities.Account, error)
+	FindWithPending(ctx context.Context, through time.Time) ([]uint, error)
+	Accrue(ctx context.Context, accrual *entities.InterestAccrual) (bool, error)
+	Capitalize(ctx context.Context, accountID uint, through time.Time, description string) (*entities.Transaction, error)
+	Pending(ctx context.Context, accountID uint) ([]*entities.InterestAccrual, error)
+}
+
+type interestRepository struct {
+	db *gorm.DB
+}
+
+func NewInterestRepository(db *gorm.DB) InterestRepository {
+	return &interestRepository{db: db}
+}
+
+// FindAccounts возвращает счета указанных типов, открытые на момент closedAt, с остатком
+// на этот момент в Balance. Так проценты за прошедший день начисляются на остаток конца
+// дня, даже если задача запущена позже.
+func (r *interestRepository) FindAccounts(ctx context.Context, types []string, closedAt time.Time) ([]*entities.Account, error) {
+	var accounts []*entities.Account
+	if len(types) == 0 {
+		return accounts, nil
+	}
+
+	err := r.balancesAt(ctx, closedAt).
+		Where("type IN ?", types).
+		Order("id").
+		Find(&accounts).Error
+	return accounts, err
+}
+
+// FindOverdrawn возвращает счета, остаток которых на момент closedAt был отрицательным,
+// с этим остатком в Balance
+func (r *interestRepository) FindOverdrawn(ctx context.Context, closedAt time.Time) ([]*entities.Account, error) {
+	var accounts []*entities.Account
+	err := r.balancesAt(ctx, closedAt).
+		Where("balance < 0").
+		Order("id").
+		Find(&accounts).Error
+	return accounts, err
+}
+
+// balancesAt строит выборку счетов, открытых на момент closedAt, с остатком на этот момент
+func (r *interestRepository) balancesAt(ctx context.Context, closedAt time.Time) *gorm.DB {
+	balances := r.db.Table("accounts a").
+		Select("a.id, a.user_id, a.type, a.currency, a.status, "+balanceAtSQL+" AS balance", closedAt).
+		Where(openAtSQL, closedAt, entities.AccountClosed, closedAt)
+	return r.db.WithContext(ctx).Table("(?) AS a", balances)
+}
+
+// FindWithPending возвращает счета с невыплаченными начислениями по дату through включительно
+func (r *interestRepository) FindWithPending(ctx context.Context, through time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Model(&entities.InterestAccrual{}).
+		Where("capitalized_at IS NULL AND business_date <= ?", through).
+		Distinct("account_id").
+		Order("account_id").
+		Pluck("account_id", &ids).Error
+	return ids, err
+}
+
+// Accrue сохраняет начисление за день. Если начисление за этот день уже есть,
+// ничего не меняется и возвращается false — так повторный запуск задачи безопасен.
+func (r *interestRepository) Accrue(ctx context.Context, accrual *entities.InterestAccrual) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(accrual)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Capitalize в одной транзакции проводит по счёту невыплаченные начисления по дату through
+// включительно и отмечает их выплаченными. Положительная сумма зачисляется на счёт,
+// отрицательная (проценты за овердрафт) списывается с него. Сумма округляется до разрядов валюты счёта;
+// если она нулевая или счёт закрыт, начисления остаются непроведёнными, а результат — nil.
+func (r *interestRepository) Capitalize(ctx context.Context, accountID uint, through time.Time, description string) (*entities.Transaction, error) {
+	var result *entities.Transaction
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var accruals []*entities.InterestAccrual
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("account_id = ? AND capitalized_at IS NULL AND business_date <= ?", accountID, through).
+			Find(&accruals).Error; err != nil {
+			return err
+		}
+
+		var total float64
+		ids := make([]uint, 0, len(accruals))
+		for _, accrual := range accruals {
+			total += accrual.Amount
+			ids = append(ids, accrual.ID)
+		}
+
+		var account entities.Account
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, "id = ?", accountID).Error; err != nil {
+			return err
+		}
+		if account.Status == entities.AccountClosed {
+			return nil
+		}
+
+		amount := entities.RoundAmount(total, entities.MinorUnitsOf(account.Currency))
+		if amount == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		result = &entities.Transaction{
+			ToAccountID: accountID,
+			UserID:      account.UserID,
+			Amount:      amount,
+			Description: description,
+			Type:        entities.Interest,
+			CreatedAt:   now,
+		}
+		if amount < 0 {
+			result.FromAccountID, result.ToAccountID = accountID, 0
+			result.Amount = -amount
+			result.Type = entities.OverdraftInterest
+		}
+		if err := tx.Create(result).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&account).Update("balance", gorm.Expr("balance + ?", amount)).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.InterestAccrual{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{"transaction_id": result.ID, "capitalized_at": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Pending возвращает невыплаченные начисления по счёту
+func (r *interestRepository) Pending(ctx context.Context, accountID uint) ([]*entities.InterestAccrual, error) {
+	var accruals []*entities.InterestAccrual
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND capitalized_at IS NULL", accountID).
+		Order("business_date").
+		Find(&accruals).Error
+	return accruals, err
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type JobRunsRepository interface {
+	Last(ctx context.Context, job string) (time.Time, error)
+	Save(ctx context.Context, job string, businessDate time.Time) error
+}
+
+type jobRunsRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRunsRepository(db *gorm.DB) JobRunsRepository {
+	return &jobRunsRepository{db: db}
+}
+
+// Last возвращает последний обработанный задачей день или нулевое время, если задача
+// ещё не выполнялась
+func (r *jobRunsRepository) Last(ctx context.Context, job string) (time.Time, error) {
+	var runs []*entities.JobRun
+	if err := r.db.WithContext(ctx).Where("job = ?", job).Limit(1).Find(&runs).Error; err != nil {
+		return time.Time{}, err
+	}
+	if len(runs) == 0 {
+		return time.Time{}, nil
+	}
+	date := runs[0].BusinessDate
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// Save отмечает день businessDate обработанным. Дата только растёт: инстанс, который
+// отстал, не откатит её назад.
+func (r *jobRunsRepository) Save(ctx context.Context, job string, businessDate time.Time) error {
+	return r.db.WithContext(ctx).Exec(`
+		INSERT INTO job_runs (job, business_date, updated_at) VALUES (?, ?, NOW())
+		ON CONFLICT (job) DO UPDATE SET business_date = EXCLUDED.business_date, updated_at = EXCLUDED.updated_at
+		WHERE job_runs.business_date < EXCLUDED.business_date`,
+		job, businessDate).Error
+}
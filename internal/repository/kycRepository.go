@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+)
+
+type KYCRepository interface {
+	FindByUserID(ctx context.Context, userID uint) (*entities.KYCProfile, error)
+	FindByStatus(ctx context.Context, status entities.KYCStatus) ([]*entities.KYCProfile, error)
+	Save(ctx context.Context, profile *entities.KYCProfile) error
+}
+
+type kycRepository struct {
+	db *gorm.DB
+}
+
+func NewKYCRepository(db *gorm.DB) KYCRepository {
+	return &kycRepository{db: db}
+}
+
+func (r *kycRepository) FindByUserID(ctx context.Context, userID uint) (*entities.KYCProfile, error) {
+	var profile entities.KYCProfile
+	if err := r.db.WithContext(ctx).First(&profile, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *kycRepository) FindByStatus(ctx context.Context, status entities.KYCStatus) ([]*entities.KYCProfile, error) {
+	var profiles []*entities.KYCProfile
+
+	db := r.db.WithContext(ctx).Order("submitted_at asc")
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	if err := db.Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (r *kycRepository) Save(ctx context.Context, profile *entities.KYCProfile) error {
+	return r.db.WithContext(ctx).Save(profile).Error
+}
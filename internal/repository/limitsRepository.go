@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type LimitsRepository interface {
+	FindRules(ctx context.Context) ([]*entities.LimitRule, error)
+	FindRulesFor(ctx context.Context, tier entities.KYCTier, accountType string, userID uint) ([]*entities.LimitRule, error)
+	SaveRule(ctx context.Context, rule *entities.LimitRule) error
+	DeleteRule(ctx context.Context, id uint) (*entities.LimitRule, error)
+	Usage(ctx context.Context, userID uint, period entities.LimitPeriod, start time.Time) (*entities.LimitUsage, error)
+	Reserve(ctx context.Context, charge *LimitCharge) error
+	Release(ctx context.Context, userID uint, dayStart, monthStart time.Time, amount float64, count int) error
+}
+
+// LimitCharge — учёт операции в использовании лимитов пользователя за день и месяц.
+// Check получает счётчики до операции и отклоняет её ошибкой.
+type LimitCharge struct {
+	UserID     uint
+	DayStart   time.Time
+	MonthStart time.Time
+	Amount     float64
+	Check      func(daily, monthly *entities.LimitUsage) error
+}
+
+// limitsUsageLock — пространство ключей advisory-блокировки, сериализующей списания одного пользователя
+const limitsUsageLock = 7_305_002
+
+type limitsRepository struct {
+	db *gorm.DB
+}
+
+func NewLimitsRepository(db *gorm.DB) LimitsRepository {
+	return &limitsRepository{db: db}
+}
+
+func (r *limitsRepository) FindRules(ctx context.Context) ([]*entities.LimitRule, error) {
+	var rules []*entities.LimitRule
+	if err := r.db.WithContext(ctx).Order("scope, value").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// FindRulesFor возвращает правила, применимые к пользователю, уровню KYC и типу счёта
+func (r *limitsRepository) FindRulesFor(ctx context.Context, tier entities.KYCTier, accountType string, userID uint) ([]*entities.LimitRule, error) {
+	var rules []*entities.LimitRule
+	err := r.db.WithContext(ctx).
+		Where("scope = ? AND value = ?", entities.LimitScopeTier, string(tier)).
+		Or("scope = ? AND value = ?", entities.LimitScopeAccountType, accountType).
+		Or("scope = ? AND value = ?", entities.LimitScopeUser, fmt.Sprint(userID)).
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// SaveRule создаёт правило или заменяет существующее с той же областью и значением
+func (r *limitsRepository) SaveRule(ctx context.Context, rule *entities.LimitRule) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "scope"}, {Name: "value"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"max_per_transaction", "daily_amount", "daily_count", "monthly_amount", "monthly_count", "updated_at",
+		}),
+	}).Create(rule).Error
+}
+
+func (r *limitsRepository) DeleteRule(ctx context.Context, id uint) (*entities.LimitRule, error) {
+	var rule entities.LimitRule
+	if err := r.db.WithContext(ctx).First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.WithContext(ctx).Delete(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Usage возвращает использование лимитов за период; если операций не было, счётчики нулевые
+func (r *limitsRepository) Usage(ctx context.Context, userID uint, period entities.LimitPeriod, start time.Time) (*entities.LimitUsage, error) {
+	return usage(r.db.WithContext(ctx), userID, period, start)
+}
+
+// Reserve учитывает операцию в лимитах отдельной транзакцией, до её выполнения
+func (r *limitsRepository) Reserve(ctx context.Context, charge *LimitCharge) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return chargeLimits(tx, charge)
+	})
+}
+
+// Release возвращает в лимит сумму amount и count операций, которые не были выполнены
+func (r *limitsRepository) Release(ctx context.Context, userID uint, dayStart, monthStart time.Time, amount float64, count int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for period, start := range map[entities.LimitPeriod]time.Time{
+			entities.LimitPeriodDay:   dayStart,
+			entities.LimitPeriodMonth: monthStart,
+		} {
+			err := tx.Model(&entities.LimitUsage{}).
+				Where("user_id = ? AND period = ? AND period_start = ? AND count >= ?", userID, period, start, count).
+				Updates(map[string]interface{}{
+					"amount": gorm.Expr("amount - ?", amount),
+					"count":  gorm.Expr("count - ?", count),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// chargeLimits под блокировкой пользователя читает счётчики дня и месяца, вызывает check
+// и, если он не вернул ошибку, увеличивает их на сумму операции. Блокировка не даёт
+// параллельным операциям одного пользователя вместе превысить лимит.
+func chargeLimits(tx *gorm.DB, charge *LimitCharge) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", limitsUsageLock, int32(charge.UserID)).Error; err != nil {
+		return err
+	}
+
+	daily, err := usage(tx, charge.UserID, entities.LimitPeriodDay, charge.DayStart)
+	if err != nil {
+		return err
+	}
+	monthly, err := usage(tx, charge.UserID, entities.LimitPeriodMonth, charge.MonthStart)
+	if err != nil {
+		return err
+	}
+
+	if err := charge.Check(daily, monthly); err != nil {
+		return err
+	}
+
+	for _, u := range []*entities.LimitUsage{daily, monthly} {
+		u.Amount += charge.Amount
+		u.Count++
+		if err := tx.Save(u).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func usage(db *gorm.DB, userID uint, period entities.LimitPeriod, start time.Time) (*entities.LimitUsage, error) {
+	var u entities.LimitUsage
+	err := db.First(&u, "user_id = ? AND period = ? AND period_start = ?", userID, period, start).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entities.LimitUsage{UserID: userID, Period: period, PeriodStart: start}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
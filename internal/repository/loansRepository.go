@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type LoansRepository interface {
+	Create(ctx context.Context, loan *entities.Loan) error
+	FindByID(ctx context.Context, id uint) (*entities.Loan, error)
+	FindByUser(ctx context.Context, userID uint) ([]*entities.Loan, error)
+	FindByAccount(ctx context.Context, accountID uint) ([]*entities.Loan, error)
+	FindByStatus(ctx context.Context, status entities.LoanStatus) ([]*entities.Loan, error)
+	Update(ctx context.Context, loan *entities.Loan) error
+	Disburse(ctx context.Context, id uint, check func(loan *entities.Loan, account *entities.Account) error) (*entities.Loan, error)
+	FindDueInstallments(ctx context.Context, through time.Time) ([]*entities.LoanInstallment, error)
+	Repay(ctx context.Context, installmentID uint, check func(installment *entities.LoanInstallment, account *entities.Account) error) (*entities.LoanInstallment, error)
+	MarkOverdue(ctx context.Context, installmentID uint, lateFee float64) error
+}
+
+type loansRepository struct {
+	db *gorm.DB
+}
+
+func NewLoansRepository(db *gorm.DB) LoansRepository {
+	return &loansRepository{db: db}
+}
+
+func (r *loansRepository) Create(ctx context.Context, loan *entities.Loan) error {
+	return r.db.WithContext(ctx).Create(loan).Error
+}
+
+// FindByID возвращает кредит вместе с графиком платежей
+func (r *loansRepository) FindByID(ctx context.Context, id uint) (*entities.Loan, error) {
+	var loan entities.Loan
+	err := r.db.WithContext(ctx).
+		Preload("Installments", func(db *gorm.DB) *gorm.DB { return db.Order("number") }).
+		First(&loan, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+func (r *loansRepository) FindByUser(ctx context.Context, userID uint) ([]*entities.Loan, error) {
+	var loans []*entities.Loan
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc").Find(&loans).Error
+	return loans, err
+}
+
+func (r *loansRepository) FindByAccount(ctx context.Context, accountID uint) ([]*entities.Loan, error) {
+	var loans []*entities.Loan
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("created_at desc").Find(&loans).Error
+	return loans, err
+}
+
+// FindByStatus возвращает кредиты в статусе status, старые первыми; пустой status — все
+func (r *loansRepository) FindByStatus(ctx context.Context, status entities.LoanStatus) ([]*entities.Loan, error) {
+	query := r.db.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var loans []*entities.Loan
+	err := query.Order("created_at").Find(&loans).Error
+	return loans, err
+}
+
+func (r *loansRepository) Update(ctx context.Context, loan *entities.Loan) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(loan).Error
+}
+
+// Disburse в одной транзакции зачисляет сумму кредита на счёт, создаёт транзакцию выдачи
+// и график платежей и переводит кредит в статус active. check вызывается под блокировкой
+// кредита и счёта и может заполнить поля кредита.
+func (r *loansRepository) Disburse(ctx context.Context, id uint, check func(loan *entities.Loan, account *entities.Account) error) (*entities.Loan, error) {
+	var loan entities.Loan
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&loan, "id = ?", id).Error; err != nil {
+			return err
+		}
+		account, err := lockAccount(tx, loan.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := check(&loan, account); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		transaction := &entities.Transaction{
+			ToAccountID: loan.AccountID,
+			UserID:      loan.UserID,
+			Amount:      loan.Principal,
+			Description: fmt.Sprintf("Выдача кредита №%d", loan.ID),
+			Type:        entities.LoanDisbursement,
+			CreatedAt:   now,
+		}
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(account).Update("balance", gorm.Expr("balance + ?", loan.Principal)).Error; err != nil {
+			return err
+		}
+
+		loan.Installments = entities.BuildLoanSchedule(loan.Principal, loan.InterestRate, loan.TermMonths, loan.ScheduleType, now, entities.MinorUnitsOf(account.Currency))
+		for i := range loan.Installments {
+			loan.Installments[i].LoanID = loan.ID
+		}
+		if err := tx.Create(&loan.Installments).Error; err != nil {
+			return err
+		}
+
+		loan.Status = entities.LoanActive
+		loan.Outstanding = loan.Principal
+		loan.TransactionID = &transaction.ID
+		loan.DisbursedAt = &now
+		return tx.Omit(clause.Associations).Save(&loan).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+// FindDueInstallments возвращает неоплаченные платежи со сроком не позже through,
+// в порядке сроков
+func (r *loansRepository) FindDueInstallments(ctx context.Context, through time.Time) ([]*entities.LoanInstallment, error) {
+	var installments []*entities.LoanInstallment
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND due_date <= ?", []entities.InstallmentStatus{entities.InstallmentDue, entities.InstallmentOverdue}, through).
+		Order("due_date, loan_id, number").
+		Find(&installments).Error
+	return installments, err
+}
+
+// Repay в одной транзакции списывает платёж со счёта кредита, отмечает его оплаченным и
+// уменьшает долг; после последнего платежа кредит переходит в статус repaid. check
+// вызывается под блокировкой платежа и счёта.
+func (r *loansRepository) Repay(ctx context.Context, installmentID uint, check func(installment *entities.LoanInstallment, account *entities.Account) error) (*entities.LoanInstallment, error) {
+	var installment entities.LoanInstallment
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&installment, "id = ?", installmentID).Error; err != nil {
+			return err
+		}
+		var loan entities.Loan
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&loan, "id = ?", installment.LoanID).Error; err != nil {
+			return err
+		}
+		account, err := lockAccount(tx, loan.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := check(&installment, account); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		amount := installment.Amount()
+		transaction := &entities.Transaction{
+			FromAccountID: loan.AccountID,
+			UserID:        loan.UserID,
+			Amount:        amount,
+			Description:   fmt.Sprintf("Платёж №%d по кредиту №%d", installment.Number, loan.ID),
+			Type:          entities.LoanRepayment,
+			CreatedAt:     now,
+		}
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(account).Update("balance", gorm.Expr("balance - ?", amount)).Error; err != nil {
+			return err
+		}
+
+		installment.Status = entities.InstallmentPaid
+		installment.PaidAt = &now
+		installment.TransactionID = &transaction.ID
+		if err := tx.Save(&installment).Error; err != nil {
+			return err
+		}
+
+		var unpaid int64
+		if err := tx.Model(&entities.LoanInstallment{}).
+			Where("loan_id = ? AND status <> ?", loan.ID, entities.InstallmentPaid).
+			Count(&unpaid).Error; err != nil {
+			return err
+		}
+
+		loan.Outstanding = entities.RoundAmount(loan.Outstanding-installment.Principal, entities.MinorUnitsOf(account.Currency))
+		if unpaid == 0 {
+			loan.Status = entities.LoanRepaid
+			loan.Outstanding = 0
+		}
+		return tx.Omit(clause.Associations).Save(&loan).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &installment, nil
+}
+
+// MarkOverdue отмечает платёж просроченным; положительный lateFee начисляется штрафом
+func (r *loansRepository) MarkOverdue(ctx context.Context, installmentID uint, lateFee float64) error {
+	updates := map[string]interface{}{"status": entities.InstallmentOverdue}
+	if lateFee > 0 {
+		updates["late_fee"] = lateFee
+	}
+	return r.db.WithContext(ctx).Model(&entities.LoanInstallment{}).
+		Where("id = ? AND status <> ?", installmentID, entities.InstallmentPaid).
+		Updates(updates).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	redis "bank-app-backend/internal/lib/redis"
+	"context"
+	"fmt"
+	"time"
+)
+
+// LoginAttemptsRepository хранит счётчики неудачных попыток входа,
+// задержки и блокировки в Redis. subject — это "email:<email>" или "ip:<ip>".
+type LoginAttemptsRepository interface {
+	IncrementFailures(ctx context.Context, subject string, window time.Duration) (int64, error)
+	ResetFailures(ctx context.Context, subject string) error
+	SetDelay(ctx context.Context, subject string, delay time.Duration) error
+	GetDelay(ctx context.Context, subject string) (time.Duration, error)
+	Lock(ctx context.Context, subject string, duration time.Duration) error
+	GetLock(ctx context.Context, subject string) (time.Duration, error)
+	Unlock(ctx context.Context, subject string) error
+}
+
+type loginAttemptsRepository struct {
+	redis *redis.Client
+}
+
+func NewLoginAttemptsRepository(redisClient *redis.Client) LoginAttemptsRepository {
+	return &loginAttemptsRepository{redis: redisClient}
+}
+
+// IncrementFailures увеличивает счётчик неудач; окно window отсчитывается от первой неудачи
+func (r *loginAttemptsRepository) IncrementFailures(ctx context.Context, subject string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf("login_attempts:%s", subject)
+
+	return r.redis.IncrWithTTL(ctx, key, window)
+}
+
+func (r *loginAttemptsRepository) ResetFailures(ctx context.Context, subject string) error {
+	if err := r.redis.Del(ctx, fmt.Sprintf("login_attempts:%s", subject)); err != nil {
+		return err
+	}
+	return r.redis.Del(ctx, fmt.Sprintf("login_delay:%s", subject))
+}
+
+func (r *loginAttemptsRepository) SetDelay(ctx context.Context, subject string, delay time.Duration) error {
+	return r.redis.Set(ctx, fmt.Sprintf("login_delay:%s", subject), 1, delay)
+}
+
+// GetDelay возвращает оставшееся время задержки или 0, если задержки нет
+func (r *loginAttemptsRepository) GetDelay(ctx context.Context, subject string) (time.Duration, error) {
+	return r.remaining(ctx, fmt.Sprintf("login_delay:%s", subject))
+}
+
+func (r *loginAttemptsRepository) Lock(ctx context.Context, subject string, duration time.Duration) error {
+	return r.redis.Set(ctx, fmt.Sprintf("login_lock:%s", subject), time.Now().Unix(), duration)
+}
+
+// GetLock возвращает оставшееся время блокировки или 0, если блокировки нет
+func (r *loginAttemptsRepository) GetLock(ctx context.Context, subject string) (time.Duration, error) {
+	return r.remaining(ctx, fmt.Sprintf("login_lock:%s", subject))
+}
+
+func (r *loginAttemptsRepository) Unlock(ctx context.Context, subject string) error {
+	if err := r.redis.Del(ctx, fmt.Sprintf("login_lock:%s", subject)); err != nil {
+		return err
+	}
+	return r.ResetFailures(ctx, subject)
+}
+
+func (r *loginAttemptsRepository) remaining(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.redis.TTL(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	// TTL возвращает отрицательные значения для отсутствующих ключей
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
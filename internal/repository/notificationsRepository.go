@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type NotificationsRepository interface {
+	Create(ctx context.Context, notification *entities.Notification) error
+	FindByUser(ctx context.Context, filter *entities.NotificationFilter) ([]*entities.Notification, int64, error)
+	FindAllByUser(ctx context.Context, userID uint) ([]*entities.Notification, error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+	MarkRead(ctx context.Context, userID, id uint) error
+	MarkAllRead(ctx context.Context, userID uint) error
+	FindPreferences(ctx context.Context, userID uint) ([]*entities.NotificationPreference, error)
+	SavePreferences(ctx context.Context, prefs []*entities.NotificationPreference) error
+}
+
+type notificationsRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationsRepository(db *gorm.DB) NotificationsRepository {
+	return &notificationsRepository{db: db}
+}
+
+func (r *notificationsRepository) Create(ctx context.Context, notification *entities.Notification) error {
+	return r.db.WithContext(ctx).Create(notification).Error
+}
+
+func (r *notificationsRepository) FindByUser(ctx context.Context, filter *entities.NotificationFilter) ([]*entities.Notification, int64, error) {
+	var notifications []*entities.Notification
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entities.Notification{}).Where("user_id = ?", filter.UserID)
+	if filter.UnreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("created_at desc").
+		Limit(filter.Limit).
+		Offset((filter.Page - 1) * filter.Limit).
+		Find(&notifications).Error
+
+	return notifications, total, err
+}
+
+// FindAllByUser возвращает все уведомления пользователя без пагинации, новые первыми
+func (r *notificationsRepository) FindAllByUser(ctx context.Context, userID uint) ([]*entities.Notification, error) {
+	var notifications []*entities.Notification
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc").Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *notificationsRepository) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead отмечает уведомление прочитанным; повторная отметка не меняет время прочтения
+func (r *notificationsRepository) MarkRead(ctx context.Context, userID, id uint) error {
+	var notification entities.Notification
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&notification).Error; err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&entities.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", time.Now()).Error
+}
+
+func (r *notificationsRepository) MarkAllRead(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).
+		Model(&entities.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
+
+func (r *notificationsRepository) FindPreferences(ctx context.Context, userID uint) ([]*entities.NotificationPreference, error) {
+	var prefs []*entities.NotificationPreference
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&prefs).Error; err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// SavePreferences создаёт или обновляет настройки по ключу (user_id, event, channel)
+func (r *notificationsRepository) SavePreferences(ctx context.Context, prefs []*entities.NotificationPreference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(&prefs).Error
+}
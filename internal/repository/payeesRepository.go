@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+)
+
+type PayeesRepository interface {
+	FindByUser(ctx context.Context, userID uint) ([]*entities.Payee, error)
+	FindByID(ctx context.Context, userID, id uint) (*entities.Payee, error)
+	FindByAccount(ctx context.Context, userID, accountID uint) (*entities.Payee, error)
+	Create(ctx context.Context, payee *entities.Payee) error
+	Update(ctx context.Context, payee *entities.Payee) error
+	Delete(ctx context.Context, userID, id uint) error
+}
+
+type payeesRepository struct {
+	db *gorm.DB
+}
+
+func NewPayeesRepository(db *gorm.DB) PayeesRepository {
+	return &payeesRepository{db: db}
+}
+
+func (r *payeesRepository) FindByUser(ctx context.Context, userID uint) ([]*entities.Payee, error) {
+	var payees []*entities.Payee
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("nickname asc").
+		Find(&payees).Error; err != nil {
+		return nil, err
+	}
+	return payees, nil
+}
+
+func (r *payeesRepository) FindByID(ctx context.Context, userID, id uint) (*entities.Payee, error) {
+	var payee entities.Payee
+	if err := r.db.WithContext(ctx).First(&payee, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &payee, nil
+}
+
+func (r *payeesRepository) FindByAccount(ctx context.Context, userID, accountID uint) (*entities.Payee, error) {
+	var payee entities.Payee
+	if err := r.db.WithContext(ctx).First(&payee, "user_id = ? AND account_id = ?", userID, accountID).Error; err != nil {
+		return nil, err
+	}
+	return &payee, nil
+}
+
+func (r *payeesRepository) Create(ctx context.Context, payee *entities.Payee) error {
+	return r.db.WithContext(ctx).Create(payee).Error
+}
+
+func (r *payeesRepository) Update(ctx context.Context, payee *entities.Payee) error {
+	return r.db.WithContext(ctx).Save(payee).Error
+}
+
+func (r *payeesRepository) Delete(ctx context.Context, userID, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&entities.Payee{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type PotsRepository interface {
+	Create(ctx context.Context, pot *entities.Pot) error
+	FindByID(ctx context.Context, id uint) (*entities.Pot, error)
+	FindByAccount(ctx context.Context, accountID uint) ([]*entities.Pot, error)
+	FindRoundUp(ctx context.Context, accountID uint) (*entities.Pot, error)
+	FindWeeklyDue(ctx context.Context, businessDate time.Time) ([]*entities.Pot, error)
+	Update(ctx context.Context, pot *entities.Pot) error
+	Move(ctx context.Context, id uint, amount float64, check func(pot *entities.Pot, account *entities.Account) error) (*entities.Pot, error)
+	Delete(ctx context.Context, id uint) (*entities.Pot, error)
+}
+
+type potsRepository struct {
+	db *gorm.DB
+}
+
+func NewPotsRepository(db *gorm.DB) PotsRepository {
+	return &potsRepository{db: db}
+}
+
+// Create создаёт копилку; если у неё включено округление, оно выключается у остальных
+// копилок счёта
+func (r *potsRepository) Create(ctx context.Context, pot *entities.Pot) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(pot).Error; err != nil {
+			return err
+		}
+		return clearRoundUp(tx, pot)
+	})
+}
+
+func (r *potsRepository) FindByID(ctx context.Context, id uint) (*entities.Pot, error) {
+	var pot entities.Pot
+	if err := r.db.WithContext(ctx).First(&pot, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &pot, nil
+}
+
+func (r *potsRepository) FindByAccount(ctx context.Context, accountID uint) ([]*entities.Pot, error) {
+	var pots []*entities.Pot
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("created_at").Find(&pots).Error
+	return pots, err
+}
+
+// FindRoundUp возвращает копилку счёта с включённым округлением или nil
+func (r *potsRepository) FindRoundUp(ctx context.Context, accountID uint) (*entities.Pot, error) {
+	var pots []*entities.Pot
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND round_up > 0", accountID).
+		Limit(1).
+		Find(&pots).Error
+	if err != nil || len(pots) == 0 {
+		return nil, err
+	}
+	return pots[0], nil
+}
+
+// FindWeeklyDue возвращает копилки с еженедельным пополнением в день недели businessDate,
+// ещё не пополненные за этот день
+func (r *potsRepository) FindWeeklyDue(ctx context.Context, businessDate time.Time) ([]*entities.Pot, error) {
+	var pots []*entities.Pot
+	err := r.db.WithContext(ctx).
+		Where("weekly_amount > 0 AND weekly_day = ?", businessDate.Weekday()).
+		Where("last_weekly_on IS NULL OR last_weekly_on < ?", businessDate).
+		Where("created_at < ?", businessDate.AddDate(0, 0, 1)).
+		Order("id").
+		Find(&pots).Error
+	return pots, err
+}
+
+// Update сохраняет настройки копилки. Баланс меняется только через Move и здесь не
+// перезаписывается.
+func (r *potsRepository) Update(ctx context.Context, pot *entities.Pot) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("balance").Save(pot).Error; err != nil {
+			return err
+		}
+		return clearRoundUp(tx, pot)
+	})
+}
+
+// Move под блокировкой копилки и счёта вызывает check и переводит amount со счёта в
+// копилку (отрицательный amount — обратно). check может изменить поля копилки, они
+// сохраняются вместе с балансом.
+func (r *potsRepository) Move(ctx context.Context, id uint, amount float64, check func(pot *entities.Pot, account *entities.Account) error) (*entities.Pot, error) {
+	var pot entities.Pot
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&pot, "id = ?", id).Error; err != nil {
+			return err
+		}
+		account, err := lockAccount(tx, pot.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := check(&pot, account); err != nil {
+			return err
+		}
+
+		if err := tx.Model(account).Update("pots", gorm.Expr("pots + ?", amount)).Error; err != nil {
+			return err
+		}
+
+		pot.Balance += amount
+		return tx.Save(&pot).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pot, nil
+}
+
+// Delete удаляет копилку и возвращает её баланс в доступный остаток счёта
+func (r *potsRepository) Delete(ctx context.Context, id uint) (*entities.Pot, error) {
+	var pot entities.Pot
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&pot, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entities.Account{}).
+			Where("id = ?", pot.AccountID).
+			Update("pots", gorm.Expr("pots - ?", pot.Balance)).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&pot).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pot, nil
+}
+
+// clearRoundUp выключает округление у других копилок счёта, если оно включено у pot:
+// разница от списания уходит только в одну копилку
+func clearRoundUp(tx *gorm.DB, pot *entities.Pot) error {
+	if pot.RoundUp <= 0 {
+		return nil
+	}
+	return tx.Model(&entities.Pot{}).
+		Where("account_id = ? AND id <> ? AND round_up > 0", pot.AccountID, pot.ID).
+		Update("round_up", 0).Error
+}
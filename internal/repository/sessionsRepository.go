@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	redis "bank-app-backend/internal/lib/redis"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+// SessionsRepository хранит сессии пользователей в Redis:
+// session:<id> — данные сессии, user_sessions:<userID> — множество id сессий пользователя
+type SessionsRepository interface {
+	Save(ctx context.Context, session *entities.Session, ttl time.Duration) error
+	Get(ctx context.Context, sessionID string) (*entities.Session, error)
+	Exists(ctx context.Context, sessionID string) (bool, error)
+	ListByUser(ctx context.Context, userID uint) ([]*entities.Session, error)
+	Delete(ctx context.Context, userID uint, sessionID string) error
+	DeleteAllByUser(ctx context.Context, userID uint) error
+}
+
+type sessionsRepository struct {
+	redis *redis.Client
+}
+
+func NewSessionsRepository(redisClient *redis.Client) SessionsRepository {
+	return &sessionsRepository{redis: redisClient}
+}
+
+func (r *sessionsRepository) Save(ctx context.Context, session *entities.Session, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if err := r.redis.Set(ctx, sessionKey(session.ID), data, ttl); err != nil {
+		lib.Log.Error("Failed to save session", zap.Uint("user_id", session.UserID), zap.Error(err))
+		return err
+	}
+
+	return r.redis.SAdd(ctx, userSessionsKey(session.UserID), session.ID)
+}
+
+func (r *sessionsRepository) Get(ctx context.Context, sessionID string) (*entities.Session, error) {
+	data, err := r.redis.Get(ctx, sessionKey(sessionID))
+	if err != nil {
+		return nil, err
+	}
+
+	var session entities.Session
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *sessionsRepository) Exists(ctx context.Context, sessionID string) (bool, error) {
+	return r.redis.Exists(ctx, sessionKey(sessionID))
+}
+
+// ListByUser возвращает активные сессии пользователя, попутно удаляя
+// из индекса id сессий, срок жизни которых уже истёк
+func (r *sessionsRepository) ListByUser(ctx context.Context, userID uint) ([]*entities.Session, error) {
+	ids, err := r.redis.SMembers(ctx, userSessionsKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*entities.Session, 0, len(ids))
+	for _, id := range ids {
+		session, err := r.Get(ctx, id)
+		if err != nil {
+			if err := r.redis.SRem(ctx, userSessionsKey(userID), id); err != nil {
+				lib.Log.Error("Failed to remove stale session id", zap.String("session_id", id), zap.Error(err))
+			}
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionsRepository) Delete(ctx context.Context, userID uint, sessionID string) error {
+	if err := r.redis.Del(ctx, sessionKey(sessionID)); err != nil {
+		return err
+	}
+	return r.redis.SRem(ctx, userSessionsKey(userID), sessionID)
+}
+
+func (r *sessionsRepository) DeleteAllByUser(ctx context.Context, userID uint) error {
+	ids, err := r.redis.SMembers(ctx, userSessionsKey(userID))
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := r.redis.Del(ctx, sessionKey(id)); err != nil {
+			return err
+		}
+	}
+
+	return r.redis.Del(ctx, userSessionsKey(userID))
+}
+
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
+}
+
+func userSessionsKey(userID uint) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
@@ -4,14 +4,22 @@ import (
 	"bank-app-backend/internal/entities"
 	"context"
 	"gorm.io/gorm"
+	"time"
 )
 
 type TransactionsRepository interface {
 	Create(ctx context.Context, tx *entities.Transaction) error
 	FindAll(ctx context.Context, filter *entities.TransactionFilter) ([]entities.Transaction, error)
 	FindByID(ctx context.Context, id uint) (*entities.Transaction, error)
+	FindAllByUser(ctx context.Context, userID uint, accountIDs []uint) ([]entities.Transaction, error)
+	CountTransfersSince(ctx context.Context, userID uint, since time.Time) (int64, error)
+	RecentTransferAmounts(ctx context.Context, userID uint, limit int) ([]float64, error)
+	HasTransferredTo(ctx context.Context, userID, toAccountID uint) (bool, error)
 }
 
+// transferTypes — типы транзакций, которые являются переводами пользователя
+var transferTypes = []entities.TransferType{entities.InternalTransfer, entities.ExternalTransfer}
+
 type transactionsRepository struct {
 	db *gorm.DB
 }
@@ -28,7 +36,13 @@ func (r *transactionsRepository) Create(ctx context.Context, tx *entities.Transa
 
 func (r *transactionsRepository) FindAll(ctx context.Context, filter *entities.TransactionFilter) ([]entities.Transaction, error) {
 	var txs []entities.Transaction
-	db := r.db.WithContext(ctx).Model(&entities.Transaction{}).Where("user_id = ?", filter.UserID)
+	db := r.db.WithContext(ctx).Model(&entities.Transaction{})
+	if len(filter.AccountIDs) > 0 {
+		db = db.Where("(user_id = ? OR from_account_id IN ? OR to_account_id IN ?)",
+			filter.UserID, filter.AccountIDs, filter.AccountIDs)
+	} else {
+		db = db.Where("user_id = ?", filter.UserID)
+	}
 
 	if filter.Type != nil {
 		db = db.Where("type = ?", *filter.Type)
@@ -65,3 +79,45 @@ func (r *transactionsRepository) FindByID(ctx context.Context, id uint) (*entiti
 	}
 	return &tx, nil
 }
+
+// FindAllByUser возвращает все транзакции, созданные пользователем
+// или затрагивающие его счета, без пагинации
+func (r *transactionsRepository) FindAllByUser(ctx context.Context, userID uint, accountIDs []uint) ([]entities.Transaction, error) {
+	var txs []entities.Transaction
+
+	db := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if len(accountIDs) > 0 {
+		db = db.Or("from_account_id IN ?", accountIDs).Or("to_account_id IN ?", accountIDs)
+	}
+
+	err := db.Order("created_at asc").Find(&txs).Error
+	return txs, err
+}
+
+func (r *transactionsRepository) CountTransfersSince(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.Transaction{}).
+		Where("user_id = ? AND type IN ? AND created_at >= ?", userID, transferTypes, since).
+		Count(&count).Error
+	return count, err
+}
+
+// RecentTransferAmounts возвращает суммы последних limit переводов пользователя
+func (r *transactionsRepository) RecentTransferAmounts(ctx context.Context, userID uint, limit int) ([]float64, error) {
+	var amounts []float64
+	err := r.db.WithContext(ctx).Model(&entities.Transaction{}).
+		Where("user_id = ? AND type IN ?", userID, transferTypes).
+		Order("created_at desc").
+		Limit(limit).
+		Pluck("amount", &amounts).Error
+	return amounts, err
+}
+
+func (r *transactionsRepository) HasTransferredTo(ctx context.Context, userID, toAccountID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.Transaction{}).
+		Where("user_id = ? AND to_account_id = ? AND type IN ?", userID, toAccountID, transferTypes).
+		Limit(1).
+		Count(&count).Error
+	return count > 0, err
+}
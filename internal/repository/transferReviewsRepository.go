@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"bank-app-backend/internal/entities"
+	"context"
+	"gorm.io/gorm"
+)
+
+type TransferReviewsRepository interface {
+	Create(ctx context.Context, review *entities.TransferReview) error
+	FindByID(ctx context.Context, id uint) (*entities.TransferReview, error)
+	FindByStatus(ctx context.Context, status entities.TransferReviewStatus) ([]*entities.TransferReview, error)
+	SetStatus(ctx context.Context, id uint, from, to entities.TransferReviewStatus) (bool, error)
+	Close(ctx context.Context, review *entities.TransferReview, from entities.TransferReviewStatus) error
+}
+
+type transferReviewsRepository struct {
+	db *gorm.DB
+}
+
+func NewTransferReviewsRepository(db *gorm.DB) TransferReviewsRepository {
+	return &transferReviewsRepository{db: db}
+}
+
+func (r *transferReviewsRepository) Create(ctx context.Context, review *entities.TransferReview) error {
+	return r.db.WithContext(ctx).Create(review).Error
+}
+
+func (r *transferReviewsRepository) FindByID(ctx context.Context, id uint) (*entities.TransferReview, error) {
+	var review entities.TransferReview
+	if err := r.db.WithContext(ctx).First(&review, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindByStatus возвращает заявки в порядке поступления; пустой статус — все заявки
+func (r *transferReviewsRepository) FindByStatus(ctx context.Context, status entities.TransferReviewStatus) ([]*entities.TransferReview, error) {
+	var reviews []*entities.TransferReview
+
+	db := r.db.WithContext(ctx)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	if err := db.Order("created_at asc").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// SetStatus переводит заявку из статуса from в to, только если её статус всё ещё from.
+// false означает, что заявку уже обрабатывает или решил другой сотрудник.
+func (r *transferReviewsRepository) SetStatus(ctx context.Context, id uint, from, to entities.TransferReviewStatus) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entities.TransferReview{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Close сохраняет решение по заявке, если её статус всё ещё from
+func (r *transferReviewsRepository) Close(ctx context.Context, review *entities.TransferReview, from entities.TransferReviewStatus) error {
+	return closeTransferReview(r.db.WithContext(ctx), review, from)
+}
+
+// closeTransferReview записывает статус, сотрудника, комментарий и транзакцию заявки
+// условным UPDATE; если статус заявки уже не from, возвращает gorm.ErrRecordNotFound
+func closeTransferReview(db *gorm.DB, review *entities.TransferReview, from entities.TransferReviewStatus) error {
+	result := db.Model(&entities.TransferReview{}).
+		Where("id = ? AND status = ?", review.ID, from).
+		Updates(map[string]interface{}{
+			"status":         review.Status,
+			"reviewer_id":    review.ReviewerID,
+			"note":           review.Note,
+			"transaction_id": review.TransactionID,
+			"reviewed_at":    review.ReviewedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -2,13 +2,13 @@ package repository
 
 import (
 	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/lib/cache"
 	lib "bank-app-backend/internal/lib/logger"
-	redis "bank-app-backend/internal/lib/redis"
 	"context"
-	"encoding/json"
-	"fmt"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
 	"time"
 )
 
@@ -16,23 +16,29 @@ type UsersRepository interface {
 	CreateUser(ctx context.Context, user *entities.User) error
 	FindByEmail(ctx context.Context, email string) (*entities.User, error)
 	FindByID(ctx context.Context, id uint) (*entities.User, error)
+	FindByIDWithPassword(ctx context.Context, id uint) (*entities.User, error)
 	FindAll(ctx context.Context) ([]*entities.User, error)
+	Search(ctx context.Context, filter *entities.UserFilter) ([]*entities.User, int64, error)
 	Update(ctx context.Context, user *entities.User) error
-	SaveRefreshToken(ctx context.Context, userID uint, token string) error
+	DeleteCache(ctx context.Context, userID uint) error
+	UpdatePassword(ctx context.Context, userID uint, hashedPassword string) error
+	UpdateRole(ctx context.Context, userID uint, role entities.Role) error
+	ChangeRole(ctx context.Context, userID uint, role entities.Role, check func(user *entities.User, admins int64) error) (*entities.User, error)
+	Change(ctx context.Context, userID uint, columns []string, change func(user *entities.User, admins int64) error) (*entities.User, error)
+	PromoteByEmails(ctx context.Context, emails []string, role entities.Role) error
 	FindUserByRefreshToken(ctx context.Context, token string) (*entities.User, error)
-	DeleteRefreshToken(ctx context.Context, userID uint) error
 	DeleteExpiredTokens(ctx context.Context) error
 }
 
 type usersRepository struct {
 	db    *gorm.DB
-	redis *redis.Client
+	cache *cache.Cache[entities.User]
 }
 
-func NewUsersRepository(db *gorm.DB, redisClient *redis.Client) UsersRepository {
+func NewUsersRepository(db *gorm.DB, userCache *cache.Cache[entities.User]) UsersRepository {
 	return &usersRepository{
 		db:    db,
-		redis: redisClient,
+		cache: userCache,
 	}
 }
 
@@ -48,33 +54,21 @@ func (r *usersRepository) FindByEmail(ctx context.Context, email string) (*entit
 	return &user, nil
 }
 
+// FindByID возвращает пользователя через кеш user:<id>. Хеш пароля в кеш
+// не попадает, поэтому у возвращённого пользователя Password пустой.
 func (r *usersRepository) FindByID(ctx context.Context, id uint) (*entities.User, error) {
-	key := fmt.Sprintf("user:%d", id)
-
-	cached, err := r.redis.Get(ctx, key)
-	if err == nil {
-		var user entities.User
-		if err := json.Unmarshal([]byte(cached), &user); err == nil {
-			lib.Log.Info("User found in Redis", zap.Uint("user_id", id))
-			return &user, nil
-		}
-	}
+	return r.cache.Get(ctx, id, func(ctx context.Context) (*entities.User, error) {
+		return r.FindByIDWithPassword(ctx, id)
+	})
+}
 
+// FindByIDWithPassword читает пользователя из базы в обход кеша, вместе с хешем пароля
+func (r *usersRepository) FindByIDWithPassword(ctx context.Context, id uint) (*entities.User, error) {
 	var user entities.User
 	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
 		lib.Log.Error("User not found in DB", zap.Uint("user_id", id), zap.Error(err))
 		return nil, err
 	}
-
-	data, err := json.Marshal(&user)
-	if err == nil {
-		lib.Log.Info("User cached in Redis", zap.Uint("user_id", id))
-		err := r.redis.Set(ctx, key, data, 10*time.Minute)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	return &user, nil
 }
 
@@ -87,37 +81,186 @@ func (r *usersRepository) FindAll(ctx context.Context) ([]*entities.User, error)
 	return users, nil
 }
 
-func (r *usersRepository) Update(ctx context.Context, user *entities.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+// userSortColumns — поля, по которым разрешена сортировка пользователей
+var userSortColumns = map[string]string{
+	"id":       "id",
+	"email":    "email",
+	"username": "username",
+}
+
+func (r *usersRepository) Search(ctx context.Context, filter *entities.UserFilter) ([]*entities.User, int64, error) {
+	var users []*entities.User
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entities.User{})
+
+	if filter.Query != "" {
+		pattern := "%" + strings.ToLower(filter.Query) + "%"
+		db = db.Where("LOWER(email) LIKE ? OR LOWER(username) LIKE ?", pattern, pattern)
+	}
+	if filter.Role != nil {
+		db = db.Where("role = ?", *filter.Role)
+	}
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			db = db.Where("blocked_at IS NOT NULL")
+		} else {
+			db = db.Where("blocked_at IS NULL")
+		}
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	column, ok := userSortColumns[filter.SortBy]
+	if !ok {
+		column = "id"
+	}
+	direction := "asc"
+	if filter.Desc {
+		direction = "desc"
+	}
+
+	err := db.Order(column + " " + direction).
+		Limit(filter.Limit).
+		Offset((filter.Page - 1) * filter.Limit).
+		Find(&users).Error
+
+	return users, total, err
 }
 
-func (r *usersRepository) SaveRefreshToken(ctx context.Context, userID uint, token string) error {
-	key := fmt.Sprintf("refresh_token:%d", userID)
-	if err := r.redis.Set(ctx, key, token, 7*24*time.Hour); err != nil {
-		lib.Log.Error("Failed to save refresh token", zap.Uint("userID", userID), zap.Error(err))
+// Update сохраняет пользователя и обновляет кеш. Пароль не перезаписывается:
+// для его смены используется UpdatePassword.
+func (r *usersRepository) Update(ctx context.Context, user *entities.User) error {
+	if err := r.db.WithContext(ctx).Omit("password").Save(user).Error; err != nil {
 		return err
 	}
 
+	if err := r.cache.Set(ctx, user.ID, user); err != nil {
+		lib.Log.Error("Failed to update user cache", zap.Uint("user_id", user.ID), zap.Error(err))
+		return r.DeleteCache(ctx, user.ID)
+	}
+
 	return nil
 }
 
-func (r *usersRepository) FindUserByRefreshToken(ctx context.Context, token string) (*entities.User, error) {
+// DeleteCache удаляет закешированного пользователя user:<id> из Redis и памяти всех инстансов
+func (r *usersRepository) DeleteCache(ctx context.Context, userID uint) error {
+	if err := r.cache.Invalidate(ctx, userID); err != nil {
+		lib.Log.Error("Failed to invalidate user cache", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UpdatePassword обновляет хеш пароля и сбрасывает кеш пользователя
+func (r *usersRepository) UpdatePassword(ctx context.Context, userID uint, hashedPassword string) error {
+	if err := r.db.WithContext(ctx).
+		Model(&entities.User{}).
+		Where("id = ?", userID).
+		Update("password", hashedPassword).Error; err != nil {
+		return err
+	}
+
+	return r.DeleteCache(ctx, userID)
+}
+
+// UpdateRole меняет роль пользователя и сбрасывает кеш пользователя
+func (r *usersRepository) UpdateRole(ctx context.Context, userID uint, role entities.Role) error {
+	result := r.db.WithContext(ctx).
+		Model(&entities.User{}).
+		Where("id = ?", userID).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return r.DeleteCache(ctx, userID)
+}
+
+// ChangeRole меняет роль пользователя в транзакции. check получает текущее состояние
+// пользователя и число незаблокированных администраторов, как в Change.
+func (r *usersRepository) ChangeRole(ctx context.Context, userID uint, role entities.Role, check func(user *entities.User, admins int64) error) (*entities.User, error) {
+	return r.Change(ctx, userID, []string{"role"}, func(user *entities.User, admins int64) error {
+		if err := check(user, admins); err != nil {
+			return err
+		}
+		user.Role = role
+		return nil
+	})
+}
+
+// Change в транзакции читает пользователя из базы, вызывает change, который проверяет и
+// меняет его, и сохраняет только колонки columns: параллельные изменения других полей не
+// затираются. change получает число незаблокированных администраторов; их строки
+// заблокированы до конца транзакции, поэтому два одновременных понижения или блокировки
+// не оставят систему без администратора.
+func (r *usersRepository) Change(ctx context.Context, userID uint, columns []string, change func(user *entities.User, admins int64) error) (*entities.User, error) {
 	var user entities.User
-	if err := r.db.WithContext(ctx).Where("refresh_token = ?", token).First(&user).Error; err != nil {
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var adminIDs []uint
+		if err := tx.Model(&entities.User{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("role = ? AND blocked_at IS NULL", entities.RoleAdmin).
+			Pluck("id", &adminIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, "id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := change(&user, int64(len(adminIDs))); err != nil {
+			return err
+		}
+
+		return tx.Model(&user).Select(columns).Updates(&user).Error
+	})
+	if err != nil {
 		return nil, err
 	}
+
+	if err := r.DeleteCache(ctx, userID); err != nil {
+		lib.Log.Warn("Failed to invalidate user cache", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	user.Password = ""
 	return &user, nil
 }
 
-func (r *usersRepository) DeleteRefreshToken(ctx context.Context, userID uint) error {
-	key := fmt.Sprintf("refresh_token:%d", userID)
-	err := r.redis.Del(ctx, key)
-	if err != nil {
-		lib.Log.Error("Error deleting key", zap.String("key", key), zap.Error(err))
-	} else {
-		lib.Log.Info("Key successfully deleted", zap.String("key", key))
+// PromoteByEmails назначает роль пользователям с указанными email
+func (r *usersRepository) PromoteByEmails(ctx context.Context, emails []string, role entities.Role) error {
+	if len(emails) == 0 {
+		return nil
 	}
-	return err
+
+	var users []*entities.User
+	if err := r.db.WithContext(ctx).Where("email IN ?", emails).Find(&users).Error; err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if user.Role == role {
+			continue
+		}
+		if err := r.UpdateRole(ctx, user.ID, role); err != nil {
+			return err
+		}
+		lib.Log.Info("User role assigned", zap.Uint("user_id", user.ID), zap.String("role", string(role)))
+	}
+
+	return nil
+}
+
+func (r *usersRepository) FindUserByRefreshToken(ctx context.Context, token string) (*entities.User, error) {
+	var user entities.User
+	if err := r.db.WithContext(ctx).Where("refresh_token = ?", token).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
 }
 
 func (r *usersRepository) DeleteExpiredTokens(ctx context.Context) error {
@@ -0,0 +1,411 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+type AccountMembersService interface {
+	Authorize(ctx context.Context, userID, accountID uint, permission entities.AccountPermission) (*entities.Account, *entities.AccountMember, error)
+	List(ctx context.Context, userID, accountID uint) ([]*entities.AccountMember, error)
+	Invite(ctx context.Context, userID, accountID uint, req *entities.InviteMemberRequest) (*entities.AccountInvitation, error)
+	AccountInvitations(ctx context.Context, userID, accountID uint) ([]*entities.AccountInvitation, error)
+	RevokeInvitation(ctx context.Context, userID, accountID, invitationID uint) error
+	MyInvitations(ctx context.Context, userID uint) ([]*entities.AccountInvitation, error)
+	AcceptInvitation(ctx context.Context, userID, invitationID uint) (*entities.AccountMember, error)
+	DeclineInvitation(ctx context.Context, userID, invitationID uint) error
+	UpdateMember(ctx context.Context, userID, accountID, memberID uint, req *entities.UpdateMemberRequest) (*entities.AccountMember, error)
+	RemoveMember(ctx context.Context, userID, accountID, memberID uint) error
+}
+
+var (
+	ErrAccountNotFound    = errors.New("account not found")
+	ErrAccountForbidden   = errors.New("operation is not allowed for your role on this account")
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrAlreadyMember      = errors.New("user is already a member of this account")
+	ErrOwnerMembership    = errors.New("account owner cannot be changed or removed")
+	ErrSpendLimitRequired = errors.New("spend_limit is required for the spender role")
+	ErrSpendLimitExceeded = errors.New("amount exceeds your spend limit on this account")
+)
+
+type accountMembersService struct {
+	repo          repository.AccountMembersRepository
+	accRepo       repository.AccountsRepository
+	usersRepo     repository.UsersRepository
+	invitationTTL time.Duration
+	audit         AuditService
+}
+
+func NewAccountMembersService(
+	r repository.AccountMembersRepository,
+	accRepo repository.AccountsRepository,
+	usersRepo repository.UsersRepository,
+	invitationTTL time.Duration,
+	audit AuditService,
+) AccountMembersService {
+	return &accountMembersService{
+		repo:          r,
+		accRepo:       accRepo,
+		usersRepo:     usersRepo,
+		invitationTTL: invitationTTL,
+		audit:         audit,
+	}
+}
+
+// Authorize возвращает счёт и членство пользователя, если его роль даёт право permission.
+// Тем, кто не является участником, счёт не раскрывается: для них он «не найден».
+func (s *accountMembersService) Authorize(ctx context.Context, userID, accountID uint, permission entities.AccountPermission) (*entities.Account, *entities.AccountMember, error) {
+	member, err := s.repo.FindMember(ctx, accountID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrAccountNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get account member: %w", err)
+	}
+
+	if !member.Role.Can(permission) {
+		return nil, nil, ErrAccountForbidden
+	}
+
+	account, err := s.accRepo.FindByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrAccountNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	return account, member, nil
+}
+
+func (s *accountMembersService) List(ctx context.Context, userID, accountID uint) ([]*entities.AccountMember, error) {
+	if _, _, err := s.Authorize(ctx, userID, accountID, entities.PermissionView); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.FindByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account members: %w", err)
+	}
+	return members, nil
+}
+
+// Invite приглашает пользователя по email. Приглашение можно отправить и на адрес,
+// который ещё не зарегистрирован: оно станет видно после регистрации.
+func (s *accountMembersService) Invite(ctx context.Context, userID, accountID uint, req *entities.InviteMemberRequest) (invitation *entities.AccountInvitation, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditMemberInvite,
+			ResourceType: "account",
+			ResourceID:   accountID,
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.After = invitation
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	account, _, err := s.Authorize(ctx, userID, accountID, entities.PermissionManage)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status == entities.AccountClosed {
+		return nil, errors.New("account is closed")
+	}
+	if err := checkMemberRole(req.Role, req.SpendLimit); err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if invitee, err := s.usersRepo.FindByEmail(ctx, email); err == nil {
+		if _, err := s.repo.FindMember(ctx, accountID, invitee.ID); err == nil {
+			return nil, ErrAlreadyMember
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to check account members: %w", err)
+		}
+	}
+
+	invitation = &entities.AccountInvitation{
+		AccountID:  accountID,
+		InvitedBy:  userID,
+		Email:      email,
+		Role:       req.Role,
+		SpendLimit: spendLimit(req.Role, req.SpendLimit),
+		Status:     entities.InvitationPending,
+		ExpiresAt:  time.Now().Add(s.invitationTTL),
+	}
+
+	if err := s.repo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to save invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+func (s *accountMembersService) AccountInvitations(ctx context.Context, userID, accountID uint) ([]*entities.AccountInvitation, error) {
+	if _, _, err := s.Authorize(ctx, userID, accountID, entities.PermissionManage); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.repo.FindInvitationsByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (s *accountMembersService) RevokeInvitation(ctx context.Context, userID, accountID, invitationID uint) error {
+	if _, _, err := s.Authorize(ctx, userID, accountID, entities.PermissionManage); err != nil {
+		return err
+	}
+
+	invitation, err := s.pendingInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation.AccountID != accountID {
+		return ErrInvitationNotFound
+	}
+
+	return s.respond(ctx, invitation, entities.InvitationRevoked)
+}
+
+// MyInvitations возвращает действующие приглашения, адресованные email пользователя
+func (s *accountMembersService) MyInvitations(ctx context.Context, userID uint) ([]*entities.AccountInvitation, error) {
+	user, err := s.usersRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %v", err)
+	}
+
+	invitations, err := s.repo.FindPendingInvitations(ctx, strings.ToLower(user.Email), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (s *accountMembersService) AcceptInvitation(ctx context.Context, userID, invitationID uint) (member *entities.AccountMember, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditMemberAccept,
+			ResourceType: "account_invitation",
+			ResourceID:   invitationID,
+			Err:          err,
+		}
+		if err == nil {
+			event.After = member
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	invitation, err := s.invitationFor(ctx, userID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accRepo.FindByID(ctx, invitation.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if account.Status == entities.AccountClosed {
+		return nil, errors.New("account is closed")
+	}
+
+	if _, err := s.repo.FindMember(ctx, invitation.AccountID, userID); err == nil {
+		return nil, ErrAlreadyMember
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check account members: %w", err)
+	}
+
+	now := time.Now()
+	invitation.Status = entities.InvitationAccepted
+	invitation.RespondedAt = &now
+
+	member = &entities.AccountMember{
+		AccountID:  invitation.AccountID,
+		UserID:     userID,
+		Role:       invitation.Role,
+		SpendLimit: invitation.SpendLimit,
+	}
+
+	if err := s.repo.AcceptInvitation(ctx, invitation, member); err != nil {
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	return member, nil
+}
+
+func (s *accountMembersService) DeclineInvitation(ctx context.Context, userID, invitationID uint) error {
+	invitation, err := s.invitationFor(ctx, userID, invitationID)
+	if err != nil {
+		return err
+	}
+
+	return s.respond(ctx, invitation, entities.InvitationDeclined)
+}
+
+// UpdateMember меняет роль или лимит участника; роль владельца не меняется
+func (s *accountMembersService) UpdateMember(ctx context.Context, userID, accountID, memberID uint, req *entities.UpdateMemberRequest) (member *entities.AccountMember, err error) {
+	var before entities.AccountMember
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditMemberUpdate,
+			ResourceType: "account",
+			ResourceID:   accountID,
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.Before = before
+			event.After = member
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if _, _, err := s.Authorize(ctx, userID, accountID, entities.PermissionManage); err != nil {
+		return nil, err
+	}
+	if err := checkMemberRole(req.Role, req.SpendLimit); err != nil {
+		return nil, err
+	}
+
+	member, err = s.member(ctx, accountID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == entities.AccountRoleOwner {
+		return nil, ErrOwnerMembership
+	}
+	before = *member
+
+	member.Role = req.Role
+	member.SpendLimit = spendLimit(req.Role, req.SpendLimit)
+
+	if err := s.repo.UpdateMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to update account member: %w", err)
+	}
+
+	return member, nil
+}
+
+// RemoveMember исключает участника. Владелец может исключить любого, кроме себя,
+// остальные участники могут только выйти из счёта сами.
+func (s *accountMembersService) RemoveMember(ctx context.Context, userID, accountID, memberID uint) (err error) {
+	var before *entities.AccountMember
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditMemberRemove,
+			ResourceType: "account",
+			ResourceID:   accountID,
+			Before:       before,
+			Err:          err,
+		})
+	}()
+
+	permission := entities.PermissionManage
+	if memberID == userID {
+		permission = entities.PermissionView
+	}
+	if _, _, err := s.Authorize(ctx, userID, accountID, permission); err != nil {
+		return err
+	}
+
+	before, err = s.member(ctx, accountID, memberID)
+	if err != nil {
+		return err
+	}
+	if before.Role == entities.AccountRoleOwner {
+		return ErrOwnerMembership
+	}
+
+	if err := s.repo.DeleteMember(ctx, accountID, memberID); err != nil {
+		return fmt.Errorf("failed to remove account member: %w", err)
+	}
+	return nil
+}
+
+func (s *accountMembersService) member(ctx context.Context, accountID, userID uint) (*entities.AccountMember, error) {
+	member, err := s.repo.FindMember(ctx, accountID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("account member not found")
+		}
+		return nil, fmt.Errorf("failed to get account member: %w", err)
+	}
+	return member, nil
+}
+
+// invitationFor возвращает действующее приглашение, адресованное пользователю
+func (s *accountMembersService) invitationFor(ctx context.Context, userID, invitationID uint) (*entities.AccountInvitation, error) {
+	invitation, err := s.pendingInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.usersRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %v", err)
+	}
+	if invitation.Email != strings.ToLower(user.Email) {
+		return nil, ErrInvitationNotFound
+	}
+
+	return invitation, nil
+}
+
+func (s *accountMembersService) pendingInvitation(ctx context.Context, invitationID uint) (*entities.AccountInvitation, error) {
+	invitation, err := s.repo.FindInvitation(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	if invitation.Status != entities.InvitationPending || time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+func (s *accountMembersService) respond(ctx context.Context, invitation *entities.AccountInvitation, status entities.InvitationStatus) error {
+	now := time.Now()
+	invitation.Status = status
+	invitation.RespondedAt = &now
+
+	if err := s.repo.UpdateInvitation(ctx, invitation); err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
+	}
+	return nil
+}
+
+// checkSpendLimit ограничивает сумму одной операции участника с ролью spender
+func checkSpendLimit(member *entities.AccountMember, amount float64) error {
+	if member.Role == entities.AccountRoleSpender && amount > member.SpendLimit {
+		return fmt.Errorf("%w (%.2f)", ErrSpendLimitExceeded, member.SpendLimit)
+	}
+	return nil
+}
+
+func checkMemberRole(role entities.AccountRole, limit float64) error {
+	if role == entities.AccountRoleSpender && limit <= 0 {
+		return ErrSpendLimitRequired
+	}
+	return nil
+}
+
+// spendLimit хранит лимит только у роли spender, у остальных ролей он не применяется
+func spendLimit(role entities.AccountRole, limit float64) float64 {
+	if role != entities.AccountRoleSpender {
+		return 0
+	}
+	return limit
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AccountStatusService interface {
+	Freeze(ctx context.Context, accountID uint, reason string) (*entities.Account, error)
+	Unfreeze(ctx context.Context, accountID uint, reason string) (*entities.Account, error)
+	Run(ctx context.Context)
+	MarkDormant(ctx context.Context, businessDate time.Time) (int, error)
+}
+
+var (
+	ErrAccountInactive         = errors.New("account status does not allow the operation")
+	ErrInvalidStatusTransition = errors.New("invalid account status transition")
+)
+
+// AccountStatusError сообщает, что операция невозможна из-за статуса счёта
+type AccountStatusError struct {
+	AccountID uint
+	Status    entities.AccountStatus
+}
+
+func (e *AccountStatusError) Error() string {
+	return fmt.Sprintf("account %d is %s", e.AccountID, e.Status)
+}
+
+func (e *AccountStatusError) Is(target error) bool {
+	return target == ErrAccountInactive
+}
+
+// dormantReason — причина, с которой задача переводит неактивные счета в статус dormant
+const dormantReason = "Нет операций по счёту"
+
+type accountStatusService struct {
+	repo         repository.AccountsRepository
+	dormantAfter time.Duration
+	interval     time.Duration
+	location     *time.Location
+	runs         repository.JobRunsRepository
+	audit        AuditService
+}
+
+// NewAccountStatusService создаёт сервис статусов счетов. dormantAfter — срок без
+// клиентских операций, после которого счёт становится dormant; 0 отключает задачу.
+func NewAccountStatusService(
+	r repository.AccountsRepository,
+	dormantAfter time.Duration,
+	interval time.Duration,
+	timezone string,
+	runs repository.JobRunsRepository,
+	audit AuditService,
+) AccountStatusService {
+	return &accountStatusService{
+		repo:         r,
+		dormantAfter: dormantAfter,
+		interval:     interval,
+		location:     loadLocation(timezone),
+		runs:         runs,
+		audit:        audit,
+	}
+}
+
+func (s *accountStatusService) Freeze(ctx context.Context, accountID uint, reason string) (*entities.Account, error) {
+	return s.change(ctx, accountID, entities.AccountFrozen, reason)
+}
+
+// Unfreeze возвращает замороженный счёт в статус active
+func (s *accountStatusService) Unfreeze(ctx context.Context, accountID uint, reason string) (*entities.Account, error) {
+	return s.change(ctx, accountID, entities.AccountActive, reason, entities.AccountFrozen)
+}
+
+// Run переводит неактивные счета в статус dormant раз за операционный день
+func (s *accountStatusService) Run(ctx context.Context) {
+	if s.dormantAfter <= 0 {
+		return
+	}
+
+	runDaily(ctx, "dormancy", s.interval, s.location, s.runs, func(ctx context.Context, date time.Time) error {
+		_, err := s.MarkDormant(ctx, date)
+		return err
+	})
+}
+
+// MarkDormant переводит в статус dormant счета без клиентских операций за dormantAfter
+// до конца дня businessDate и возвращает их число
+func (s *accountStatusService) MarkDormant(ctx context.Context, businessDate time.Time) (int, error) {
+	since := businessDate.AddDate(0, 0, 1).Add(-s.dormantAfter)
+
+	accounts, err := s.repo.FindInactive(ctx, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get inactive accounts: %w", err)
+	}
+
+	marked := 0
+	for _, account := range accounts {
+		if _, err := s.apply(ctx, account, entities.AccountDormant, dormantReason); err != nil {
+			lib.Log.Error("Failed to mark account dormant", zap.Uint("account_id", account.ID), zap.Error(err))
+			continue
+		}
+		marked++
+	}
+
+	if marked > 0 {
+		lib.Log.Info("Accounts marked dormant", zap.Int("count", marked))
+	}
+	return marked, nil
+}
+
+// change переводит счёт в статус to. Если заданы from, исходный статус должен быть одним из них.
+func (s *accountStatusService) change(ctx context.Context, accountID uint, to entities.AccountStatus, reason string, from ...entities.AccountStatus) (*entities.Account, error) {
+	account, err := s.repo.FindByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if len(from) > 0 && !containsStatus(from, account.Status) {
+		return nil, fmt.Errorf("%w: account is %s", ErrInvalidStatusTransition, account.Status)
+	}
+
+	return s.apply(ctx, account, to, reason)
+}
+
+func (s *accountStatusService) apply(ctx context.Context, account *entities.Account, to entities.AccountStatus, reason string) (_ *entities.Account, err error) {
+	before := account.ToResponse()
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditAccountStatus,
+			ResourceType: "account",
+			ResourceID:   account.ID,
+			Before:       before,
+			After:        map[string]interface{}{"status": to, "reason": reason},
+			Err:          err,
+		}
+		if err == nil {
+			event.After = account.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if err := transitionAccount(account, to, reason); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+	return account, nil
+}
+
+// transitionAccount меняет статус счёта, если переход разрешён, и запоминает причину
+func transitionAccount(account *entities.Account, to entities.AccountStatus, reason string) error {
+	if !account.Status.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, account.Status, to)
+	}
+
+	now := time.Now()
+	account.Status = to
+	account.StatusReason = reason
+	account.StatusChangedAt = &now
+	return nil
+}
+
+// checkDebit проверяет, что статус счёта позволяет списание
+func checkDebit(account *entities.Account) error {
+	if !account.Status.CanDebit() {
+		return &AccountStatusError{AccountID: account.ID, Status: account.Status}
+	}
+	return nil
+}
+
+// checkCredit проверяет, что статус счёта позволяет зачисление
+func checkCredit(account *entities.Account) error {
+	if !account.Status.CanCredit() {
+		return &AccountStatusError{AccountID: account.ID, Status: account.Status}
+	}
+	return nil
+}
+
+func containsStatus(statuses []entities.AccountStatus, status entities.AccountStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
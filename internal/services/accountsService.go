@@ -6,10 +6,12 @@ import (
 	lib "bank-app-backend/internal/lib/logger"
 	"bank-app-backend/internal/repository"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
@@ -17,25 +19,67 @@ type AccountsService interface {
 	GetAll(ctx context.Context, userID uint) ([]*entities.Account, error)
 	GetByID(ctx context.Context, userID, accountID uint) (*entities.Account, error)
 	Deposit(ctx context.Context, userID, accountID uint, amount float64) (*entities.Account, error)
+	Withdraw(ctx context.Context, userID, accountID uint, amount float64) (*entities.Account, error)
 	Create(ctx context.Context, userID uint, input *entities.CreateAccountRequest) (*entities.Account, error)
 	Delete(ctx context.Context, userID, accountID uint) error
+	SetOverdraft(ctx context.Context, accountID uint, limit float64) (*entities.Account, error)
 }
 
+var (
+	ErrInsufficientFunds   = errors.New("insufficient funds")
+	ErrOverdraftBelowDebt  = errors.New("overdraft limit is below the current debt")
+	ErrAccountNotClosable  = errors.New("cannot close account")
+	ErrReservedAccountType = errors.New("account type is reserved for the bank")
+)
+
 type accountsService struct {
-	repo     repository.AccountsRepository
-	txRepo   repository.TransactionsRepository
-	producer *kafka.Producer
+	repo       repository.AccountsRepository
+	holdsRepo  repository.HoldsRepository
+	cardsRepo  repository.CardsRepository
+	loansRepo  repository.LoansRepository
+	members    AccountMembersService
+	limits     LimitsService
+	fees       FeesService
+	pots       PotsService
+	currencies CurrenciesService
+	kyc        KYCService
+	producer   *kafka.Producer
+	completed  *kafka.Producer
+	overdrawn  *kafka.Producer
+	audit      AuditService
 }
 
 func NewAccountsService(
 	r repository.AccountsRepository,
-	txRepo repository.TransactionsRepository,
+	holdsRepo repository.HoldsRepository,
+	cardsRepo repository.CardsRepository,
+	loansRepo repository.LoansRepository,
+	members AccountMembersService,
+	limits LimitsService,
+	fees FeesService,
+	pots PotsService,
+	currencies CurrenciesService,
+	kyc KYCService,
 	prod *kafka.Producer,
+	completed *kafka.Producer,
+	overdrawn *kafka.Producer,
+	audit AuditService,
 ) AccountsService {
 	return &accountsService{
-		repo:     r,
-		txRepo:   txRepo,
-		producer: prod,
+		repo:       r,
+		holdsRepo:  holdsRepo,
+		cardsRepo:  cardsRepo,
+		loansRepo:  loansRepo,
+		members:    members,
+		limits:     limits,
+		fees:       fees,
+		pots:       pots,
+		currencies: currencies,
+		kyc:        kyc,
+		producer:   prod,
+		completed:  completed,
+		overdrawn:  overdrawn,
+		audit:      audit,
 	}
 }
 
@@ -52,20 +96,60 @@ func (s *accountsService) GetByID(ctx context.Context, userID, accountID uint) (
 	account, err := s.repo.GetByID(ctx, userID, accountID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("account not found")
+			return nil, ErrAccountNotFound
 		}
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 	return account, nil
 }
 
-func (s *accountsService) Create(ctx context.Context, userID uint, req *entities.CreateAccountRequest) (*entities.Account, error) {
-	account := &entities.Account{
+func (s *accountsService) Create(ctx context.Context, userID uint, req *entities.CreateAccountRequest) (account *entities.Account, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditAccountCreate,
+			ResourceType: "account",
+			After:        req,
+			Err:          err,
+		}
+		if account != nil && account.ID != 0 {
+			event.ResourceID = account.ID
+			event.After = account.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if strings.EqualFold(req.Type, entities.AccountTypeBank) {
+		return nil, ErrReservedAccountType
+	}
+
+	currency, err := s.currencies.Validate(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Лимит уровня KYC считается по собственным счетам, без совместных чужих
+	accounts, err := s.repo.GetOwned(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	openAccounts := 0
+	for _, acc := range accounts {
+		if acc.Status != entities.AccountClosed {
+			openAccounts++
+		}
+	}
+
+	if err := s.kyc.CheckAccountCreation(ctx, userID, openAccounts); err != nil {
+		return nil, err
+	}
+
+	account = &entities.Account{
 		UserID:   userID,
 		Type:     req.Type,
-		Currency: req.Currency,
+		Currency: currency.Code,
 		Balance:  0,
-		Status:   "active",
+		Status:   entities.AccountActive,
 	}
 
 	if err := s.repo.Create(ctx, account); err != nil {
@@ -80,20 +164,37 @@ func (s *accountsService) Create(ctx context.Context, userID uint, req *entities
 	return account, nil
 }
 
-func (s *accountsService) Deposit(ctx context.Context, userID, accountID uint, amount float64) (*entities.Account, error) {
-	account, err := s.repo.GetByID(ctx, userID, accountID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("account not found")
+func (s *accountsService) Deposit(ctx context.Context, userID, accountID uint, amount float64) (account *entities.Account, err error) {
+	var before *entities.AccountResponse
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditDeposit,
+			ResourceType: "account",
+			ResourceID:   accountID,
+			After:        map[string]float64{"amount": amount},
+			Err:          err,
 		}
-	}
-
-	account.Balance += amount
+		if err == nil {
+			event.Before = before
+			event.After = account.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
 
-	if err := s.repo.Update(ctx, account); err != nil {
-		return nil, fmt.Errorf("failed to update account: %w", err)
+	if err := s.kyc.CheckDeposit(ctx, userID, amount); err != nil {
+		return nil, err
 	}
 
+	account, _, err = s.members.Authorize(ctx, userID, accountID, entities.PermissionDeposit)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCredit(account); err != nil {
+		return nil, err
+	}
+	if err := s.currencies.CheckAmount(account.Currency, amount); err != nil {
+		return nil, err
+	}
 	tx := &entities.Transaction{
 		FromAccountID: 0, // Внешний источник (например, банк)
 		ToAccountID:   accountID,
@@ -104,40 +205,276 @@ func (s *accountsService) Deposit(ctx context.Context, userID, accountID uint, a
 		CreatedAt:     time.Now(),
 	}
 
-	if err := s.txRepo.Create(ctx, tx); err != nil {
+	_, account, err = s.repo.Post(ctx, &repository.Posting{
+		Transaction: tx,
+		Check: func(_, to *entities.Account) error {
+			if err := checkCredit(to); err != nil {
+				return err
+			}
+			before = to.ToResponse()
+			// Пополнение участником счёта — активность клиента, поэтому спящий счёт снова становится активным
+			if to.Status == entities.AccountDormant {
+				return transitionAccount(to, entities.AccountActive, "Клиент пополнил счёт")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		if errors.Is(err, ErrAccountInactive) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to deposit: %w", err)
+	}
+
+	publishTransactionCompleted(s.completed, tx, account.UserID)
+	return account, nil
+}
+
+// Withdraw выводит деньги со счёта за пределы банка с проверкой лимитов списаний
+func (s *accountsService) Withdraw(ctx context.Context, userID, accountID uint, amount float64) (account *entities.Account, err error) {
+	var before *entities.AccountResponse
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditWithdrawal,
+			ResourceType: "account",
+			ResourceID:   accountID,
+			After:        map[string]float64{"amount": amount},
+			Err:          err,
+		}
+		if err == nil {
+			event.Before = before
+			event.After = account.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	account, member, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionSpend)
+	if err != nil {
 		return nil, err
 	}
+	if err := checkSpendLimit(member, amount); err != nil {
+		return nil, err
+	}
+	if err := checkDebit(account); err != nil {
+		return nil, err
+	}
+	if err := s.currencies.CheckAmount(account.Currency, amount); err != nil {
+		return nil, err
+	}
+	before = account.ToResponse()
+
+	quote, err := s.fees.Quote(ctx, account, entities.Withdrawal, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := s.limits.Charge(ctx, userID, account, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &entities.Transaction{
+		FromAccountID: accountID,
+		ToAccountID:   0, // Внешний получатель
+		UserID:        userID,
+		Amount:        amount,
+		Description:   "Снятие со счёта",
+		Type:          entities.Withdrawal,
+		CreatedAt:     time.Now(),
+	}
+
+	var balanceBefore float64
+	account, _, err = s.repo.Post(ctx, &repository.Posting{
+		Transaction: tx,
+		Debit:       quote.Total,
+		Limits:      limits,
+		Fee:         s.fees.Charge(account, quote),
+		Check: func(from, _ *entities.Account) error {
+			if err := checkDebit(from); err != nil {
+				return err
+			}
+			// Холды и копилки читаются под той же блокировкой, что и списание
+			if from.Available() < quote.Total {
+				return ErrInsufficientFunds
+			}
+			balanceBefore = from.Balance
+			return nil
+		},
+	})
+	if err != nil {
+		if errors.Is(err, ErrAccountInactive) || errors.Is(err, ErrInsufficientFunds) ||
+			errors.Is(err, ErrLimitExceeded) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to withdraw: %w", err)
+	}
+
+	publishOverdrawn(s.overdrawn, account, balanceBefore)
+	s.pots.RoundUp(ctx, account, amount)
 
 	return account, nil
 }
 
-func (s *accountsService) Delete(ctx context.Context, userID, accountID uint) error {
-	account, err := s.repo.GetByID(ctx, userID, accountID)
+func (s *accountsService) Delete(ctx context.Context, userID, accountID uint) (err error) {
+	var before, after *entities.AccountResponse
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditAccountClose,
+			ResourceType: "account",
+			ResourceID:   accountID,
+			Before:       before,
+			After:        after,
+			Err:          err,
+		})
+	}()
+
+	if _, _, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionClose); err != nil {
+		return err
+	}
+
+	// Остаток и холды проверяются под блокировкой счёта, вместе со сменой статуса
+	account, err := s.repo.ChangeStatus(ctx, accountID, func(account *entities.Account) error {
+		before = account.ToResponse()
+		if err := checkAccountClosable(ctx, account, s.holdsRepo, s.cardsRepo, s.loansRepo); err != nil {
+			return err
+		}
+		return transitionAccount(account, entities.AccountClosed, "Закрыт клиентом")
+	})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fmt.Errorf("account not found")
+		if errors.Is(err, ErrAccountNotClosable) || errors.Is(err, ErrInvalidStatusTransition) {
+			return err
 		}
-		return fmt.Errorf("failed to fetch account: %w", err)
+		return fmt.Errorf("failed to close account: %w", err)
 	}
+	after = account.ToResponse()
 
+	return nil
+}
+
+// checkAccountClosable проверяет, что закрытие счёта ничего не оставит висеть: на нём нет
+// денег и активных холдов, к нему не выпущены действующие карты и по нему нет кредита,
+// который ещё не отклонён или не погашен
+func checkAccountClosable(
+	ctx context.Context,
+	account *entities.Account,
+	holdsRepo repository.HoldsRepository,
+	cardsRepo repository.CardsRepository,
+	loansRepo repository.LoansRepository,
+) error {
 	if account.Balance != 0 {
-		return fmt.Errorf("cannot close account with non-zero balance")
+		return fmt.Errorf("%w: balance is not zero", ErrAccountNotClosable)
 	}
 
-	account.Status = "closed"
+	holds, err := holdsRepo.FindByAccount(ctx, account.ID, entities.HoldActive)
+	if err != nil {
+		return fmt.Errorf("failed to get holds: %w", err)
+	}
+	if len(holds) > 0 {
+		return fmt.Errorf("%w: there are active holds", ErrAccountNotClosable)
+	}
 
-	if err := s.repo.Update(ctx, account); err != nil {
-		return fmt.Errorf("failed to close account: %w", err)
+	cards, err := cardsRepo.FindByAccount(ctx, account.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+	for _, card := range cards {
+		if card.Status != entities.CardCancelled {
+			return fmt.Errorf("%w: there are cards that are not cancelled", ErrAccountNotClosable)
+		}
 	}
 
+	loans, err := loansRepo.FindByAccount(ctx, account.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get loans: %w", err)
+	}
+	for _, loan := range loans {
+		if loan.Status == entities.LoanPending || loan.Status == entities.LoanActive {
+			return fmt.Errorf("%w: there is a %s loan", ErrAccountNotClosable, loan.Status)
+		}
+	}
 	return nil
 }
 
+// SetOverdraft задаёт лимит овердрафта счёта. Лимит нельзя сделать меньше текущего долга.
+func (s *accountsService) SetOverdraft(ctx context.Context, accountID uint, limit float64) (account *entities.Account, err error) {
+	var before *entities.AccountResponse
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditOverdraftSet,
+			ResourceType: "account",
+			ResourceID:   accountID,
+			Before:       before,
+			After:        map[string]float64{"overdraft_limit": limit},
+			Err:          err,
+		}
+		if err == nil {
+			event.After = account.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	account, err = s.repo.FindByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	before = account.ToResponse()
+
+	if account.Status == entities.AccountClosed {
+		return nil, &AccountStatusError{AccountID: account.ID, Status: account.Status}
+	}
+	if err := s.currencies.CheckAmount(account.Currency, limit); err != nil {
+		return nil, err
+	}
+	if account.Balance+limit < 0 {
+		return nil, ErrOverdraftBelowDebt
+	}
+
+	account.OverdraftLimit = limit
+
+	if err := s.repo.Update(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+
+	return account, nil
+}
+
 // sendKafkaEvent отправляет событие в Kafka
 func (s *accountsService) sendKafkaEvent(account *entities.Account) error {
-	eventData := fmt.Sprintf(`{"account_id": %d, "user_id": %d, "status": "open"}`, account.ID, account.UserID)
-	key := []byte(fmt.Sprint(account.ID))
-	value := []byte(eventData)
+	value, err := json.Marshal(entities.AccountCreatedEvent{
+		AccountID: account.ID,
+		UserID:    account.UserID,
+		Type:      account.Type,
+		Currency:  account.Currency,
+		Status:    "open",
+	})
+	if err != nil {
+		return err
+	}
 
-	return s.producer.SendEvent(key, value)
+	return s.producer.SendEvent([]byte(fmt.Sprint(account.ID)), value)
+}
+
+// publishOverdrawn сообщает владельцу, что списание перевело остаток счёта ниже нуля.
+// Деньги к этому моменту уже списаны, поэтому ошибка отправки только логируется.
+func publishOverdrawn(producer *kafka.Producer, account *entities.Account, balanceBefore float64) {
+	if balanceBefore < 0 || account.Balance >= 0 {
+		return
+	}
+
+	value, err := json.Marshal(entities.AccountOverdrawnEvent{
+		AccountID:      account.ID,
+		UserID:         account.UserID,
+		Currency:       account.Currency,
+		Balance:        account.Balance,
+		OverdraftLimit: account.OverdraftLimit,
+	})
+	if err == nil {
+		err = producer.SendEvent([]byte(fmt.Sprint(account.ID)), value)
+	}
+	if err != nil {
+		lib.Log.Error("Failed to send account overdrawn event", zap.Uint("account_id", account.ID), zap.Error(err))
+	}
 }
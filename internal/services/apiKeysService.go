@@ -0,0 +1,195 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyPrefix = "bk"
+	// apiKeyTouchInterval ограничивает частоту записи last_used_at
+	apiKeyTouchInterval = time.Minute
+)
+
+var (
+	ErrInvalidAPIKey  = errors.New("invalid API key")
+	ErrAPIKeyNotFound = errors.New("API key not found")
+)
+
+type APIKeysService interface {
+	Create(ctx context.Context, adminID uint, req *entities.CreateAPIKeyRequest) (string, *entities.APIKey, error)
+	GetAll(ctx context.Context) ([]*entities.APIKey, error)
+	Revoke(ctx context.Context, id uint) error
+	Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error)
+}
+
+type apiKeysService struct {
+	repo      repository.APIKeysRepository
+	usersRepo repository.UsersRepository
+	audit     AuditService
+}
+
+func NewAPIKeysService(r repository.APIKeysRepository, usersRepo repository.UsersRepository, audit AuditService) APIKeysService {
+	return &apiKeysService{
+		repo:      r,
+		usersRepo: usersRepo,
+		audit:     audit,
+	}
+}
+
+// Create выпускает новый ключ. Значение ключа возвращается только здесь,
+// в базе хранится лишь его хеш.
+func (s *apiKeysService) Create(ctx context.Context, adminID uint, req *entities.CreateAPIKeyRequest) (rawKey string, key *entities.APIKey, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditAPIKeyCreate,
+			ResourceType: "api_key",
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.ResourceID = key.ID
+			event.After = key.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if _, err := s.usersRepo.FindByID(ctx, req.UserID); err != nil {
+		return "", nil, fmt.Errorf("user not found: %v", err)
+	}
+
+	for _, scope := range req.Scopes {
+		if !isKnownScope(scope) {
+			return "", nil, fmt.Errorf("unknown scope: %s", scope)
+		}
+	}
+
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		return "", nil, fmt.Errorf("expiry must be in the future")
+	}
+
+	prefix, err := randomHex(4)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not generate key: %v", err)
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not generate key: %v", err)
+	}
+	rawKey = fmt.Sprintf("%s_%s_%s", apiKeyPrefix, prefix, secret)
+
+	key = &entities.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hashToken(rawKey),
+		UserID:    req.UserID,
+		CreatedBy: adminID,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	if err := s.repo.Create(ctx, key); err != nil {
+		return "", nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	lib.Log.Info("API key issued",
+		zap.Uint("key_id", key.ID),
+		zap.String("prefix", key.Prefix),
+		zap.Uint("user_id", key.UserID),
+		zap.Uint("created_by", adminID),
+	)
+
+	return rawKey, key, nil
+}
+
+func (s *apiKeysService) GetAll(ctx context.Context) ([]*entities.APIKey, error) {
+	keys, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (s *apiKeysService) Revoke(ctx context.Context, id uint) (err error) {
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditAPIKeyRevoke,
+			ResourceType: "api_key",
+			ResourceID:   id,
+			Err:          err,
+		})
+	}()
+
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if err := s.repo.Revoke(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	lib.Log.Info("API key revoked", zap.Uint("key_id", id))
+	return nil
+}
+
+// Authenticate проверяет ключ вида bk_<prefix>_<secret> и обновляет время последнего использования
+func (s *apiKeysService) Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error) {
+	parts := strings.SplitN(rawKey, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.FindByPrefix(ctx, parts[1])
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashToken(rawKey))) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && key.ExpiresAt.Before(now)) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > apiKeyTouchInterval {
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			lib.Log.Error("Failed to update API key last use", zap.Uint("key_id", key.ID), zap.Error(err))
+		}
+		key.LastUsedAt = &now
+	}
+
+	return key, nil
+}
+
+func isKnownScope(scope entities.APIScope) bool {
+	for _, s := range entities.APIScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/lib/reqctx"
+	"bank-app-backend/internal/repository"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, event entities.AuditEvent)
+	Search(ctx context.Context, filter *entities.AuditFilter) (*entities.AuditPage, error)
+	Verify(ctx context.Context) (*entities.AuditVerification, error)
+}
+
+// auditVerifyBatch — сколько записей журнала читается за раз при проверке цепочки
+const auditVerifyBatch = 500
+
+var errAuditChainBroken = errors.New("audit chain broken")
+
+// auditPIIFields — поля снимков с персональными данными. Журнал нельзя изменить, поэтому
+// их значения хранятся только в виде HMAC: удаление данных пользователя их не затрагивает,
+// а сравнить запись с известным значением по-прежнему можно.
+var auditPIIFields = map[string]bool{"email": true, "username": true, "ip": true}
+
+type auditService struct {
+	repo   repository.AuditRepository
+	secret []byte
+}
+
+// NewAuditService создаёт журнал аудита; secret — ключ HMAC для персональных данных
+func NewAuditService(r repository.AuditRepository, secret string) AuditService {
+	return &auditService{repo: r, secret: []byte(secret)}
+}
+
+// Record пишет событие в журнал аудита, дополняя его сведениями о запросе из контекста.
+// Ошибка записи не прерывает уже выполненную операцию и только логируется.
+func (s *auditService) Record(ctx context.Context, event entities.AuditEvent) {
+	meta := reqctx.From(ctx)
+
+	entry := &entities.AuditEntry{
+		CreatedAt:    time.Now().UTC().Truncate(time.Microsecond),
+		ActorID:      meta.ActorID,
+		ActorRole:    meta.ActorRole,
+		IP:           s.hash(meta.IP).(string),
+		RequestID:    meta.RequestID,
+		Action:       event.Action,
+		ResourceType: event.ResourceType,
+		Changes:      s.pseudonymize(auditDiff(event.Before, event.After)),
+		Result:       entities.AuditSuccess,
+	}
+	if event.ActorID != 0 {
+		entry.ActorID = event.ActorID
+	}
+	if event.ResourceID != nil {
+		entry.ResourceID = fmt.Sprint(event.ResourceID)
+	}
+	if event.Err != nil {
+		entry.Result = entities.AuditFailure
+		entry.Error = event.Err.Error()
+	}
+
+	// Операция уже выполнена, поэтому запись не должна теряться из-за отмены запроса
+	if err := s.repo.Append(context.WithoutCancel(ctx), entry); err != nil {
+		lib.Log.Error("Failed to write audit entry",
+			zap.String("action", string(event.Action)),
+			zap.String("request_id", meta.RequestID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *auditService) Search(ctx context.Context, filter *entities.AuditFilter) (*entities.AuditPage, error) {
+	entries, total, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search audit log: %v", err)
+	}
+
+	return &entities.AuditPage{
+		Items: entries,
+		Total: total,
+		Page:  filter.Page,
+		Limit: filter.Limit,
+	}, nil
+}
+
+// Verify пересчитывает хеши всех записей и проверяет, что каждая ссылается на предыдущую
+func (s *auditService) Verify(ctx context.Context) (*entities.AuditVerification, error) {
+	result := &entities.AuditVerification{Valid: true}
+	prevHash := ""
+
+	err := s.repo.Walk(ctx, auditVerifyBatch, func(entries []*entities.AuditEntry) error {
+		for _, entry := range entries {
+			switch {
+			case entry.PrevHash != prevHash:
+				result.Reason = "previous hash mismatch"
+			case entry.ComputeHash() != entry.Hash:
+				result.Reason = "hash mismatch"
+			}
+
+			if result.Reason != "" {
+				id := entry.ID
+				result.Valid = false
+				result.BrokenAt = &id
+				return errAuditChainBroken
+			}
+
+			result.Checked++
+			prevHash = entry.Hash
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errAuditChainBroken) {
+		return nil, fmt.Errorf("failed to verify audit log: %v", err)
+	}
+
+	if result.Valid {
+		result.LastHash = prevHash
+	} else {
+		lib.Log.Warn("Audit log chain is broken", zap.Uint("entry_id", *result.BrokenAt), zap.String("reason", result.Reason))
+	}
+
+	return result, nil
+}
+
+// pseudonymize заменяет значения полей с персональными данными их HMAC
+func (s *auditService) pseudonymize(changes entities.AuditChanges) entities.AuditChanges {
+	for field, change := range changes {
+		if auditPIIFields[field] {
+			changes[field] = entities.AuditChange{Before: s.hash(change.Before), After: s.hash(change.After)}
+		}
+	}
+	return changes
+}
+
+// hash возвращает HMAC строкового значения без учёта регистра; пустые значения не меняются
+func (s *auditService) hash(value interface{}) interface{} {
+	str, ok := value.(string)
+	if !ok || str == "" {
+		return value
+	}
+
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(str))))
+	return "hmac:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// auditDiff возвращает только изменившиеся поля снимков before и after.
+// Снимки приводятся к JSON, поэтому в журнал попадают только поля, видимые в API.
+func auditDiff(before, after interface{}) entities.AuditChanges {
+	b := auditFields(before)
+	a := auditFields(after)
+
+	changes := entities.AuditChanges{}
+	for field, value := range b {
+		if !reflect.DeepEqual(value, a[field]) {
+			changes[field] = entities.AuditChange{Before: value, After: a[field]}
+		}
+	}
+	for field, value := range a {
+		if _, ok := b[field]; !ok && value != nil {
+			changes[field] = entities.AuditChange{After: value}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+// auditFields раскладывает снимок на поля верхнего уровня; nil-указатель даёт пустой набор
+func auditFields(snapshot interface{}) map[string]interface{} {
+	if snapshot == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		var value interface{}
+		_ = json.Unmarshal(data, &value)
+		return map[string]interface{}{"value": value}
+	}
+	return fields
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	lib.Log = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+type fakeAuditRepository struct {
+	repository.AuditRepository
+	entries []*entities.AuditEntry
+}
+
+func (r *fakeAuditRepository) Walk(ctx context.Context, batchSize int, fn func(entries []*entities.AuditEntry) error) error {
+	for start := 0; start < len(r.entries); start += batchSize {
+		end := min(start+batchSize, len(r.entries))
+		if err := fn(r.entries[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditChain строит цепочку из n записей с корректными хешами
+func auditChain(n int) []*entities.AuditEntry {
+	entries := make([]*entities.AuditEntry, 0, n)
+	prevHash := ""
+	for i := 1; i <= n; i++ {
+		entry := &entities.AuditEntry{
+			ID:           uint(i),
+			CreatedAt:    time.Date(2025, time.March, 1, 12, i, 0, 0, time.UTC),
+			ActorID:      1,
+			Action:       entities.AuditAccountCreate,
+			ResourceType: "account",
+			ResourceID:   fmt.Sprint(i),
+			Result:       entities.AuditSuccess,
+			PrevHash:     prevHash,
+		}
+		entry.Hash = entry.ComputeHash()
+		prevHash = entry.Hash
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditServiceVerify(t *testing.T) {
+	tests := []struct {
+		name         string
+		tamper       func(entries []*entities.AuditEntry) []*entities.AuditEntry
+		wantValid    bool
+		wantChecked  int64
+		wantBrokenAt uint
+		wantReason   string
+	}{
+		{
+			name:        "intact chain",
+			tamper:      func(entries []*entities.AuditEntry) []*entities.AuditEntry { return entries },
+			wantValid:   true,
+			wantChecked: 5,
+		},
+		{
+			name:        "empty log",
+			tamper:      func([]*entities.AuditEntry) []*entities.AuditEntry { return nil },
+			wantValid:   true,
+			wantChecked: 0,
+		},
+		{
+			name: "modified entry",
+			tamper: func(entries []*entities.AuditEntry) []*entities.AuditEntry {
+				entries[2].Result = entities.AuditFailure
+				return entries
+			},
+			wantChecked:  2,
+			wantBrokenAt: 3,
+			wantReason:   "hash mismatch",
+		},
+		{
+			name: "deleted entry",
+			tamper: func(entries []*entities.AuditEntry) []*entities.AuditEntry {
+				return append(entries[:1], entries[2:]...)
+			},
+			wantChecked:  1,
+			wantBrokenAt: 3,
+			wantReason:   "previous hash mismatch",
+		},
+		{
+			name: "rehashed entry",
+			tamper: func(entries []*entities.AuditEntry) []*entities.AuditEntry {
+				entries[1].ActorID = 2
+				entries[1].Hash = entries[1].ComputeHash()
+				return entries
+			},
+			wantChecked:  2,
+			wantBrokenAt: 3,
+			wantReason:   "previous hash mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := tt.tamper(auditChain(5))
+			s := &auditService{repo: &fakeAuditRepository{entries: entries}}
+
+			result, err := s.Verify(context.Background())
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if result.Valid != tt.wantValid || result.Checked != tt.wantChecked || result.Reason != tt.wantReason {
+				t.Fatalf("got %+v, want valid %v, checked %d, reason %q", result, tt.wantValid, tt.wantChecked, tt.wantReason)
+			}
+
+			if tt.wantValid {
+				wantLast := ""
+				if len(entries) > 0 {
+					wantLast = entries[len(entries)-1].Hash
+				}
+				if result.BrokenAt != nil || result.LastHash != wantLast {
+					t.Errorf("valid chain reported broken at %v with last hash %q", result.BrokenAt, result.LastHash)
+				}
+				return
+			}
+			if result.BrokenAt == nil || *result.BrokenAt != tt.wantBrokenAt {
+				t.Errorf("broken at %v, want %d", result.BrokenAt, tt.wantBrokenAt)
+			}
+		})
+	}
+}
@@ -2,47 +2,97 @@ package services
 
 import (
 	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/lib/password"
 	redis "bank-app-backend/internal/lib/redis"
 	"bank-app-backend/internal/lib/token"
 	"bank-app-backend/internal/repository"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
+	"time"
 )
 
 type AuthService interface {
 	RegisterUser(ctx context.Context, req entities.RegisterRequest) (*entities.User, error)
-	Login(ctx context.Context, req entities.LoginRequest) (*entities.AuthResponse, error)
-	Logout(ctx context.Context, userID uint) error
-	RefreshToken(ctx context.Context, refreshToken string) (*entities.AuthResponse, error)
+	Login(ctx context.Context, req entities.LoginRequest, client entities.ClientInfo) (*entities.AuthResponse, error)
+	Logout(ctx context.Context, userID uint, sessionID string) error
+	RefreshToken(ctx context.Context, refreshToken string, client entities.ClientInfo) (*entities.AuthResponse, error)
+	ChangePassword(ctx context.Context, userID uint, req entities.ChangePasswordRequest) error
 }
 
+var (
+	ErrInvalidCredentials     = errors.New("invalid email or password")
+	ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+	ErrPasswordReused         = errors.New("new password must differ from the current one")
+)
+
+// dummyPasswordHash используется для сравнения, когда пользователь не найден,
+// чтобы время ответа не выдавало существование email
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
 type authService struct {
-	repo  repository.UsersRepository
-	redis *redis.Client
+	repo       repository.UsersRepository
+	sessions   repository.SessionsRepository
+	redis      *redis.Client
+	policy     password.Policy
+	protection LoginProtectionService
+	audit      AuditService
 }
 
-func NewAuthService(r repository.UsersRepository, redisClient *redis.Client) AuthService {
+func NewAuthService(
+	r repository.UsersRepository,
+	sessions repository.SessionsRepository,
+	redisClient *redis.Client,
+	policy password.Policy,
+	protection LoginProtectionService,
+	audit AuditService,
+) AuthService {
 	return &authService{
-		repo:  r,
-		redis: redisClient,
+		repo:       r,
+		sessions:   sessions,
+		redis:      redisClient,
+		policy:     policy,
+		protection: protection,
+		audit:      audit,
 	}
 }
 
-func (s *authService) RegisterUser(ctx context.Context, req entities.RegisterRequest) (*entities.User, error) {
+func (s *authService) RegisterUser(ctx context.Context, req entities.RegisterRequest) (user *entities.User, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditRegister,
+			ResourceType: "user",
+			After:        map[string]string{"email": req.Email, "username": req.Username},
+			Err:          err,
+		}
+		if user != nil {
+			event.ActorID = user.ID
+			event.ResourceID = user.ID
+			event.After = user.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if err := s.policy.Validate(req.Password); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, fmt.Errorf("could not hash password: %v", err)
 	}
 
-	user := &entities.User{
+	user = &entities.User{
 		Email:    req.Email,
 		Username: req.Username,
 		Password: string(hashedPassword),
+		Role:     entities.RoleCustomer,
 	}
 
-	fmt.Println("Password from DB:", user.Password)
 	if err := s.repo.CreateUser(ctx, user); err != nil {
 		return nil, fmt.Errorf("could not create user: %v", err)
 	}
@@ -50,25 +100,76 @@ func (s *authService) RegisterUser(ctx context.Context, req entities.RegisterReq
 	return user, nil
 }
 
-func (s *authService) Login(ctx context.Context, req entities.LoginRequest) (*entities.AuthResponse, error) {
-	user, err := s.repo.FindByEmail(ctx, req.Email)
+func (s *authService) Login(ctx context.Context, req entities.LoginRequest, client entities.ClientInfo) (resp *entities.AuthResponse, err error) {
+	var user *entities.User
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditLogin,
+			ResourceType: "user",
+			After:        map[string]string{"email": req.Email},
+			Err:          err,
+		}
+		if user != nil {
+			event.ResourceID = user.ID
+			if err == nil {
+				event.ActorID = user.ID
+			}
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if err := s.protection.Check(ctx, req.Email, client.IP); err != nil {
+		return nil, err
+	}
+
+	user, err = s.repo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("could not find user: %v", err)
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(req.Password))
+		return nil, s.loginFailed(ctx, req.Email, client.IP)
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		return nil, fmt.Errorf("invalid pasword: %v", err)
+		return nil, s.loginFailed(ctx, req.Email, client.IP)
+	}
+
+	if err := s.protection.RegisterSuccess(ctx, req.Email); err != nil {
+		return nil, err
 	}
 
-	accessToken, refreshToken, err := lib.GenerateTokens(user)
+	if user.IsBlocked() {
+		return nil, ErrUserBlocked
+	}
+
+	sessionID, err := newSessionID()
 	if err != nil {
-		return nil, fmt.Errorf("could not generate tokens: %v", err)
+		return nil, fmt.Errorf("could not create session: %v", err)
 	}
 
-	err = s.repo.SaveRefreshToken(ctx, user.ID, refreshToken)
+	now := time.Now()
+	session := &entities.Session{
+		ID:         sessionID,
+		UserID:     user.ID,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+
+	return s.issueTokens(ctx, user, session)
+}
+
+// issueTokens выпускает новую пару токенов для сессии и сохраняет
+// хеш refresh-токена, так что предыдущий токен сессии перестаёт действовать
+func (s *authService) issueTokens(ctx context.Context, user *entities.User, session *entities.Session) (*entities.AuthResponse, error) {
+	accessToken, refreshToken, err := lib.GenerateTokens(user, session.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not save refresh token: %v", err)
+		return nil, fmt.Errorf("could not generate tokens: %v", err)
+	}
+
+	session.RefreshTokenHash = hashToken(refreshToken)
+	if err := s.sessions.Save(ctx, session, lib.RefreshTokenTTL); err != nil {
+		return nil, fmt.Errorf("could not save session: %v", err)
 	}
 
 	return &entities.AuthResponse{
@@ -77,11 +178,21 @@ func (s *authService) Login(ctx context.Context, req entities.LoginRequest) (*en
 	}, nil
 }
 
-func (s *authService) Logout(ctx context.Context, userID uint) error {
-	return s.repo.DeleteRefreshToken(ctx, userID)
+// loginFailed фиксирует неудачную попытку и возвращает ошибку,
+// не раскрывающую, существует ли пользователь
+func (s *authService) loginFailed(ctx context.Context, email, ip string) error {
+	if err := s.protection.RegisterFailure(ctx, email, ip); err != nil {
+		return err
+	}
+	return ErrInvalidCredentials
 }
 
-func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*entities.AuthResponse, error) {
+// Logout завершает сессию, к которой относится токен; остальные устройства остаются в системе
+func (s *authService) Logout(ctx context.Context, userID uint, sessionID string) error {
+	return s.sessions.Delete(ctx, userID, sessionID)
+}
+
+func (s *authService) RefreshToken(ctx context.Context, refreshToken string, client entities.ClientInfo) (*entities.AuthResponse, error) {
 	token, err := jwt.Parse(refreshToken, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
@@ -104,22 +215,82 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*e
 	}
 	userID := uint(userIDFloat)
 
+	sessionID, ok := claims["sid"].(string)
+	if !ok || sessionID == "" {
+		return nil, fmt.Errorf("invalid session in token")
+	}
+
+	session, err := s.sessions.Get(ctx, sessionID)
+	if err != nil || session.UserID != userID || session.RefreshTokenHash != hashToken(refreshToken) {
+		return nil, fmt.Errorf("refresh token has been revoked")
+	}
+
 	user, err := s.repo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %v", err)
 	}
 
-	accessToken, newRefreshToken, err := lib.GenerateTokens(user)
+	if user.IsBlocked() {
+		return nil, ErrUserBlocked
+	}
+
+	session.IP = client.IP
+	session.UserAgent = client.UserAgent
+	session.LastUsedAt = time.Now()
+
+	return s.issueTokens(ctx, user, session)
+}
+
+// ChangePassword меняет пароль пользователя после проверки текущего
+// и отзывает все его активные сессии
+func (s *authService) ChangePassword(ctx context.Context, userID uint, req entities.ChangePasswordRequest) (err error) {
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditPasswordChange,
+			ResourceType: "user",
+			ResourceID:   userID,
+			Err:          err,
+		})
+	}()
+
+	user, err := s.repo.FindByIDWithPassword(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("could not generate tokens: %v", err)
+		return fmt.Errorf("user not found: %v", err)
 	}
 
-	if err := s.repo.SaveRefreshToken(ctx, user.ID, newRefreshToken); err != nil {
-		return nil, fmt.Errorf("failed to save refresh token: %v", err)
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return ErrInvalidCurrentPassword
 	}
 
-	return &entities.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: newRefreshToken,
-	}, nil
+	if req.CurrentPassword == req.NewPassword {
+		return ErrPasswordReused
+	}
+
+	if err := s.policy.Validate(req.NewPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("could not hash password: %v", err)
+	}
+
+	if err := s.repo.UpdatePassword(ctx, userID, string(hashedPassword)); err != nil {
+		return fmt.Errorf("could not update password: %v", err)
+	}
+
+	if err := s.sessions.DeleteAllByUser(ctx, userID); err != nil {
+		return fmt.Errorf("could not revoke sessions: %v", err)
+	}
+
+	return nil
+}
+
+func newSessionID() (string, error) {
+	return randomHex(16)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"bank-app-backend/internal/config"
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+type BalancesService interface {
+	Run(ctx context.Context)
+	SnapshotDay(ctx context.Context, businessDate time.Time) (*entities.BalanceSnapshotResult, error)
+	BalanceAt(ctx context.Context, userID, accountID uint, at time.Time) (*entities.AccountBalanceAt, error)
+}
+
+var ErrBalanceAtFuture = errors.New("balance time is in the future")
+
+type balancesService struct {
+	repo     repository.BalancesRepository
+	members  AccountMembersService
+	interval time.Duration
+	location *time.Location
+	runs     repository.JobRunsRepository
+	audit    AuditService
+}
+
+func NewBalancesService(
+	r repository.BalancesRepository,
+	members AccountMembersService,
+	jobs config.JobsConfig,
+	runs repository.JobRunsRepository,
+	audit AuditService,
+) BalancesService {
+	return &balancesService{
+		repo:     r,
+		members:  members,
+		interval: jobs.Interval,
+		location: loadLocation(jobs.Timezone),
+		runs:     runs,
+		audit:    audit,
+	}
+}
+
+// Run сохраняет остатки счетов на конец каждого завершившегося операционного дня
+func (s *balancesService) Run(ctx context.Context) {
+	runDaily(ctx, "balance_snapshots", s.interval, s.location, s.runs, func(ctx context.Context, date time.Time) error {
+		_, err := s.SnapshotDay(ctx, date)
+		return err
+	})
+}
+
+// SnapshotDay сохраняет остатки всех счетов на конец операционного дня businessDate.
+// Нулевая дата означает предыдущий день; день должен быть завершён.
+func (s *balancesService) SnapshotDay(ctx context.Context, businessDate time.Time) (result *entities.BalanceSnapshotResult, err error) {
+	if businessDate.IsZero() {
+		businessDate = previousBusinessDate(time.Now(), s.location)
+	}
+	date := time.Date(businessDate.Year(), businessDate.Month(), businessDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditBalanceSnapshot,
+			ResourceType: "balance_snapshot",
+			ResourceID:   date.Format(entities.BusinessDateLayout),
+			After:        result,
+			Err:          err,
+		})
+	}()
+
+	if date.After(previousBusinessDate(time.Now(), s.location)) {
+		return nil, ErrBusinessDateNotOver
+	}
+
+	closedAt := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, s.location)
+	count, err := s.repo.Snapshot(ctx, date, closedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save balance snapshots: %w", err)
+	}
+
+	lib.Log.Info("Balance snapshots saved",
+		zap.String("business_date", date.Format(entities.BusinessDateLayout)),
+		zap.Int64("accounts", count),
+	)
+	return &entities.BalanceSnapshotResult{
+		BusinessDate: date.Format(entities.BusinessDateLayout),
+		Accounts:     count,
+	}, nil
+}
+
+// BalanceAt возвращает остаток счёта на момент at: последний снимок до at плюс транзакции
+// после него. Если снимков ещё нет, остаток считается по всем транзакциям счёта.
+func (s *balancesService) BalanceAt(ctx context.Context, userID, accountID uint, at time.Time) (*entities.AccountBalanceAt, error) {
+	account, _, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionView)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if at.IsZero() {
+		at = now
+	}
+	if at.After(now) {
+		return nil, ErrBalanceAtFuture
+	}
+
+	result := &entities.AccountBalanceAt{AccountID: accountID, At: at, Currency: account.Currency}
+
+	var from time.Time
+	snapshot, err := s.repo.FindLatest(ctx, accountID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance snapshot: %w", err)
+	}
+	if snapshot != nil {
+		from = snapshot.ClosedAt
+		result.Balance = snapshot.Balance
+		result.SnapshotDate = snapshot.BusinessDate.Format(entities.BusinessDateLayout)
+	}
+
+	change, err := s.repo.NetChange(ctx, accountID, from, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	result.Balance += change
+
+	return result, nil
+}
@@ -0,0 +1,502 @@
+package services
+
+import (
+	"bank-app-backend/internal/config"
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"math/big"
+	"strings"
+	"time"
+)
+
+type CardsService interface {
+	Issue(ctx context.Context, userID, accountID uint, req *entities.IssueCardRequest) (*entities.IssuedCardResponse, error)
+	List(ctx context.Context, userID, accountID uint) ([]*entities.Card, error)
+	Get(ctx context.Context, userID, cardID uint) (*entities.Card, error)
+	Freeze(ctx context.Context, userID, cardID uint) (*entities.Card, error)
+	Unfreeze(ctx context.Context, userID, cardID uint) (*entities.Card, error)
+	Cancel(ctx context.Context, userID, cardID uint) (*entities.Card, error)
+	SetLimits(ctx context.Context, userID, cardID uint, req *entities.CardLimitsRequest) (*entities.Card, error)
+	Authorize(ctx context.Context, req *entities.CardAuthorizationRequest) (*entities.CardAuthorizationResponse, error)
+}
+
+var (
+	ErrCardNotFound            = errors.New("card not found")
+	ErrInvalidCardStatusChange = errors.New("card status does not allow the operation")
+	ErrInvalidCardLimits       = errors.New("daily card limit is below the per-transaction limit")
+	ErrCardLimitExceeded       = errors.New("card daily limit exceeded")
+)
+
+// panLength — длина номера карты вместе с контрольной цифрой
+const panLength = 16
+
+type cardsService struct {
+	repo     repository.CardsRepository
+	attempts repository.CardAttemptsRepository
+	accRepo  repository.AccountsRepository
+	members  AccountMembersService
+	holds    HoldsService
+	cfg      config.CardsConfig
+	location *time.Location
+	audit    AuditService
+}
+
+// NewCardsService создаёт сервис виртуальных карт. Дневной лимит карты считается по
+// календарным дням в часовом поясе timezone.
+func NewCardsService(
+	r repository.CardsRepository,
+	attempts repository.CardAttemptsRepository,
+	accRepo repository.AccountsRepository,
+	members AccountMembersService,
+	holds HoldsService,
+	cfg config.CardsConfig,
+	timezone string,
+	audit AuditService,
+) CardsService {
+	return &cardsService{
+		repo:     r,
+		attempts: attempts,
+		accRepo:  accRepo,
+		members:  members,
+		holds:    holds,
+		cfg:      cfg,
+		location: loadLocation(timezone),
+		audit:    audit,
+	}
+}
+
+// Issue выпускает виртуальную карту к счёту на пользователя. Полный номер и CVV
+// возвращаются только в ответе на выпуск. Карта участника с ролью spender не может
+// тратить за одну операцию больше его лимита: без лимита на операцию он и ставится.
+func (s *cardsService) Issue(ctx context.Context, userID, accountID uint, req *entities.IssueCardRequest) (issued *entities.IssuedCardResponse, err error) {
+	var card *entities.Card
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditCardIssue,
+			ResourceType: "card",
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.ResourceID = card.ID
+			event.After = card.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	account, member, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionSpend)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDebit(account); err != nil {
+		return nil, err
+	}
+	perTransaction, err := spenderCardLimit(member, req.LimitPerTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCardLimits(perTransaction, req.LimitDaily); err != nil {
+		return nil, err
+	}
+
+	pan, err := s.generatePAN()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate card number: %w", err)
+	}
+	token, err := randomHex(8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate card token: %w", err)
+	}
+
+	expiry := time.Now().In(s.location).AddDate(s.cfg.ValidityYears, 0, 0)
+	cvv := s.cardCVV(pan, int(expiry.Month()), expiry.Year())
+	card = &entities.Card{
+		AccountID:           accountID,
+		UserID:              userID,
+		Token:               "card_" + token,
+		PANHash:             s.hashPAN(pan),
+		Last4:               pan[len(pan)-4:],
+		ExpiryMonth:         int(expiry.Month()),
+		ExpiryYear:          expiry.Year(),
+		Name:                req.Name,
+		Status:              entities.CardActive,
+		LimitPerTransaction: perTransaction,
+		LimitDaily:          req.LimitDaily,
+	}
+
+	if err := s.repo.Create(ctx, card); err != nil {
+		return nil, fmt.Errorf("failed to create card: %w", err)
+	}
+
+	return &entities.IssuedCardResponse{PAN: pan, CVV: cvv, Card: card.ToResponse()}, nil
+}
+
+func (s *cardsService) List(ctx context.Context, userID, accountID uint) ([]*entities.Card, error) {
+	if _, _, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionView); err != nil {
+		return nil, err
+	}
+
+	cards, err := s.repo.FindByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+	return cards, nil
+}
+
+func (s *cardsService) Get(ctx context.Context, userID, cardID uint) (*entities.Card, error) {
+	return s.authorizedCard(ctx, userID, cardID, false)
+}
+
+func (s *cardsService) Freeze(ctx context.Context, userID, cardID uint) (*entities.Card, error) {
+	return s.changeStatus(ctx, userID, cardID, entities.CardFrozen, entities.CardActive)
+}
+
+func (s *cardsService) Unfreeze(ctx context.Context, userID, cardID uint) (*entities.Card, error) {
+	return s.changeStatus(ctx, userID, cardID, entities.CardActive, entities.CardFrozen)
+}
+
+// Cancel закрывает карту навсегда; уже авторизованные по ней холды остаются в силе
+func (s *cardsService) Cancel(ctx context.Context, userID, cardID uint) (*entities.Card, error) {
+	return s.changeStatus(ctx, userID, cardID, entities.CardCancelled, entities.CardActive, entities.CardFrozen)
+}
+
+func (s *cardsService) SetLimits(ctx context.Context, userID, cardID uint, req *entities.CardLimitsRequest) (card *entities.Card, err error) {
+	var before *entities.CardResponse
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditCardLimits,
+			ResourceType: "card",
+			ResourceID:   cardID,
+			Before:       before,
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.After = card.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	card, err = s.authorizedCard(ctx, userID, cardID, true)
+	if err != nil {
+		return nil, err
+	}
+	before = card.ToResponse()
+
+	if card.Status == entities.CardCancelled {
+		return nil, ErrInvalidCardStatusChange
+	}
+	// Лимит spender ограничивает карту держателя, кто бы ни менял её лимиты
+	_, holder, err := s.members.Authorize(ctx, card.UserID, card.AccountID, entities.PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	perTransaction, err := spenderCardLimit(holder, req.LimitPerTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCardLimits(perTransaction, req.LimitDaily); err != nil {
+		return nil, err
+	}
+
+	card.LimitPerTransaction = perTransaction
+	card.LimitDaily = req.LimitDaily
+
+	if err := s.repo.Update(ctx, card); err != nil {
+		return nil, fmt.Errorf("failed to update card: %w", err)
+	}
+	return card, nil
+}
+
+// Authorize принимает решение по оплате картой от платёжной сети. Одобренная оплата
+// резервирует сумму на счёте холдом, поэтому проходит те же проверки, что и холд:
+// доступный остаток, статус счёта и лимиты списаний. Отказ — не ошибка: причина
+// возвращается в ответе.
+func (s *cardsService) Authorize(ctx context.Context, req *entities.CardAuthorizationRequest) (result *entities.CardAuthorizationResponse, err error) {
+	var card *entities.Card
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditCardAuthorize,
+			ResourceType: "card",
+			After:        result,
+			Err:          err,
+		}
+		if card != nil {
+			event.ResourceID = card.ID
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	card, err = s.repo.FindByPANHash(ctx, s.hashPAN(req.PAN))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return decline(entities.DeclineInvalidCard), nil
+		}
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+
+	if card.Status == entities.CardCancelled ||
+		card.ExpiryMonth != req.ExpiryMonth || card.ExpiryYear != req.ExpiryYear {
+		return decline(entities.DeclineInvalidCard), nil
+	}
+	valid, err := s.checkCVV(ctx, card, req.PAN, req.CVV)
+	if errors.Is(err, errCVVLocked) {
+		return decline(entities.DeclineCVVLocked), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return decline(entities.DeclineInvalidCVV), nil
+	}
+
+	now := time.Now()
+	if card.Expired(now.In(s.location)) {
+		return decline(entities.DeclineExpiredCard), nil
+	}
+	if card.Status == entities.CardFrozen {
+		return decline(entities.DeclineCardFrozen), nil
+	}
+
+	account, err := s.accRepo.FindByID(ctx, card.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if !strings.EqualFold(account.Currency, req.Currency) {
+		return decline(entities.DeclineCurrencyMismatch), nil
+	}
+
+	if card.LimitPerTransaction > 0 && req.Amount > card.LimitPerTransaction {
+		return decline(entities.DeclineCardLimit), nil
+	}
+
+	// Дневной лимит карты проверяется при создании холда под блокировкой счёта карты,
+	// там же — лимит держателя с ролью spender, даже если его уменьшили после выпуска
+	local := now.In(s.location)
+	hold, err := s.holds.Create(ctx, card.UserID, card.AccountID, &entities.CreateHoldRequest{
+		Amount:         req.Amount,
+		Description:    req.Merchant,
+		CardID:         &card.ID,
+		CardDailyLimit: card.LimitDaily,
+		CardDayStart:   time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.location),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrCardLimitExceeded):
+			return decline(entities.DeclineCardLimit), nil
+		case errors.Is(err, ErrInsufficientFunds):
+			return decline(entities.DeclineInsufficientFunds), nil
+		case errors.Is(err, ErrAccountInactive):
+			return decline(entities.DeclineAccountInactive), nil
+		case errors.Is(err, ErrInvalidAmountPrecision):
+			return decline(entities.DeclineInvalidAmount), nil
+		case errors.Is(err, ErrLimitExceeded), errors.Is(err, ErrSpendLimitExceeded):
+			return decline(entities.DeclineLimitExceeded), nil
+		case errors.Is(err, ErrAccountNotFound), errors.Is(err, ErrAccountForbidden):
+			// Держатель карты больше не может распоряжаться счётом
+			return decline(entities.DeclineInvalidCard), nil
+		}
+		return nil, err
+	}
+
+	return &entities.CardAuthorizationResponse{Approved: true, HoldID: &hold.ID}, nil
+}
+
+// errCVVLocked — авторизации по карте отклоняются после слишком многих неверных CVV
+var errCVVLocked = errors.New("too many invalid CVV attempts")
+
+// checkCVV сверяет CVV и считает ошибки, как вход по паролю: после cfg.MaxCVVAttempts
+// неверных CVV авторизации по карте отклоняются на cfg.CVVLockout даже с верным CVV
+func (s *cardsService) checkCVV(ctx context.Context, card *entities.Card, pan, cvv string) (bool, error) {
+	expected := s.cardCVV(pan, card.ExpiryMonth, card.ExpiryYear)
+	valid := subtle.ConstantTimeCompare([]byte(expected), []byte(cvv)) == 1
+	if s.cfg.MaxCVVAttempts <= 0 {
+		return valid, nil
+	}
+
+	locked, err := s.attempts.CVVLocked(ctx, card.ID)
+	if err != nil {
+		return false, fmt.Errorf("could not check CVV lock: %w", err)
+	}
+	if locked {
+		return false, errCVVLocked
+	}
+
+	if valid {
+		if err := s.attempts.ResetCVVFailures(ctx, card.ID); err != nil {
+			lib.Log.Warn("Failed to reset CVV attempts", zap.Uint("card_id", card.ID), zap.Error(err))
+		}
+		return true, nil
+	}
+
+	count, err := s.attempts.IncrementCVVFailures(ctx, card.ID, s.cfg.CVVAttemptWindow)
+	if err != nil {
+		return false, fmt.Errorf("could not register invalid CVV: %w", err)
+	}
+	if count >= int64(s.cfg.MaxCVVAttempts) {
+		if err := s.attempts.LockCVV(ctx, card.ID, s.cfg.CVVLockout); err != nil {
+			return false, fmt.Errorf("could not lock card after invalid CVV: %w", err)
+		}
+		if err := s.attempts.ResetCVVFailures(ctx, card.ID); err != nil {
+			lib.Log.Warn("Failed to reset CVV attempts", zap.Uint("card_id", card.ID), zap.Error(err))
+		}
+	}
+	return false, nil
+}
+
+// changeStatus переводит карту в статус to, если её текущий статус — один из from
+func (s *cardsService) changeStatus(ctx context.Context, userID, cardID uint, to entities.CardStatus, from ...entities.CardStatus) (card *entities.Card, err error) {
+	var before *entities.CardResponse
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditCardStatus,
+			ResourceType: "card",
+			ResourceID:   cardID,
+			Before:       before,
+			After:        map[string]entities.CardStatus{"status": to},
+			Err:          err,
+		}
+		if err == nil {
+			event.After = card.ToResponse()
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	card, err = s.authorizedCard(ctx, userID, cardID, true)
+	if err != nil {
+		return nil, err
+	}
+	before = card.ToResponse()
+
+	allowed := false
+	for _, status := range from {
+		if card.Status == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: card is %s", ErrInvalidCardStatusChange, card.Status)
+	}
+
+	card.Status = to
+	if err := s.repo.Update(ctx, card); err != nil {
+		return nil, fmt.Errorf("failed to update card: %w", err)
+	}
+	return card, nil
+}
+
+// authorizedCard возвращает карту, если пользователь — участник её счёта. Управлять картой
+// (manage) могут держатель карты и участники с правом управления счётом.
+func (s *cardsService) authorizedCard(ctx context.Context, userID, cardID uint, manage bool) (*entities.Card, error) {
+	card, err := s.repo.FindByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+
+	_, member, err := s.members.Authorize(ctx, userID, card.AccountID, entities.PermissionView)
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	if manage && card.UserID != userID && !member.Role.Can(entities.PermissionManage) {
+		return nil, ErrAccountForbidden
+	}
+	return card, nil
+}
+
+// generatePAN возвращает номер карты из BIN, случайных цифр и контрольной цифры по Луну
+func (s *cardsService) generatePAN() (string, error) {
+	body, err := randomDigits(panLength - len(s.cfg.BIN) - 1)
+	if err != nil {
+		return "", err
+	}
+	pan := s.cfg.BIN + body
+	return pan + luhnCheckDigit(pan), nil
+}
+
+// hashPAN возвращает HMAC-SHA256 номера карты: без ключа номер по хешу не подобрать
+func (s *cardsService) hashPAN(pan string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Secret))
+	mac.Write([]byte(pan))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// cardCVV вычисляет CVV из номера и срока действия карты ключом cfg.Secret. CVV не
+// хранится: трёхзначный код из хеша подбирается перебором за секунды, а без ключа его
+// не получить.
+func (s *cardsService) cardCVV(pan string, expiryMonth, expiryYear int) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Secret))
+	fmt.Fprintf(mac, "cvv:%s:%02d%04d", pan, expiryMonth, expiryYear)
+	return fmt.Sprintf("%03d", binary.BigEndian.Uint64(mac.Sum(nil))%1000)
+}
+
+// spenderCardLimit возвращает лимит на операцию для карты участника: у роли spender он
+// не больше его лимита, а без лимита равен ему
+func spenderCardLimit(member *entities.AccountMember, perTransaction float64) (float64, error) {
+	if member.Role != entities.AccountRoleSpender {
+		return perTransaction, nil
+	}
+	if perTransaction == 0 {
+		return member.SpendLimit, nil
+	}
+	if err := checkSpendLimit(member, perTransaction); err != nil {
+		return 0, err
+	}
+	return perTransaction, nil
+}
+
+func checkCardLimits(perTransaction, daily float64) error {
+	if perTransaction > 0 && daily > 0 && daily < perTransaction {
+		return ErrInvalidCardLimits
+	}
+	return nil
+}
+
+func decline(reason entities.CardDeclineReason) *entities.CardAuthorizationResponse {
+	return &entities.CardAuthorizationResponse{DeclineReason: reason}
+}
+
+func randomDigits(n int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + digit.Int64()))
+	}
+	return b.String(), nil
+}
+
+// luhnCheckDigit возвращает контрольную цифру для номера без неё
+func luhnCheckDigit(number string) string {
+	sum := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if (len(number)-1-i)%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return string(rune('0' + (10-sum%10)%10))
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"math"
+	"strings"
+)
+
+type CurrenciesService interface {
+	List() []entities.Currency
+	Validate(code string) (*entities.Currency, error)
+	CheckAmount(code string, amount float64) error
+}
+
+var (
+	ErrUnsupportedCurrency    = errors.New("unsupported currency")
+	ErrInvalidAmountPrecision = errors.New("amount has more decimal places than the currency allows")
+)
+
+type currenciesService struct {
+	currencies []entities.Currency
+	byCode     map[string]*entities.Currency
+}
+
+// NewCurrenciesService создаёт реестр валют ISO 4217; enabled — коды валют, в которых
+// можно открывать счета. Неизвестные коды пропускаются с предупреждением.
+func NewCurrenciesService(enabled []string) CurrenciesService {
+	s := &currenciesService{
+		currencies: make([]entities.Currency, len(entities.Currencies)),
+		byCode:     make(map[string]*entities.Currency, len(entities.Currencies)),
+	}
+	copy(s.currencies, entities.Currencies)
+	for i := range s.currencies {
+		s.byCode[s.currencies[i].Code] = &s.currencies[i]
+	}
+
+	for _, code := range enabled {
+		currency, ok := s.byCode[strings.ToUpper(strings.TrimSpace(code))]
+		if !ok {
+			lib.Log.Warn("Unknown currency in config, skipping", zap.String("currency", code))
+			continue
+		}
+		currency.Enabled = true
+	}
+	return s
+}
+
+// List возвращает валюты, в которых можно открывать счета
+func (s *currenciesService) List() []entities.Currency {
+	enabled := make([]entities.Currency, 0, len(s.currencies))
+	for _, currency := range s.currencies {
+		if currency.Enabled {
+			enabled = append(enabled, currency)
+		}
+	}
+	return enabled
+}
+
+// Validate возвращает валюту по коду, если в ней можно открыть счёт
+func (s *currenciesService) Validate(code string) (*entities.Currency, error) {
+	currency, ok := s.byCode[strings.ToUpper(code)]
+	if !ok || !currency.Enabled {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, code)
+	}
+	return currency, nil
+}
+
+// CheckAmount проверяет, что у суммы не больше знаков после запятой, чем допускает валюта.
+// Проверка не зависит от того, включена ли валюта: отключение валюты не должно мешать
+// операциям по уже открытым в ней счетам.
+func (s *currenciesService) CheckAmount(code string, amount float64) error {
+	currency, ok := s.byCode[strings.ToUpper(code)]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedCurrency, code)
+	}
+
+	scaled := amount * math.Pow10(currency.MinorUnits)
+	if math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+		return fmt.Errorf("%w: %s allows %d", ErrInvalidAmountPrecision, currency.Code, currency.MinorUnits)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+type FeesService interface {
+	Quote(ctx context.Context, account *entities.Account, transferType entities.TransferType, amount float64) (*entities.FeeQuote, error)
+	Preview(ctx context.Context, userID uint, req *entities.FeePreviewRequest) (*entities.FeeQuote, error)
+	Charge(account *entities.Account, quote *entities.FeeQuote) func(parent *entities.Transaction) *entities.Transaction
+	CheckAccounts(ctx context.Context) error
+	Rules(ctx context.Context) ([]*entities.FeeRule, error)
+	SetRule(ctx context.Context, req *entities.SetFeeRuleRequest) (*entities.FeeRule, error)
+	DeleteRule(ctx context.Context, id uint) error
+}
+
+var (
+	ErrFeeRuleNotFound   = errors.New("fee rule not found")
+	ErrFeeAccountMissing = errors.New("no fee account for the currency")
+	ErrInvalidFeeAccount = errors.New("invalid fee account")
+)
+
+type feesService struct {
+	repo     repository.FeesRepository
+	accRepo  repository.AccountsRepository
+	members  AccountMembersService
+	accounts map[string]uint
+	audit    AuditService
+}
+
+// NewFeesService создаёт сервис комиссий. accounts — счета банка для зачисления
+// комиссий по валютам.
+func NewFeesService(
+	r repository.FeesRepository,
+	accRepo repository.AccountsRepository,
+	members AccountMembersService,
+	accounts map[string]uint,
+	audit AuditService,
+) FeesService {
+	feeAccounts := make(map[string]uint, len(accounts))
+	for currency, id := range accounts {
+		feeAccounts[strings.ToUpper(currency)] = id
+	}
+
+	return &feesService{
+		repo:     r,
+		accRepo:  accRepo,
+		members:  members,
+		accounts: feeAccounts,
+		audit:    audit,
+	}
+}
+
+// Quote рассчитывает комиссию за операцию со счёта по самому частному подходящему правилу.
+// Если правил нет, комиссия нулевая.
+func (s *feesService) Quote(ctx context.Context, account *entities.Account, transferType entities.TransferType, amount float64) (*entities.FeeQuote, error) {
+	rules, err := s.repo.FindRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee rules: %w", err)
+	}
+
+	quote := &entities.FeeQuote{Amount: amount, Total: amount, Currency: account.Currency}
+
+	var best *entities.FeeRule
+	for _, rule := range rules {
+		if rule.Matches(transferType, account.Type, account.Currency) &&
+			(best == nil || rule.Specificity() > best.Specificity()) {
+			best = rule
+		}
+	}
+	if best == nil {
+		return quote, nil
+	}
+
+	quote.RuleID = &best.ID
+	quote.Fee = best.Calculate(amount, entities.MinorUnitsOf(account.Currency))
+	quote.Total = amount + quote.Fee
+
+	if quote.Fee > 0 && s.accounts[account.Currency] == 0 {
+		return nil, fmt.Errorf("%w %s", ErrFeeAccountMissing, account.Currency)
+	}
+	return quote, nil
+}
+
+func (s *feesService) Preview(ctx context.Context, userID uint, req *entities.FeePreviewRequest) (*entities.FeeQuote, error) {
+	account, _, err := s.members.Authorize(ctx, userID, req.FromAccountID, entities.PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	return s.Quote(ctx, account, req.Type, req.Amount)
+}
+
+// Charge возвращает построитель транзакции комиссии, связанной с основной операцией, или nil,
+// если комиссии нет. Транзакцию создаёт и зачисляет на счёт банка AccountsRepository.Post
+// вместе с операцией; списание комиссии с account уже учтено в quote.Total.
+func (s *feesService) Charge(account *entities.Account, quote *entities.FeeQuote) func(parent *entities.Transaction) *entities.Transaction {
+	if quote.Fee == 0 {
+		return nil
+	}
+
+	feeAccountID := s.accounts[account.Currency]
+	return func(parent *entities.Transaction) *entities.Transaction {
+		return &entities.Transaction{
+			FromAccountID: account.ID,
+			ToAccountID:   feeAccountID,
+			UserID:        parent.UserID,
+			Amount:        quote.Fee,
+			Description:   fmt.Sprintf("Комиссия за операцию №%d", parent.ID),
+			Type:          entities.Fee,
+			ParentID:      &parent.ID,
+			CreatedAt:     time.Now(),
+		}
+	}
+}
+
+// CheckAccounts проверяет при запуске, что каждый счёт комиссий из конфигурации существует,
+// принадлежит банку (тип bank), открыт и ведётся в своей валюте. Иначе комиссии клиентов
+// зачислялись бы на чужой счёт или не зачислялись вовсе.
+func (s *feesService) CheckAccounts(ctx context.Context) error {
+	for currency, accountID := range s.accounts {
+		account, err := s.accRepo.FindByID(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w %d for %s: account does not exist", ErrInvalidFeeAccount, accountID, currency)
+			}
+			return fmt.Errorf("failed to get fee account %d: %w", accountID, err)
+		}
+		if account.Type != entities.AccountTypeBank {
+			return fmt.Errorf("%w %d for %s: type is %q, not %q", ErrInvalidFeeAccount, accountID, currency, account.Type, entities.AccountTypeBank)
+		}
+		if !strings.EqualFold(account.Currency, currency) {
+			return fmt.Errorf("%w %d for %s: currency is %s", ErrInvalidFeeAccount, accountID, currency, account.Currency)
+		}
+		if account.Status == entities.AccountClosed {
+			return fmt.Errorf("%w %d for %s: account is closed", ErrInvalidFeeAccount, accountID, currency)
+		}
+	}
+	return nil
+}
+
+func (s *feesService) Rules(ctx context.Context) ([]*entities.FeeRule, error) {
+	rules, err := s.repo.FindRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (s *feesService) SetRule(ctx context.Context, req *entities.SetFeeRuleRequest) (rule *entities.FeeRule, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditFeeSet,
+			ResourceType: "fee_rule",
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.ResourceID = rule.ID
+			event.After = rule
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if err := checkFeeRule(req); err != nil {
+		return nil, err
+	}
+
+	rule = &entities.FeeRule{
+		TransferType: req.TransferType,
+		Product:      req.Product,
+		Currency:     strings.ToUpper(req.Currency),
+		Kind:         req.Kind,
+		Amount:       req.Amount,
+		Percent:      req.Percent,
+		Min:          req.Min,
+		Max:          req.Max,
+		Tiers:        req.Tiers,
+	}
+
+	if err := s.repo.SaveRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save fee rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (s *feesService) DeleteRule(ctx context.Context, id uint) (err error) {
+	var before *entities.FeeRule
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditFeeDelete,
+			ResourceType: "fee_rule",
+			ResourceID:   id,
+			Before:       before,
+			Err:          err,
+		})
+	}()
+
+	before, err = s.repo.DeleteRule(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrFeeRuleNotFound
+		}
+		return fmt.Errorf("failed to delete fee rule: %w", err)
+	}
+	return nil
+}
+
+// checkFeeRule проверяет параметры, нужные виду комиссии
+func checkFeeRule(req *entities.SetFeeRuleRequest) error {
+	switch req.Kind {
+	case entities.FeePercentage:
+		if req.Max > 0 && req.Max < req.Min {
+			return errors.New("max fee is less than min fee")
+		}
+	case entities.FeeTiered:
+		if len(req.Tiers) == 0 {
+			return errors.New("tiered fee needs at least one tier")
+		}
+		for i, tier := range req.Tiers {
+			last := i == len(req.Tiers)-1
+			if tier.UpTo == 0 && !last {
+				return errors.New("only the last tier can be open-ended")
+			}
+			if i > 0 && tier.UpTo != 0 && tier.UpTo <= req.Tiers[i-1].UpTo {
+				return errors.New("tiers must be ordered by up_to")
+			}
+		}
+	}
+	return nil
+}
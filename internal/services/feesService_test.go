@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeFeesRepository struct {
+	repository.FeesRepository
+	rules []*entities.FeeRule
+}
+
+func (r *fakeFeesRepository) FindRules(ctx context.Context) ([]*entities.FeeRule, error) {
+	return r.rules, nil
+}
+
+func TestFeesServiceQuoteSelectsMostSpecificRule(t *testing.T) {
+	rules := []*entities.FeeRule{
+		{ID: 1, Kind: entities.FeeFlat, Amount: 1},
+		{ID: 2, Currency: "RUB", Kind: entities.FeeFlat, Amount: 2},
+		{ID: 3, Product: "savings", Kind: entities.FeeFlat, Amount: 3},
+		{ID: 4, TransferType: entities.ExternalTransfer, Kind: entities.FeeFlat, Amount: 4},
+		{ID: 5, TransferType: entities.ExternalTransfer, Currency: "USD", Kind: entities.FeeFlat, Amount: 5},
+		{ID: 6, TransferType: entities.Withdrawal, Product: "savings", Kind: entities.FeeFlat, Amount: 6},
+	}
+	s := &feesService{
+		repo:     &fakeFeesRepository{rules: rules},
+		accounts: map[string]uint{"RUB": 100, "USD": 101},
+	}
+
+	tests := []struct {
+		name         string
+		product      string
+		currency     string
+		transferType entities.TransferType
+		wantRule     uint
+	}{
+		{name: "transfer type beats product", product: "savings", currency: "RUB", transferType: entities.ExternalTransfer, wantRule: 4},
+		{name: "transfer type and currency", product: "checking", currency: "USD", transferType: entities.ExternalTransfer, wantRule: 5},
+		{name: "transfer type and product", product: "savings", currency: "RUB", transferType: entities.Withdrawal, wantRule: 6},
+		{name: "product beats currency", product: "savings", currency: "RUB", transferType: entities.InternalTransfer, wantRule: 3},
+		{name: "currency", product: "checking", currency: "RUB", transferType: entities.InternalTransfer, wantRule: 2},
+		{name: "catch-all", product: "checking", currency: "USD", transferType: entities.InternalTransfer, wantRule: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &entities.Account{ID: 1, Type: tt.product, Currency: tt.currency}
+			quote, err := s.Quote(context.Background(), account, tt.transferType, 1000)
+			if err != nil {
+				t.Fatalf("Quote: %v", err)
+			}
+			if quote.RuleID == nil || *quote.RuleID != tt.wantRule {
+				t.Fatalf("quote used rule %v, want %d", quote.RuleID, tt.wantRule)
+			}
+			if quote.Fee != float64(tt.wantRule) || quote.Total != 1000+quote.Fee {
+				t.Errorf("fee %v, total %v for rule %d", quote.Fee, quote.Total, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestFeesServiceQuote(t *testing.T) {
+	t.Run("no matching rule", func(t *testing.T) {
+		s := &feesService{repo: &fakeFeesRepository{rules: []*entities.FeeRule{
+			{ID: 1, Currency: "USD", Kind: entities.FeeFlat, Amount: 10},
+		}}}
+		quote, err := s.Quote(context.Background(), &entities.Account{Type: "checking", Currency: "RUB"}, entities.ExternalTransfer, 500)
+		if err != nil {
+			t.Fatalf("Quote: %v", err)
+		}
+		if quote.RuleID != nil || quote.Fee != 0 || quote.Total != 500 {
+			t.Errorf("got %+v, want a zero fee without a rule", quote)
+		}
+	})
+
+	t.Run("rounded by currency", func(t *testing.T) {
+		s := &feesService{
+			repo: &fakeFeesRepository{rules: []*entities.FeeRule{
+				{ID: 1, Kind: entities.FeePercentage, Percent: 1.5},
+			}},
+			accounts: map[string]uint{"JPY": 100},
+		}
+		quote, err := s.Quote(context.Background(), &entities.Account{Type: "checking", Currency: "JPY"}, entities.ExternalTransfer, 1234)
+		if err != nil {
+			t.Fatalf("Quote: %v", err)
+		}
+		if quote.Fee != 19 {
+			t.Errorf("fee %v, want 19", quote.Fee)
+		}
+	})
+
+	t.Run("no fee account", func(t *testing.T) {
+		s := &feesService{repo: &fakeFeesRepository{rules: []*entities.FeeRule{
+			{ID: 1, Kind: entities.FeeFlat, Amount: 10},
+		}}}
+		_, err := s.Quote(context.Background(), &entities.Account{Type: "checking", Currency: "RUB"}, entities.ExternalTransfer, 500)
+		if !errors.Is(err, ErrFeeAccountMissing) {
+			t.Errorf("got %v, want %v", err, ErrFeeAccountMissing)
+		}
+	})
+}
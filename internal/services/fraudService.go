@@ -0,0 +1,132 @@
+package services
+
+import (
+	"bank-app-backend/internal/config"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/repository"
+	"context"
+	"fmt"
+	"time"
+)
+
+type FraudService interface {
+	Screen(ctx context.Context, req entities.TransferRequest) (*entities.FraudResult, error)
+}
+
+// fraudRule проверяет перевод и возвращает true, если правило сработало
+type fraudRule struct {
+	name   string
+	action entities.FraudDecision
+	check  func(ctx context.Context, req entities.TransferRequest, now time.Time) (bool, error)
+}
+
+type fraudService struct {
+	txRepo     repository.TransactionsRepository
+	cfg        config.FraudConfig
+	location   *time.Location
+	rules      []fraudRule
+	blockAfter int
+}
+
+func NewFraudService(txRepo repository.TransactionsRepository, cfg config.FraudConfig) FraudService {
+	s := &fraudService{
+		txRepo:     txRepo,
+		cfg:        cfg,
+		location:   loadLocation(cfg.NightTime.Timezone),
+		blockAfter: cfg.BlockAfter,
+	}
+
+	s.rules = []fraudRule{
+		{name: "velocity", action: entities.FraudDecision(cfg.Velocity.Action), check: s.velocity},
+		{name: "new_payee", action: entities.FraudDecision(cfg.NewPayee.Action), check: s.newPayee},
+		{name: "unusual_amount", action: entities.FraudDecision(cfg.UnusualAmount.Action), check: s.unusualAmount},
+		{name: "night_time", action: entities.FraudDecision(cfg.NightTime.Action), check: s.nightTime},
+	}
+
+	return s
+}
+
+// Screen применяет все включённые правила. Итог — самое строгое из решений сработавших
+// правил; при BlockAfter и более сработавших правилах перевод блокируется.
+func (s *fraudService) Screen(ctx context.Context, req entities.TransferRequest) (*entities.FraudResult, error) {
+	result := &entities.FraudResult{Decision: entities.FraudAllow}
+	now := time.Now()
+
+	for _, rule := range s.rules {
+		if rule.action != entities.FraudHold && rule.action != entities.FraudBlock {
+			continue
+		}
+
+		triggered, err := rule.check(ctx, req, now)
+		if err != nil {
+			return nil, fmt.Errorf("fraud rule %s failed: %w", rule.name, err)
+		}
+		if !triggered {
+			continue
+		}
+
+		result.Reasons = append(result.Reasons, rule.name)
+		if rule.action.Severity() > result.Decision.Severity() {
+			result.Decision = rule.action
+		}
+	}
+
+	if s.blockAfter > 0 && len(result.Reasons) >= s.blockAfter {
+		result.Decision = entities.FraudBlock
+	}
+
+	return result, nil
+}
+
+func (s *fraudService) velocity(ctx context.Context, req entities.TransferRequest, now time.Time) (bool, error) {
+	count, err := s.txRepo.CountTransfersSince(ctx, req.UserID, now.Add(-s.cfg.Velocity.Window))
+	if err != nil {
+		return false, err
+	}
+	return count >= int64(s.cfg.Velocity.MaxTransfers), nil
+}
+
+// newPayee не применяется к внутренним переводам: они идут между счетами самого пользователя
+func (s *fraudService) newPayee(ctx context.Context, req entities.TransferRequest, _ time.Time) (bool, error) {
+	if req.Type != entities.ExternalTransfer || req.Amount < s.cfg.NewPayee.MinAmount {
+		return false, nil
+	}
+
+	known, err := s.txRepo.HasTransferredTo(ctx, req.UserID, req.ToAccountID)
+	if err != nil {
+		return false, err
+	}
+	return !known, nil
+}
+
+func (s *fraudService) unusualAmount(ctx context.Context, req entities.TransferRequest, _ time.Time) (bool, error) {
+	amounts, err := s.txRepo.RecentTransferAmounts(ctx, req.UserID, s.cfg.UnusualAmount.History)
+	if err != nil {
+		return false, err
+	}
+	if len(amounts) == 0 || len(amounts) < s.cfg.UnusualAmount.MinHistory {
+		return false, nil
+	}
+
+	var total float64
+	for _, amount := range amounts {
+		total += amount
+	}
+	average := total / float64(len(amounts))
+
+	return req.Amount > average*s.cfg.UnusualAmount.Multiplier, nil
+}
+
+// nightTime поддерживает интервалы через полночь, например с 23 до 5
+func (s *fraudService) nightTime(_ context.Context, req entities.TransferRequest, now time.Time) (bool, error) {
+	if req.Amount < s.cfg.NightTime.MinAmount {
+		return false, nil
+	}
+
+	hour := now.In(s.location).Hour()
+	start, end := s.cfg.NightTime.StartHour, s.cfg.NightTime.EndHour
+	if start <= end {
+		return hour >= start && hour < end, nil
+	}
+	return hour >= start || hour < end, nil
+}
@@ -0,0 +1,389 @@
+package services
+
+import (
+	"bank-app-backend/internal/config"
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/lib/kafka"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"time"
+)
+
+type HoldsService interface {
+	Create(ctx context.Context, userID, accountID uint, req *entities.CreateHoldRequest) (*entities.Hold, error)
+	List(ctx context.Context, userID, accountID uint, status entities.HoldStatus) ([]*entities.Hold, error)
+	Capture(ctx context.Context, userID, holdID uint, amount float64) (*entities.Hold, error)
+	Release(ctx context.Context, userID, holdID uint) (*entities.Hold, error)
+	Run(ctx context.Context)
+	ExpireDue(ctx context.Context) (int, error)
+}
+
+var (
+	ErrHoldNotFound       = errors.New("hold not found")
+	ErrHoldNotActive      = errors.New("hold is not active")
+	ErrHoldExpired        = errors.New("hold has expired")
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds the hold")
+	ErrHoldTTLTooLong     = errors.New("hold expiry exceeds the maximum")
+	ErrCardHold           = errors.New("card holds are captured and released by the card network")
+)
+
+// expireBatch — сколько истёкших холдов снимается за один проход
+const expireBatch = 100
+
+type holdsService struct {
+	repo       repository.HoldsRepository
+	accRepo    repository.AccountsRepository
+	members    AccountMembersService
+	limits     LimitsService
+	fees       FeesService
+	pots       PotsService
+	currencies CurrenciesService
+	cfg        config.HoldsConfig
+	overdrawn  *kafka.Producer
+	audit      AuditService
+}
+
+func NewHoldsService(
+	r repository.HoldsRepository,
+	accRepo repository.AccountsRepository,
+	members AccountMembersService,
+	limits LimitsService,
+	fees FeesService,
+	pots PotsService,
+	currencies CurrenciesService,
+	cfg config.HoldsConfig,
+	overdrawn *kafka.Producer,
+	audit AuditService,
+) HoldsService {
+	return &holdsService{
+		repo:       r,
+		accRepo:    accRepo,
+		members:    members,
+		limits:     limits,
+		fees:       fees,
+		pots:       pots,
+		currencies: currencies,
+		cfg:        cfg,
+		overdrawn:  overdrawn,
+		audit:      audit,
+	}
+}
+
+// Create резервирует деньги на счёте. Холд сразу учитывается в лимитах списаний:
+// решение о расходе принимается при авторизации, а не при списании.
+func (s *holdsService) Create(ctx context.Context, userID, accountID uint, req *entities.CreateHoldRequest) (hold *entities.Hold, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditHoldCreate,
+			ResourceType: "hold",
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.ResourceID = hold.ID
+			event.After = hold
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	account, member, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionSpend)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSpendLimit(member, req.Amount); err != nil {
+		return nil, err
+	}
+	if err := s.currencies.CheckAmount(account.Currency, req.Amount); err != nil {
+		return nil, err
+	}
+
+	ttl := s.cfg.DefaultTTL
+	if req.ExpiresIn > 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+	}
+	if s.cfg.MaxTTL > 0 && ttl > s.cfg.MaxTTL {
+		return nil, ErrHoldTTLTooLong
+	}
+
+	reservation, err := s.limits.Reserve(ctx, userID, account, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			s.limits.Release(ctx, reservation)
+		}
+	}()
+
+	hold = &entities.Hold{
+		AccountID:   accountID,
+		UserID:      userID,
+		Amount:      req.Amount,
+		Description: req.Description,
+		CardID:      req.CardID,
+		Status:      entities.HoldActive,
+		ExpiresAt:   time.Now().Add(ttl),
+	}
+
+	err = s.repo.Create(ctx, hold, req.CardDayStart, func(account *entities.Account, cardSpent float64) error {
+		if err := checkDebit(account); err != nil {
+			return err
+		}
+		if req.CardDailyLimit > 0 && cardSpent+hold.Amount > req.CardDailyLimit {
+			return ErrCardLimitExceeded
+		}
+		if account.Available() < hold.Amount {
+			return ErrInsufficientFunds
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrAccountInactive) || errors.Is(err, ErrInsufficientFunds) ||
+			errors.Is(err, ErrCardLimitExceeded) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create hold: %w", err)
+	}
+
+	s.invalidate(ctx, accountID)
+	return hold, nil
+}
+
+func (s *holdsService) List(ctx context.Context, userID, accountID uint, status entities.HoldStatus) ([]*entities.Hold, error) {
+	if _, _, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionView); err != nil {
+		return nil, err
+	}
+
+	holds, err := s.repo.FindByAccount(ctx, accountID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holds: %w", err)
+	}
+	return holds, nil
+}
+
+// Capture списывает со счёта amount из холда (0 — весь холд); остаток холда освобождается
+// и возвращается в лимиты. Списание проходит те же проверки, что и снятие со счёта:
+// точность суммы, лимит на операцию по текущему уровню KYC и комиссия, которую
+// оплачивает свободный остаток счёта. Холды по картам списывает только платёжная сеть.
+func (s *holdsService) Capture(ctx context.Context, userID, holdID uint, amount float64) (hold *entities.Hold, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditHoldCapture,
+			ResourceType: "hold",
+			ResourceID:   holdID,
+			After:        map[string]float64{"amount": amount},
+			Err:          err,
+		}
+		if err == nil {
+			event.After = hold
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	hold, account, member, err := s.authorizedHold(ctx, userID, holdID)
+	if err != nil {
+		return nil, err
+	}
+	if hold.CardID != nil {
+		return nil, ErrCardHold
+	}
+	if amount == 0 {
+		amount = hold.Amount
+	}
+	if err := checkSpendLimit(member, amount); err != nil {
+		return nil, err
+	}
+	if err := s.currencies.CheckAmount(account.Currency, amount); err != nil {
+		return nil, err
+	}
+	if err := s.limits.CheckReserved(ctx, hold.UserID, account, amount); err != nil {
+		return nil, err
+	}
+	quote, err := s.fees.Quote(ctx, account, entities.Capture, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	var balanceBefore float64
+	captured, fee, err := s.repo.Capture(ctx, holdID, amount, s.fees.Charge(account, quote), func(h *entities.Hold, a *entities.Account) error {
+		if err := checkActiveHold(h); err != nil {
+			return err
+		}
+		if amount > h.Amount {
+			return ErrCaptureExceedsHold
+		}
+		if err := checkDebit(a); err != nil {
+			return err
+		}
+		// Сумма холда уже зарезервирована, комиссия списывается из свободного остатка
+		if a.Available()+h.Amount < quote.Total {
+			return ErrInsufficientFunds
+		}
+		balanceBefore = a.Balance
+		account = a
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrHoldNotActive) || errors.Is(err, ErrHoldExpired) ||
+			errors.Is(err, ErrCaptureExceedsHold) || errors.Is(err, ErrAccountInactive) ||
+			errors.Is(err, ErrInsufficientFunds) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to capture hold: %w", err)
+	}
+
+	s.invalidate(ctx, captured.AccountID)
+	if fee != nil {
+		s.invalidate(ctx, fee.ToAccountID)
+	}
+	if amount < captured.Amount {
+		s.limits.Release(ctx, &entities.LimitReservation{
+			UserID:  captured.UserID,
+			Amount:  captured.Amount - amount,
+			At:      captured.CreatedAt,
+			Partial: true,
+		})
+	}
+
+	account.Balance -= quote.Total
+	publishOverdrawn(s.overdrawn, account, balanceBefore)
+	s.pots.RoundUp(ctx, account, amount)
+
+	return captured, nil
+}
+
+// Release снимает холд и возвращает его в лимиты списаний
+func (s *holdsService) Release(ctx context.Context, userID, holdID uint) (hold *entities.Hold, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditHoldRelease,
+			ResourceType: "hold",
+			ResourceID:   holdID,
+			Err:          err,
+		}
+		if err == nil {
+			event.After = hold
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	hold, _, _, err = s.authorizedHold(ctx, userID, holdID)
+	if err != nil {
+		return nil, err
+	}
+	if hold.CardID != nil {
+		return nil, ErrCardHold
+	}
+
+	return s.release(ctx, holdID, entities.HoldReleased)
+}
+
+// Run периодически снимает холды с истёкшим сроком
+func (s *holdsService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ExpireDue(ctx); err != nil {
+			lib.Log.Error("Failed to expire holds", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireDue снимает все холды с истёкшим сроком и возвращает их число
+func (s *holdsService) ExpireDue(ctx context.Context) (int, error) {
+	expired := 0
+	for {
+		holds, err := s.repo.FindExpired(ctx, time.Now(), expireBatch)
+		if err != nil {
+			return expired, fmt.Errorf("failed to get expired holds: %w", err)
+		}
+
+		for _, hold := range holds {
+			released, err := s.release(ctx, hold.ID, entities.HoldExpired)
+			s.audit.Record(ctx, entities.AuditEvent{
+				Action:       entities.AuditHoldRelease,
+				ResourceType: "hold",
+				ResourceID:   hold.ID,
+				After:        released,
+				Err:          err,
+			})
+			if err != nil {
+				return expired, err
+			}
+			expired++
+		}
+
+		if len(holds) < expireBatch {
+			return expired, nil
+		}
+	}
+}
+
+// release снимает активный холд, переводя его в status
+func (s *holdsService) release(ctx context.Context, holdID uint, status entities.HoldStatus) (*entities.Hold, error) {
+	hold, err := s.repo.Release(ctx, holdID, status, func(h *entities.Hold) error {
+		if h.Status != entities.HoldActive {
+			return ErrHoldNotActive
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrHoldNotActive) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to release hold: %w", err)
+	}
+
+	s.limits.Release(ctx, &entities.LimitReservation{UserID: hold.UserID, Amount: hold.Amount, At: hold.CreatedAt})
+	s.invalidate(ctx, hold.AccountID)
+	return hold, nil
+}
+
+// authorizedHold возвращает холд вместе со счётом и участником, если пользователь может
+// распоряжаться счётом холда
+func (s *holdsService) authorizedHold(ctx context.Context, userID, holdID uint) (*entities.Hold, *entities.Account, *entities.AccountMember, error) {
+	hold, err := s.repo.FindByID(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil, ErrHoldNotFound
+		}
+		return nil, nil, nil, fmt.Errorf("failed to get hold: %w", err)
+	}
+
+	account, member, err := s.members.Authorize(ctx, userID, hold.AccountID, entities.PermissionSpend)
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			return nil, nil, nil, ErrHoldNotFound
+		}
+		return nil, nil, nil, err
+	}
+	return hold, account, member, nil
+}
+
+func (s *holdsService) invalidate(ctx context.Context, accountID uint) {
+	if err := s.accRepo.Invalidate(ctx, accountID); err != nil {
+		lib.Log.Warn("Failed to invalidate account cache", zap.Uint("account_id", accountID), zap.Error(err))
+	}
+}
+
+func checkActiveHold(hold *entities.Hold) error {
+	if hold.Status != entities.HoldActive {
+		return ErrHoldNotActive
+	}
+	if !hold.ExpiresAt.After(time.Now()) {
+		return ErrHoldExpired
+	}
+	return nil
+}
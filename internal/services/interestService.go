@@ -0,0 +1,234 @@
+package services
+
+import (
+	"bank-app-backend/internal/config"
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+type InterestService interface {
+	Run(ctx context.Context)
+	RunDaily(ctx context.Context, businessDate time.Time) (*entities.InterestRunResult, error)
+	AccountInterest(ctx context.Context, userID, accountID uint) (*entities.AccountInterest, error)
+}
+
+var (
+	ErrNoInterest          = errors.New("account does not earn interest")
+	ErrBusinessDateNotOver = errors.New("business date is not over yet")
+)
+
+type interestService struct {
+	repo      repository.InterestRepository
+	accRepo   repository.AccountsRepository
+	members   AccountMembersService
+	products  map[string]config.ProductConfig
+	overdraft config.ProductConfig
+	jobs      config.JobsConfig
+	location  *time.Location
+	runs      repository.JobRunsRepository
+	audit     AuditService
+}
+
+func NewInterestService(
+	r repository.InterestRepository,
+	accRepo repository.AccountsRepository,
+	members AccountMembersService,
+	products map[string]config.ProductConfig,
+	overdraft config.ProductConfig,
+	jobs config.JobsConfig,
+	runs repository.JobRunsRepository,
+	audit AuditService,
+) InterestService {
+	return &interestService{
+		repo:      r,
+		accRepo:   accRepo,
+		members:   members,
+		products:  products,
+		overdraft: overdraft,
+		jobs:      jobs,
+		location:  loadLocation(jobs.Timezone),
+		runs:      runs,
+		audit:     audit,
+	}
+}
+
+// Run запускает начисление процентов за каждый завершившийся операционный день
+func (s *interestService) Run(ctx context.Context) {
+	runDaily(ctx, "interest", s.jobs.Interval, s.location, s.runs, func(ctx context.Context, date time.Time) error {
+		_, err := s.RunDaily(ctx, date)
+		return err
+	})
+}
+
+// RunDaily начисляет проценты за день businessDate, а в последний день месяца
+// капитализирует накопленное. Повторный запуск за тот же день ничего не меняет.
+// Нулевая дата означает последний завершившийся день.
+func (s *interestService) RunDaily(ctx context.Context, businessDate time.Time) (result *entities.InterestRunResult, err error) {
+	if businessDate.IsZero() {
+		businessDate = previousBusinessDate(time.Now(), s.location)
+	}
+	date := time.Date(businessDate.Year(), businessDate.Month(), businessDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditInterestRun,
+			ResourceType: "interest",
+			ResourceID:   date.Format(entities.BusinessDateLayout),
+			After:        result,
+			Err:          err,
+		})
+	}()
+
+	if date.After(previousBusinessDate(time.Now(), s.location)) {
+		return nil, ErrBusinessDateNotOver
+	}
+
+	result = &entities.InterestRunResult{BusinessDate: date.Format(entities.BusinessDateLayout)}
+	// Проценты начисляются на остаток на конец дня, а не на текущий
+	closedAt := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, s.location)
+
+	for product, terms := range s.products {
+		if terms.InterestRate <= 0 {
+			continue
+		}
+
+		accounts, err := s.repo.FindAccounts(ctx, []string{product}, closedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s accounts: %w", product, err)
+		}
+
+		for _, account := range accounts {
+			if account.Balance <= 0 {
+				continue
+			}
+			if err := s.accrue(ctx, result, account, date, terms); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.overdraft.InterestRate > 0 {
+		accounts, err := s.repo.FindOverdrawn(ctx, closedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get overdrawn accounts: %w", err)
+		}
+
+		for _, account := range accounts {
+			if err := s.accrue(ctx, result, account, date, s.overdraft); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if date.AddDate(0, 0, 1).Month() != date.Month() {
+		if err := s.capitalize(ctx, result, date); err != nil {
+			return nil, err
+		}
+	}
+
+	lib.Log.Info("Interest job finished",
+		zap.String("business_date", result.BusinessDate),
+		zap.Int("accrued", result.Accrued),
+		zap.Int("skipped", result.Skipped),
+		zap.Int("capitalized", result.Capitalized),
+	)
+	return result, nil
+}
+
+// AccountInterest возвращает условия начисления по счёту и ещё не проведённые проценты;
+// проценты за овердрафт уменьшают сумму
+func (s *interestService) AccountInterest(ctx context.Context, userID, accountID uint) (*entities.AccountInterest, error) {
+	account, _, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionView)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := s.products[account.Type]
+	overdraftRate := 0.0
+	if account.OverdraftLimit > 0 || account.Balance < 0 {
+		overdraftRate = s.overdraft.InterestRate
+	}
+	if terms.InterestRate <= 0 && overdraftRate <= 0 {
+		return nil, ErrNoInterest
+	}
+
+	accruals, err := s.repo.Pending(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interest accruals: %w", err)
+	}
+
+	interest := &entities.AccountInterest{
+		AccountID:     account.ID,
+		Product:       account.Type,
+		Rate:          terms.InterestRate,
+		DayCount:      productDayCount(terms),
+		OverdraftRate: overdraftRate,
+	}
+	for _, accrual := range accruals {
+		interest.Accrued += accrual.Amount
+		interest.LastAccrualDate = accrual.BusinessDate.Format(entities.BusinessDateLayout)
+	}
+	return interest, nil
+}
+
+// accrue начисляет проценты по ставке terms на остаток счёта за день date. Для
+// отрицательного остатка начисление отрицательное. Уже существующее начисление пропускается.
+func (s *interestService) accrue(ctx context.Context, result *entities.InterestRunResult, account *entities.Account, date time.Time, terms config.ProductConfig) error {
+	dayCount := productDayCount(terms)
+
+	created, err := s.repo.Accrue(ctx, &entities.InterestAccrual{
+		AccountID:    account.ID,
+		BusinessDate: date,
+		Balance:      account.Balance,
+		Rate:         terms.InterestRate,
+		DayCount:     dayCount,
+		Amount:       account.Balance * terms.InterestRate * dayCount.YearFraction(date),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to accrue interest for account %d: %w", account.ID, err)
+	}
+
+	if created {
+		result.Accrued++
+	} else {
+		result.Skipped++
+	}
+	return nil
+}
+
+// capitalize проводит по счетам все невыплаченные начисления по дату date включительно
+func (s *interestService) capitalize(ctx context.Context, result *entities.InterestRunResult, date time.Time) error {
+	accountIDs, err := s.repo.FindWithPending(ctx, date)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts with accrued interest: %w", err)
+	}
+
+	for _, accountID := range accountIDs {
+		tx, err := s.repo.Capitalize(ctx, accountID, date, "Проценты за "+date.Format("01.2006"))
+		if err != nil {
+			return fmt.Errorf("failed to capitalize interest for account %d: %w", accountID, err)
+		}
+		if tx == nil {
+			continue
+		}
+		result.Capitalized++
+
+		if err := s.accRepo.Invalidate(ctx, accountID); err != nil {
+			lib.Log.Warn("Failed to invalidate account cache", zap.Uint("account_id", accountID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func productDayCount(terms config.ProductConfig) entities.DayCount {
+	if terms.DayCount == "" {
+		return entities.DayCountAct365
+	}
+	return entities.DayCount(terms.DayCount)
+}
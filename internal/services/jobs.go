@@ -0,0 +1,84 @@
+package services
+
+import (
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// dailyJob — задача, выполняемая один раз за завершившийся операционный день
+type dailyJob func(ctx context.Context, businessDate time.Time) error
+
+// runDaily раз в interval проверяет, завершился ли новый операционный день в часовом
+// поясе location, и запускает job за каждый день после последнего обработанного, который
+// хранится в runs: после простоя задача догоняет пропущенные дни по порядку. При ошибке
+// догон останавливается и продолжается с того же дня на следующей проверке. Задача, которая
+// ещё ни разу не выполнялась, начинает с последнего завершившегося дня. Задачи должны быть
+// идемпотентны: на нескольких инстансах день может обрабатываться повторно.
+func runDaily(ctx context.Context, name string, interval time.Duration, location *time.Location, runs repository.JobRunsRepository, job dailyJob) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		catchUp(ctx, name, location, runs, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// catchUp запускает job за дни после последнего обработанного по вчерашний включительно
+func catchUp(ctx context.Context, name string, location *time.Location, runs repository.JobRunsRepository, job dailyJob) {
+	yesterday := previousBusinessDate(time.Now(), location)
+
+	last, err := runs.Last(ctx, name)
+	if err != nil {
+		lib.Log.Error("Failed to get last daily job run", zap.String("job", name), zap.Error(err))
+		return
+	}
+	date := yesterday
+	if !last.IsZero() {
+		date = last.AddDate(0, 0, 1)
+	}
+
+	for ; !date.After(yesterday); date = date.AddDate(0, 0, 1) {
+		if err := job(ctx, date); err != nil {
+			lib.Log.Error("Daily job failed",
+				zap.String("job", name),
+				zap.String("business_date", date.Format("2006-01-02")),
+				zap.Error(err),
+			)
+			return
+		}
+		if err := runs.Save(ctx, name, date); err != nil {
+			lib.Log.Error("Failed to save daily job run",
+				zap.String("job", name),
+				zap.String("business_date", date.Format("2006-01-02")),
+				zap.Error(err),
+			)
+			return
+		}
+	}
+}
+
+// previousBusinessDate возвращает последний завершившийся день в поясе location
+// как дату в UTC без времени
+func previousBusinessDate(now time.Time, location *time.Location) time.Time {
+	local := now.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+}
+
+// loadLocation возвращает часовой пояс по имени, а для неизвестного имени — UTC
+func loadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		lib.Log.Warn("Unknown timezone, using UTC", zap.String("timezone", name), zap.Error(err))
+		return time.UTC
+	}
+	return location
+}
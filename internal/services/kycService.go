@@ -0,0 +1,294 @@
+package services
+
+import (
+	"bank-app-backend/internal/config"
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+var (
+	ErrKYCRestricted        = errors.New("action is not allowed for your verification tier")
+	ErrKYCNotFound          = errors.New("KYC profile not found")
+	ErrKYCInvalidTransition = errors.New("invalid KYC status transition")
+)
+
+// kycTransitions — допустимые переходы статуса при проверке администратором
+var kycTransitions = map[entities.KYCStatus][]entities.KYCStatus{
+	entities.KYCStatusPending:  {entities.KYCStatusVerified, entities.KYCStatusRejected},
+	entities.KYCStatusVerified: {entities.KYCStatusRejected, entities.KYCStatusPending},
+	entities.KYCStatusRejected: {entities.KYCStatusPending, entities.KYCStatusVerified},
+}
+
+type KYCService interface {
+	Get(ctx context.Context, userID uint) (*entities.KYCResponse, error)
+	Submit(ctx context.Context, userID uint, req *entities.SubmitKYCRequest) (*entities.KYCResponse, error)
+	Review(ctx context.Context, reviewerID, userID uint, req *entities.ReviewKYCRequest) (*entities.KYCResponse, error)
+	GetByStatus(ctx context.Context, status entities.KYCStatus) ([]*entities.KYCResponse, error)
+	Limits(ctx context.Context, userID uint) (entities.KYCTierLimits, error)
+	CheckAccountCreation(ctx context.Context, userID uint, currentAccounts int) error
+	CheckDeposit(ctx context.Context, userID uint, amount float64) error
+	CheckTransfer(ctx context.Context, userID uint, amount float64, transferType entities.TransferType) error
+}
+
+type kycService struct {
+	repo  repository.KYCRepository
+	cfg   config.KYCConfig
+	audit AuditService
+}
+
+func NewKYCService(r repository.KYCRepository, cfg config.KYCConfig, audit AuditService) KYCService {
+	return &kycService{
+		repo:  r,
+		cfg:   cfg,
+		audit: audit,
+	}
+}
+
+func (s *kycService) Get(ctx context.Context, userID uint) (*entities.KYCResponse, error) {
+	profile, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.toResponse(userID, nil), nil
+		}
+		return nil, fmt.Errorf("failed to get KYC profile: %w", err)
+	}
+
+	return s.toResponse(userID, profile), nil
+}
+
+// Submit сохраняет анкету пользователя и переводит её в статус pending.
+// Повторная подача возможна только после отказа.
+func (s *kycService) Submit(ctx context.Context, userID uint, req *entities.SubmitKYCRequest) (*entities.KYCResponse, error) {
+	dateOfBirth, err := time.Parse("2006-01-02", req.DateOfBirth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date of birth: %v", err)
+	}
+
+	if s.cfg.MinAge > 0 && dateOfBirth.AddDate(s.cfg.MinAge, 0, 0).After(time.Now()) {
+		return nil, fmt.Errorf("user must be at least %d years old", s.cfg.MinAge)
+	}
+
+	profile, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get KYC profile: %w", err)
+		}
+		profile = &entities.KYCProfile{UserID: userID}
+	} else if profile.Status == entities.KYCStatusPending || profile.Status == entities.KYCStatusVerified {
+		return nil, fmt.Errorf("%w: profile is already %s", ErrKYCInvalidTransition, profile.Status)
+	}
+
+	profile.LegalName = strings.TrimSpace(req.LegalName)
+	profile.DateOfBirth = dateOfBirth
+	profile.AddressLine = req.AddressLine
+	profile.City = req.City
+	profile.PostalCode = req.PostalCode
+	profile.Country = strings.ToUpper(req.Country)
+	profile.DocumentType = req.DocumentType
+	profile.DocumentNumber = req.DocumentNumber
+	profile.Status = entities.KYCStatusPending
+	profile.RejectionReason = ""
+	profile.ReviewedBy = nil
+	profile.ReviewedAt = nil
+	profile.SubmittedAt = time.Now()
+
+	if err := s.repo.Save(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to save KYC profile: %w", err)
+	}
+
+	return s.toResponse(userID, profile), nil
+}
+
+// Review переводит анкету в новый статус по решению сотрудника
+func (s *kycService) Review(ctx context.Context, reviewerID, userID uint, req *entities.ReviewKYCRequest) (resp *entities.KYCResponse, err error) {
+	var before, after map[string]interface{}
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditKYCReview,
+			ResourceType: "kyc_profile",
+			ResourceID:   userID,
+			Before:       before,
+			After:        after,
+			Err:          err,
+		}
+		if err != nil {
+			event.After = req
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	profile, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrKYCNotFound
+		}
+		return nil, fmt.Errorf("failed to get KYC profile: %w", err)
+	}
+	before = kycAuditSnapshot(profile)
+
+	if !canTransitionKYC(profile.Status, req.Status) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrKYCInvalidTransition, profile.Status, req.Status)
+	}
+
+	if req.Status == entities.KYCStatusRejected && strings.TrimSpace(req.Reason) == "" {
+		return nil, fmt.Errorf("rejection reason is required")
+	}
+
+	now := time.Now()
+	previous := profile.Status
+	profile.Status = req.Status
+	profile.RejectionReason = ""
+	if req.Status == entities.KYCStatusRejected {
+		profile.RejectionReason = req.Reason
+	}
+	profile.ReviewedBy = &reviewerID
+	profile.ReviewedAt = &now
+
+	if err := s.repo.Save(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to save KYC profile: %w", err)
+	}
+	after = kycAuditSnapshot(profile)
+
+	lib.Log.Info("KYC status changed",
+		zap.Uint("user_id", userID),
+		zap.Uint("reviewer_id", reviewerID),
+		zap.String("from", string(previous)),
+		zap.String("to", string(req.Status)),
+	)
+
+	return s.toResponse(userID, profile), nil
+}
+
+func (s *kycService) GetByStatus(ctx context.Context, status entities.KYCStatus) ([]*entities.KYCResponse, error) {
+	profiles, err := s.repo.FindByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KYC profiles: %w", err)
+	}
+
+	responses := make([]*entities.KYCResponse, 0, len(profiles))
+	for _, profile := range profiles {
+		responses = append(responses, s.toResponse(profile.UserID, profile))
+	}
+	return responses, nil
+}
+
+func (s *kycService) Limits(ctx context.Context, userID uint) (entities.KYCTierLimits, error) {
+	status, err := s.status(ctx, userID)
+	if err != nil {
+		return entities.KYCTierLimits{}, err
+	}
+	return s.tierLimits(status.Tier()), nil
+}
+
+func (s *kycService) CheckAccountCreation(ctx context.Context, userID uint, currentAccounts int) error {
+	limits, err := s.Limits(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if limits.MaxAccounts > 0 && currentAccounts >= limits.MaxAccounts {
+		return fmt.Errorf("%w: at most %d open accounts", ErrKYCRestricted, limits.MaxAccounts)
+	}
+	return nil
+}
+
+func (s *kycService) CheckDeposit(ctx context.Context, userID uint, amount float64) error {
+	limits, err := s.Limits(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if limits.MaxDepositAmount > 0 && amount > limits.MaxDepositAmount {
+		return fmt.Errorf("%w: deposit exceeds %.2f", ErrKYCRestricted, limits.MaxDepositAmount)
+	}
+	return nil
+}
+
+func (s *kycService) CheckTransfer(ctx context.Context, userID uint, amount float64, transferType entities.TransferType) error {
+	limits, err := s.Limits(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if transferType == entities.ExternalTransfer && !limits.ExternalTransfers {
+		return fmt.Errorf("%w: external transfers require verification", ErrKYCRestricted)
+	}
+	if limits.MaxTransferAmount > 0 && amount > limits.MaxTransferAmount {
+		return fmt.Errorf("%w: transfer exceeds %.2f", ErrKYCRestricted, limits.MaxTransferAmount)
+	}
+	return nil
+}
+
+func (s *kycService) status(ctx context.Context, userID uint) (entities.KYCStatus, error) {
+	profile, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.KYCStatusNotSubmitted, nil
+		}
+		return "", fmt.Errorf("failed to get KYC profile: %w", err)
+	}
+	return profile.Status, nil
+}
+
+func (s *kycService) tierLimits(tier entities.KYCTier) entities.KYCTierLimits {
+	tierCfg := s.cfg.Basic
+	if tier == entities.KYCTierVerified {
+		tierCfg = s.cfg.Verified
+	}
+
+	return entities.KYCTierLimits{
+		MaxAccounts:       tierCfg.MaxAccounts,
+		MaxDepositAmount:  tierCfg.MaxDepositAmount,
+		MaxTransferAmount: tierCfg.MaxTransferAmount,
+		ExternalTransfers: tierCfg.ExternalTransfers,
+	}
+}
+
+func (s *kycService) toResponse(userID uint, profile *entities.KYCProfile) *entities.KYCResponse {
+	if profile == nil {
+		return &entities.KYCResponse{
+			UserID: userID,
+			Status: entities.KYCStatusNotSubmitted,
+			Tier:   entities.KYCTierBasic,
+			Limits: s.tierLimits(entities.KYCTierBasic),
+		}
+	}
+
+	submittedAt := profile.SubmittedAt
+	return &entities.KYCResponse{
+		UserID:          userID,
+		Status:          profile.Status,
+		Tier:            profile.Status.Tier(),
+		Limits:          s.tierLimits(profile.Status.Tier()),
+		RejectionReason: profile.RejectionReason,
+		Profile:         profile.ToDTO(),
+		SubmittedAt:     &submittedAt,
+		ReviewedAt:      profile.ReviewedAt,
+	}
+}
+
+func canTransitionKYC(from, to entities.KYCStatus) bool {
+	for _, allowed := range kycTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// kycAuditSnapshot — поля анкеты для журнала аудита, без персональных данных
+func kycAuditSnapshot(profile *entities.KYCProfile) map[string]interface{} {
+	return map[string]interface{}{
+		"status":           profile.Status,
+		"rejection_reason": profile.RejectionReason,
+		"reviewed_by":      profile.ReviewedBy,
+	}
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"time"
+)
+
+type LimitsService interface {
+	Status(ctx context.Context, userID, accountID uint) (*entities.LimitsStatus, error)
+	Charge(ctx context.Context, userID uint, account *entities.Account, amount float64) (*repository.LimitCharge, error)
+	Reserve(ctx context.Context, userID uint, account *entities.Account, amount float64) (*entities.LimitReservation, error)
+	CheckReserved(ctx context.Context, userID uint, account *entities.Account, amount float64) error
+	Release(ctx context.Context, reservation *entities.LimitReservation)
+	Rules(ctx context.Context) ([]*entities.LimitRule, error)
+	SetRule(ctx context.Context, req *entities.SetLimitRuleRequest) (*entities.LimitRule, error)
+	DeleteRule(ctx context.Context, id uint) error
+}
+
+var (
+	ErrLimitExceeded     = errors.New("transfer limit exceeded")
+	ErrLimitRuleNotFound = errors.New("limit rule not found")
+)
+
+// LimitExceededError сообщает, какой именно лимит превышен и сколько по нему осталось
+type LimitExceededError struct {
+	Limit     string
+	Max       float64
+	Remaining float64
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s: %s limit is %.2f, remaining %.2f", ErrLimitExceeded, e.Limit, e.Max, e.Remaining)
+}
+
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+type limitsService struct {
+	repo    repository.LimitsRepository
+	kyc     KYCService
+	members AccountMembersService
+	audit   AuditService
+}
+
+func NewLimitsService(
+	r repository.LimitsRepository,
+	kyc KYCService,
+	members AccountMembersService,
+	audit AuditService,
+) LimitsService {
+	return &limitsService{
+		repo:    r,
+		kyc:     kyc,
+		members: members,
+		audit:   audit,
+	}
+}
+
+// Status возвращает действующие лимиты пользователя и их остаток. Если указан счёт,
+// учитываются и правила для его типа.
+func (s *limitsService) Status(ctx context.Context, userID, accountID uint) (*entities.LimitsStatus, error) {
+	accountType := ""
+	if accountID != 0 {
+		account, _, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionView)
+		if err != nil {
+			return nil, err
+		}
+		accountType = account.Type
+	}
+
+	limits, err := s.effective(ctx, userID, accountType)
+	if err != nil {
+		return nil, err
+	}
+
+	dayStart, monthStart := limitPeriods(time.Now())
+
+	daily, err := s.repo.Usage(ctx, userID, entities.LimitPeriodDay, dayStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get limit usage: %w", err)
+	}
+	monthly, err := s.repo.Usage(ctx, userID, entities.LimitPeriodMonth, monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get limit usage: %w", err)
+	}
+
+	return &entities.LimitsStatus{
+		Limits:  limits,
+		Daily:   periodStatus(daily, limits.DailyAmount, limits.DailyCount, dayStart.AddDate(0, 0, 1)),
+		Monthly: periodStatus(monthly, limits.MonthlyAmount, limits.MonthlyCount, monthStart.AddDate(0, 1, 0)),
+	}, nil
+}
+
+// Charge проверяет лимит на одну операцию и готовит её учёт в дневных и месячных лимитах.
+// Учёт выполняется в транзакции самой операции, например AccountsRepository.Post.
+func (s *limitsService) Charge(ctx context.Context, userID uint, account *entities.Account, amount float64) (*repository.LimitCharge, error) {
+	limits, err := s.effective(ctx, userID, account.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPerTransaction(limits, amount); err != nil {
+		return nil, err
+	}
+
+	dayStart, monthStart := limitPeriods(time.Now())
+	return &repository.LimitCharge{
+		UserID:     userID,
+		DayStart:   dayStart,
+		MonthStart: monthStart,
+		Amount:     amount,
+		Check: func(daily, monthly *entities.LimitUsage) error {
+			return checkLimits(limits, daily, monthly, amount)
+		},
+	}, nil
+}
+
+// Reserve проверяет операцию против всех лимитов и сразу учитывает её в использовании.
+// Если операция затем не выполнится, резерв нужно вернуть через Release.
+func (s *limitsService) Reserve(ctx context.Context, userID uint, account *entities.Account, amount float64) (*entities.LimitReservation, error) {
+	charge, err := s.Charge(ctx, userID, account, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Reserve(ctx, charge); err != nil {
+		if errors.Is(err, ErrLimitExceeded) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to reserve limits: %w", err)
+	}
+
+	return &entities.LimitReservation{UserID: userID, Amount: amount, At: charge.DayStart}, nil
+}
+
+// CheckReserved перепроверяет уже зарезервированную операцию перед исполнением по
+// действующему лимиту на одну операцию: правила или уровень KYC могли измениться после
+// резерва. Дневное и месячное использование резерв уже учёл.
+func (s *limitsService) CheckReserved(ctx context.Context, userID uint, account *entities.Account, amount float64) error {
+	limits, err := s.effective(ctx, userID, account.Type)
+	if err != nil {
+		return err
+	}
+	return checkPerTransaction(limits, amount)
+}
+
+// Release возвращает резерв неудавшейся операции. Ошибка только логируется:
+// в худшем случае лимит пользователя до конца периода окажется занижен.
+func (s *limitsService) Release(ctx context.Context, reservation *entities.LimitReservation) {
+	count := 1
+	if reservation.Partial {
+		count = 0
+	}
+	dayStart, monthStart := limitPeriods(reservation.At)
+	if err := s.repo.Release(context.WithoutCancel(ctx), reservation.UserID, dayStart, monthStart, reservation.Amount, count); err != nil {
+		lib.Log.Error("Failed to release limit reservation",
+			zap.Uint("user_id", reservation.UserID),
+			zap.Float64("amount", reservation.Amount),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *limitsService) Rules(ctx context.Context) ([]*entities.LimitRule, error) {
+	rules, err := s.repo.FindRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get limit rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (s *limitsService) SetRule(ctx context.Context, req *entities.SetLimitRuleRequest) (rule *entities.LimitRule, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditLimitSet,
+			ResourceType: "limit_rule",
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.ResourceID = rule.ID
+			event.After = rule
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if req.Scope == entities.LimitScopeTier && req.Value != string(entities.KYCTierBasic) && req.Value != string(entities.KYCTierVerified) {
+		return nil, fmt.Errorf("unknown KYC tier %s", req.Value)
+	}
+
+	rule = &entities.LimitRule{
+		Scope: req.Scope,
+		Value: req.Value,
+		TransferLimits: entities.TransferLimits{
+			MaxPerTransaction: req.MaxPerTransaction,
+			DailyAmount:       req.DailyAmount,
+			DailyCount:        req.DailyCount,
+			MonthlyAmount:     req.MonthlyAmount,
+			MonthlyCount:      req.MonthlyCount,
+		},
+	}
+
+	if err := s.repo.SaveRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save limit rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (s *limitsService) DeleteRule(ctx context.Context, id uint) (err error) {
+	var before *entities.LimitRule
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditLimitDelete,
+			ResourceType: "limit_rule",
+			ResourceID:   id,
+			Before:       before,
+			Err:          err,
+		})
+	}()
+
+	before, err = s.repo.DeleteRule(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrLimitRuleNotFound
+		}
+		return fmt.Errorf("failed to delete limit rule: %w", err)
+	}
+	return nil
+}
+
+// effective накладывает правила от общего к частному: уровень KYC, тип счёта, пользователь
+func (s *limitsService) effective(ctx context.Context, userID uint, accountType string) (entities.TransferLimits, error) {
+	kyc, err := s.kyc.Get(ctx, userID)
+	if err != nil {
+		return entities.TransferLimits{}, err
+	}
+
+	rules, err := s.repo.FindRulesFor(ctx, kyc.Tier, accountType, userID)
+	if err != nil {
+		return entities.TransferLimits{}, fmt.Errorf("failed to get limit rules: %w", err)
+	}
+
+	var limits entities.TransferLimits
+	for _, scope := range entities.LimitScopes {
+		for _, rule := range rules {
+			if rule.Scope == scope {
+				limits = limits.Overlay(rule.TransferLimits)
+			}
+		}
+	}
+	return limits, nil
+}
+
+func checkPerTransaction(limits entities.TransferLimits, amount float64) error {
+	if limits.MaxPerTransaction > 0 && amount > limits.MaxPerTransaction {
+		return &LimitExceededError{Limit: "per_transaction", Max: limits.MaxPerTransaction, Remaining: limits.MaxPerTransaction}
+	}
+	return nil
+}
+
+func checkLimits(limits entities.TransferLimits, daily, monthly *entities.LimitUsage, amount float64) error {
+	if limits.DailyAmount > 0 && daily.Amount+amount > limits.DailyAmount {
+		return &LimitExceededError{Limit: "daily_amount", Max: limits.DailyAmount, Remaining: limits.DailyAmount - daily.Amount}
+	}
+	if limits.DailyCount > 0 && daily.Count+1 > limits.DailyCount {
+		return &LimitExceededError{Limit: "daily_count", Max: float64(limits.DailyCount), Remaining: float64(limits.DailyCount - daily.Count)}
+	}
+	if limits.MonthlyAmount > 0 && monthly.Amount+amount > limits.MonthlyAmount {
+		return &LimitExceededError{Limit: "monthly_amount", Max: limits.MonthlyAmount, Remaining: limits.MonthlyAmount - monthly.Amount}
+	}
+	if limits.MonthlyCount > 0 && monthly.Count+1 > limits.MonthlyCount {
+		return &LimitExceededError{Limit: "monthly_count", Max: float64(limits.MonthlyCount), Remaining: float64(limits.MonthlyCount - monthly.Count)}
+	}
+	return nil
+}
+
+func periodStatus(usage *entities.LimitUsage, maxAmount float64, maxCount int, resetsAt time.Time) entities.LimitPeriodStatus {
+	status := entities.LimitPeriodStatus{
+		UsedAmount: usage.Amount,
+		UsedCount:  usage.Count,
+		ResetsAt:   resetsAt.Format(time.RFC3339),
+	}
+	if maxAmount > 0 {
+		remaining := max(maxAmount-usage.Amount, 0)
+		status.RemainingAmount = &remaining
+	}
+	if maxCount > 0 {
+		remaining := max(maxCount-usage.Count, 0)
+		status.RemainingCount = &remaining
+	}
+	return status
+}
+
+// limitPeriods возвращает начало суток и месяца по UTC, к которым относится момент at
+func limitPeriods(at time.Time) (dayStart, monthStart time.Time) {
+	at = at.UTC()
+	dayStart = time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart = time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return dayStart, monthStart
+}
@@ -0,0 +1,399 @@
+package services
+
+import (
+	"bank-app-backend/internal/config"
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+type LoansService interface {
+	Apply(ctx context.Context, userID uint, req *entities.LoanApplicationRequest) (*entities.Loan, error)
+	List(ctx context.Context, userID uint) ([]*entities.Loan, error)
+	Get(ctx context.Context, userID, loanID uint) (*entities.Loan, error)
+	Balance(ctx context.Context, userID, loanID uint) (*entities.LoanBalance, error)
+	Applications(ctx context.Context, status entities.LoanStatus) ([]*entities.Loan, error)
+	Approve(ctx context.Context, reviewerID, loanID uint, req *entities.ReviewLoanRequest) (*entities.Loan, error)
+	Reject(ctx context.Context, reviewerID, loanID uint, req *entities.ReviewLoanRequest) (*entities.Loan, error)
+	Run(ctx context.Context)
+	RunRepayments(ctx context.Context, businessDate time.Time) (*entities.LoanRepaymentResult, error)
+}
+
+var (
+	ErrLoanNotFound      = errors.New("loan not found")
+	ErrLoanNotPending    = errors.New("loan application is already reviewed")
+	ErrLoanNoteRequired  = errors.New("rejection note is required")
+	ErrLoanAmountInvalid = errors.New("loan amount is out of the allowed range")
+	ErrLoanTermInvalid   = errors.New("loan term is out of the allowed range")
+	// errInsufficientForInstallment — на счёте не хватает средств на платёж, он становится просроченным
+	errInsufficientForInstallment = errors.New("insufficient funds for installment")
+)
+
+type loansService struct {
+	repo       repository.LoansRepository
+	accRepo    repository.AccountsRepository
+	members    AccountMembersService
+	currencies CurrenciesService
+	cfg        config.LoansConfig
+	interval   time.Duration
+	location   *time.Location
+	runs       repository.JobRunsRepository
+	audit      AuditService
+}
+
+func NewLoansService(
+	r repository.LoansRepository,
+	accRepo repository.AccountsRepository,
+	members AccountMembersService,
+	currencies CurrenciesService,
+	cfg config.LoansConfig,
+	jobs config.JobsConfig,
+	runs repository.JobRunsRepository,
+	audit AuditService,
+) LoansService {
+	return &loansService{
+		repo:       r,
+		accRepo:    accRepo,
+		members:    members,
+		currencies: currencies,
+		cfg:        cfg,
+		interval:   jobs.Interval,
+		location:   loadLocation(jobs.Timezone),
+		runs:       runs,
+		audit:      audit,
+	}
+}
+
+// Apply создаёт заявку на кредит по текущей ставке. Деньги зачисляются на счёт только
+// после одобрения, поэтому заявку может подать лишь тот, кто управляет счётом.
+func (s *loansService) Apply(ctx context.Context, userID uint, req *entities.LoanApplicationRequest) (loan *entities.Loan, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditLoanApply,
+			ResourceType: "loan",
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.ResourceID = loan.ID
+			event.After = loan
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	account, _, err := s.members.Authorize(ctx, userID, req.AccountID, entities.PermissionManage)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCredit(account); err != nil {
+		return nil, err
+	}
+	if req.Amount < s.cfg.MinAmount || req.Amount > s.cfg.MaxAmount {
+		return nil, fmt.Errorf("%w: %.2f-%.2f", ErrLoanAmountInvalid, s.cfg.MinAmount, s.cfg.MaxAmount)
+	}
+	if err := s.currencies.CheckAmount(account.Currency, req.Amount); err != nil {
+		return nil, err
+	}
+	if req.TermMonths > s.cfg.MaxTermMonths {
+		return nil, fmt.Errorf("%w: up to %d months", ErrLoanTermInvalid, s.cfg.MaxTermMonths)
+	}
+
+	loan = &entities.Loan{
+		UserID:       userID,
+		AccountID:    req.AccountID,
+		Principal:    entities.RoundAmount(req.Amount, entities.MinorUnitsOf(account.Currency)),
+		InterestRate: s.cfg.InterestRate,
+		TermMonths:   req.TermMonths,
+		ScheduleType: req.ScheduleType,
+		Status:       entities.LoanPending,
+		Purpose:      req.Purpose,
+	}
+	if err := s.repo.Create(ctx, loan); err != nil {
+		return nil, fmt.Errorf("failed to create loan: %w", err)
+	}
+	return loan, nil
+}
+
+func (s *loansService) List(ctx context.Context, userID uint) ([]*entities.Loan, error) {
+	loans, err := s.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loans: %w", err)
+	}
+	return loans, nil
+}
+
+// Get возвращает кредит пользователя вместе с графиком платежей
+func (s *loansService) Get(ctx context.Context, userID, loanID uint) (*entities.Loan, error) {
+	loan, err := s.find(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan.UserID != userID {
+		return nil, ErrLoanNotFound
+	}
+	return loan, nil
+}
+
+// Balance считает задолженность по графику платежей кредита
+func (s *loansService) Balance(ctx context.Context, userID, loanID uint) (*entities.LoanBalance, error) {
+	loan, err := s.Get(ctx, userID, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := &entities.LoanBalance{
+		LoanID:               loan.ID,
+		Status:               loan.Status,
+		OutstandingPrincipal: loan.Outstanding,
+		TotalInstallments:    len(loan.Installments),
+	}
+	for i := range loan.Installments {
+		installment := &loan.Installments[i]
+		if installment.Status == entities.InstallmentPaid {
+			balance.PaidInstallments++
+			continue
+		}
+
+		amount := installment.Amount()
+		balance.RemainingAmount += amount
+		balance.LateFees += installment.LateFee
+		if installment.Status == entities.InstallmentOverdue {
+			balance.OverdueAmount += amount
+		} else if balance.NextDueDate == nil {
+			balance.NextDueDate = &installment.DueDate
+			balance.NextPayment = amount
+		}
+	}
+	balance.RemainingAmount = entities.RoundCents(balance.RemainingAmount)
+	balance.OverdueAmount = entities.RoundCents(balance.OverdueAmount)
+	balance.LateFees = entities.RoundCents(balance.LateFees)
+	return balance, nil
+}
+
+// Applications возвращает кредиты в статусе status, по умолчанию — заявки на рассмотрении
+func (s *loansService) Applications(ctx context.Context, status entities.LoanStatus) ([]*entities.Loan, error) {
+	if status == "" {
+		status = entities.LoanPending
+	}
+
+	loans, err := s.repo.FindByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loans: %w", err)
+	}
+	return loans, nil
+}
+
+// Approve одобряет заявку: сумма кредита зачисляется на счёт транзакцией выдачи,
+// а от даты выдачи строится график платежей
+func (s *loansService) Approve(ctx context.Context, reviewerID, loanID uint, req *entities.ReviewLoanRequest) (loan *entities.Loan, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditLoanReview,
+			ResourceType: "loan",
+			ResourceID:   loanID,
+			Before:       map[string]interface{}{"status": entities.LoanPending},
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.After = loan
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	loan, err = s.repo.Disburse(ctx, loanID, func(loan *entities.Loan, account *entities.Account) error {
+		if loan.Status != entities.LoanPending {
+			return ErrLoanNotPending
+		}
+		if err := checkCredit(account); err != nil {
+			return err
+		}
+		loan.ReviewedBy = &reviewerID
+		loan.ReviewNote = req.Note
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLoanNotFound
+		}
+		var statusErr *AccountStatusError
+		if errors.Is(err, ErrLoanNotPending) || errors.As(err, &statusErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to disburse loan: %w", err)
+	}
+	s.invalidate(ctx, loan.AccountID)
+
+	lib.Log.Info("Loan disbursed",
+		zap.Uint("loan_id", loan.ID),
+		zap.Uint("account_id", loan.AccountID),
+		zap.Uint("reviewer_id", reviewerID),
+		zap.Float64("principal", loan.Principal),
+	)
+	return loan, nil
+}
+
+// Reject отклоняет заявку; причина обязательна
+func (s *loansService) Reject(ctx context.Context, reviewerID, loanID uint, req *entities.ReviewLoanRequest) (loan *entities.Loan, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditLoanReview,
+			ResourceType: "loan",
+			ResourceID:   loanID,
+			Before:       map[string]interface{}{"status": entities.LoanPending},
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.After = loan
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if strings.TrimSpace(req.Note) == "" {
+		return nil, ErrLoanNoteRequired
+	}
+
+	loan, err = s.find(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Status != entities.LoanPending {
+		return nil, ErrLoanNotPending
+	}
+
+	loan.Status = entities.LoanRejected
+	loan.ReviewedBy = &reviewerID
+	loan.ReviewNote = req.Note
+	if err := s.repo.Update(ctx, loan); err != nil {
+		return nil, fmt.Errorf("failed to update loan: %w", err)
+	}
+	return loan, nil
+}
+
+// Run списывает платежи по кредитам за каждый завершившийся операционный день
+func (s *loansService) Run(ctx context.Context) {
+	runDaily(ctx, "loan_repayments", s.interval, s.location, s.runs, func(ctx context.Context, date time.Time) error {
+		_, err := s.RunRepayments(ctx, date)
+		return err
+	})
+}
+
+// RunRepayments списывает со счетов платежи со сроком по businessDate включительно.
+// Платёж, на который не хватает доступных средств, становится просроченным и
+// повторяется в следующие дни; спустя GraceDays дней к нему один раз добавляется штраф.
+// Нулевая дата означает последний завершившийся день.
+func (s *loansService) RunRepayments(ctx context.Context, businessDate time.Time) (result *entities.LoanRepaymentResult, err error) {
+	if businessDate.IsZero() {
+		businessDate = previousBusinessDate(time.Now(), s.location)
+	}
+	date := time.Date(businessDate.Year(), businessDate.Month(), businessDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditLoanRepay,
+			ResourceType: "loan_repayment",
+			ResourceID:   date.Format(entities.BusinessDateLayout),
+			After:        result,
+			Err:          err,
+		})
+	}()
+
+	if date.After(previousBusinessDate(time.Now(), s.location)) {
+		return nil, ErrBusinessDateNotOver
+	}
+
+	installments, err := s.repo.FindDueInstallments(ctx, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due installments: %w", err)
+	}
+
+	result = &entities.LoanRepaymentResult{BusinessDate: date.Format(entities.BusinessDateLayout)}
+	for _, installment := range installments {
+		if err := s.repay(ctx, result, installment, date); err != nil {
+			return nil, err
+		}
+	}
+
+	lib.Log.Info("Loan repayment job finished",
+		zap.String("business_date", result.BusinessDate),
+		zap.Int("paid", result.Paid),
+		zap.Int("overdue", result.Overdue),
+		zap.Int("late_fees", result.LateFees),
+	)
+	return result, nil
+}
+
+// repay списывает платёж или, если средств не хватает, отмечает его просроченным
+func (s *loansService) repay(ctx context.Context, result *entities.LoanRepaymentResult, installment *entities.LoanInstallment, date time.Time) error {
+	var accountID uint
+	var alreadyPaid bool
+	_, err := s.repo.Repay(ctx, installment.ID, func(installment *entities.LoanInstallment, account *entities.Account) error {
+		accountID = account.ID
+		if installment.Status == entities.InstallmentPaid {
+			alreadyPaid = true
+			return errInsufficientForInstallment
+		}
+		if err := s.currencies.CheckAmount(account.Currency, installment.Amount()); err != nil {
+			return err
+		}
+		if checkDebit(account) != nil || account.Available() < installment.Amount() {
+			return errInsufficientForInstallment
+		}
+		return nil
+	})
+	if err == nil {
+		result.Paid++
+		s.invalidate(ctx, accountID)
+		return nil
+	}
+	if !errors.Is(err, errInsufficientForInstallment) {
+		return fmt.Errorf("failed to repay installment %d: %w", installment.ID, err)
+	}
+	if alreadyPaid {
+		return nil
+	}
+
+	lateFee := 0.0
+	if installment.LateFee == 0 && s.cfg.LateFee > 0 && date.Sub(installment.DueDate) > time.Duration(s.cfg.GraceDays)*24*time.Hour {
+		lateFee = s.cfg.LateFee
+	}
+	if err := s.repo.MarkOverdue(ctx, installment.ID, lateFee); err != nil {
+		return fmt.Errorf("failed to mark installment %d overdue: %w", installment.ID, err)
+	}
+
+	result.Overdue++
+	if lateFee > 0 {
+		result.LateFees++
+		lib.Log.Info("Loan late fee charged",
+			zap.Uint("loan_id", installment.LoanID),
+			zap.Int("installment", installment.Number),
+			zap.Float64("late_fee", lateFee),
+		)
+	}
+	return nil
+}
+
+func (s *loansService) find(ctx context.Context, loanID uint) (*entities.Loan, error) {
+	loan, err := s.repo.FindByID(ctx, loanID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLoanNotFound
+		}
+		return nil, fmt.Errorf("failed to get loan: %w", err)
+	}
+	return loan, nil
+}
+
+func (s *loansService) invalidate(ctx context.Context, accountID uint) {
+	if err := s.accRepo.Invalidate(ctx, accountID); err != nil {
+		lib.Log.Warn("Failed to invalidate account cache", zap.Uint("account_id", accountID), zap.Error(err))
+	}
+}
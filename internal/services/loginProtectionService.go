@@ -0,0 +1,167 @@
+package services
+
+import (
+	"bank-app-backend/internal/config"
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+// LoginThrottledError возвращается, когда попытка входа отклонена
+// из-за задержки или временной блокировки
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed login attempts, try again in %s", e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("too many login attempts, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+type LoginProtectionService interface {
+	Check(ctx context.Context, email, ip string) error
+	RegisterFailure(ctx context.Context, email, ip string) error
+	RegisterSuccess(ctx context.Context, email string) error
+	Unlock(ctx context.Context, email, ip string) error
+}
+
+type loginProtectionService struct {
+	repo  repository.LoginAttemptsRepository
+	cfg   config.LoginProtectionConfig
+	audit AuditService
+}
+
+func NewLoginProtectionService(r repository.LoginAttemptsRepository, cfg config.LoginProtectionConfig, audit AuditService) LoginProtectionService {
+	return &loginProtectionService{
+		repo:  r,
+		cfg:   cfg,
+		audit: audit,
+	}
+}
+
+// Check проверяет, разрешена ли сейчас попытка входа для email и IP
+func (s *loginProtectionService) Check(ctx context.Context, email, ip string) error {
+	for _, subject := range loginSubjects(email, ip) {
+		locked, err := s.repo.GetLock(ctx, subject)
+		if err != nil {
+			return fmt.Errorf("could not check login lock: %v", err)
+		}
+		if locked > 0 {
+			return &LoginThrottledError{RetryAfter: locked, Locked: true}
+		}
+
+		delay, err := s.repo.GetDelay(ctx, subject)
+		if err != nil {
+			return fmt.Errorf("could not check login delay: %v", err)
+		}
+		if delay > 0 {
+			return &LoginThrottledError{RetryAfter: delay}
+		}
+	}
+
+	return nil
+}
+
+// RegisterFailure увеличивает счётчики неудачных попыток, назначает
+// прогрессивную задержку и блокирует вход при превышении порога
+func (s *loginProtectionService) RegisterFailure(ctx context.Context, email, ip string) error {
+	for _, subject := range loginSubjects(email, ip) {
+		count, err := s.repo.IncrementFailures(ctx, subject, s.cfg.AttemptWindow)
+		if err != nil {
+			return fmt.Errorf("could not register failed login: %v", err)
+		}
+
+		limit := s.cfg.MaxAttemptsPerEmail
+		if strings.HasPrefix(subject, "ip:") {
+			limit = s.cfg.MaxAttemptsPerIP
+		}
+
+		if limit > 0 && int(count) >= limit {
+			if err := s.repo.Lock(ctx, subject, s.cfg.LockoutDuration); err != nil {
+				return fmt.Errorf("could not lock login: %v", err)
+			}
+			lib.Log.Warn("Login locked out",
+				zap.String("subject", subject),
+				zap.Int64("failed_attempts", count),
+				zap.Duration("duration", s.cfg.LockoutDuration),
+			)
+			continue
+		}
+
+		if delay := s.delayFor(int(count)); delay > 0 {
+			if err := s.repo.SetDelay(ctx, subject, delay); err != nil {
+				return fmt.Errorf("could not set login delay: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RegisterSuccess сбрасывает счётчики для email после успешного входа.
+// Счётчики по IP не сбрасываются, чтобы один валидный аккаунт
+// не позволял обнулять ограничение для перебора других.
+func (s *loginProtectionService) RegisterSuccess(ctx context.Context, email string) error {
+	return s.repo.ResetFailures(ctx, emailSubject(email))
+}
+
+// Unlock снимает блокировку с email и/или IP
+func (s *loginProtectionService) Unlock(ctx context.Context, email, ip string) (err error) {
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditLoginUnlock,
+			ResourceType: "login",
+			After:        map[string]string{"email": email, "ip": ip},
+			Err:          err,
+		})
+	}()
+
+	for _, subject := range loginSubjects(email, ip) {
+		if err := s.repo.Unlock(ctx, subject); err != nil {
+			return fmt.Errorf("could not unlock %s: %v", subject, err)
+		}
+		lib.Log.Info("Login lock removed", zap.String("subject", subject))
+	}
+
+	return nil
+}
+
+// delayFor вычисляет задержку, удваивающуюся с каждой попыткой сверх DelayAfter
+func (s *loginProtectionService) delayFor(failures int) time.Duration {
+	if failures <= s.cfg.DelayAfter || s.cfg.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := s.cfg.BaseDelay
+	for i := s.cfg.DelayAfter + 1; i < failures; i++ {
+		delay *= 2
+		if s.cfg.MaxDelay > 0 && delay >= s.cfg.MaxDelay {
+			return s.cfg.MaxDelay
+		}
+	}
+
+	return delay
+}
+
+func emailSubject(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func loginSubjects(email, ip string) []string {
+	var result []string
+	if email != "" {
+		result = append(result, emailSubject(email))
+	}
+	if ip != "" {
+		result = append(result, "ip:"+ip)
+	}
+	return result
+}
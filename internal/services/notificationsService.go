@@ -0,0 +1,309 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/lib/notify"
+	"bank-app-backend/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type NotificationsService interface {
+	HandleEvent(ctx context.Context, topic string, key, value []byte) error
+	Notify(ctx context.Context, userID uint, event entities.NotificationEvent, title, body string) error
+	List(ctx context.Context, filter *entities.NotificationFilter) (*entities.NotificationsPage, error)
+	MarkRead(ctx context.Context, userID, id uint) error
+	MarkAllRead(ctx context.Context, userID uint) error
+	Preferences(ctx context.Context, userID uint) (*entities.NotificationPreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, userID uint, req *entities.UpdateNotificationPreferencesRequest) (*entities.NotificationPreferencesResponse, error)
+}
+
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrInvalidNotificationPref = errors.New("invalid notification preference")
+)
+
+type notificationsService struct {
+	repo      repository.NotificationsRepository
+	usersRepo repository.UsersRepository
+	senders   map[entities.NotificationChannel]notify.Sender
+	defaults  map[entities.NotificationChannel]bool
+}
+
+// NewNotificationsService создаёт сервис уведомлений. senders — внешние каналы
+// (email, SMS, push), defaultChannels — каналы, включённые, пока пользователь не настроил иначе.
+func NewNotificationsService(
+	r repository.NotificationsRepository,
+	usersRepo repository.UsersRepository,
+	senders map[entities.NotificationChannel]notify.Sender,
+	defaultChannels []entities.NotificationChannel,
+) NotificationsService {
+	defaults := make(map[entities.NotificationChannel]bool, len(defaultChannels))
+	for _, channel := range defaultChannels {
+		defaults[channel] = true
+	}
+
+	return &notificationsService{
+		repo:      r,
+		usersRepo: usersRepo,
+		senders:   senders,
+		defaults:  defaults,
+	}
+}
+
+// HandleEvent превращает доменное событие из Kafka в уведомление пользователя.
+// События неизвестных топиков пропускаются.
+func (s *notificationsService) HandleEvent(ctx context.Context, topic string, _, value []byte) error {
+	switch topic {
+	case entities.TopicAccountCreated:
+		var event entities.AccountCreatedEvent
+		if err := json.Unmarshal(value, &event); err != nil {
+			return fmt.Errorf("invalid %s event: %w", topic, err)
+		}
+		return s.Notify(ctx, event.UserID, entities.NotificationAccountCreated,
+			"Счёт открыт",
+			fmt.Sprintf("Открыт счёт №%d (%s, %s)", event.AccountID, event.Type, event.Currency),
+		)
+
+	case entities.TopicTransactionCompleted:
+		var event entities.TransactionCompletedEvent
+		if err := json.Unmarshal(value, &event); err != nil {
+			return fmt.Errorf("invalid %s event: %w", topic, err)
+		}
+		if err := s.Notify(ctx, event.UserID, entities.NotificationTransactionCompleted,
+			"Операция выполнена",
+			transactionNotificationBody(event),
+		); err != nil {
+			return err
+		}
+		// Владелец счёта зачисления узнаёт о поступлении, если операцию выполнил не он
+		if event.RecipientID == 0 || event.RecipientID == event.UserID {
+			return nil
+		}
+		return s.Notify(ctx, event.RecipientID, entities.NotificationTransactionCompleted,
+			"Поступление на счёт",
+			fmt.Sprintf("На счёт №%d зачислено %.2f", event.ToAccountID, event.Amount),
+		)
+
+	case entities.TopicAccountOverdrawn:
+		var event entities.AccountOverdrawnEvent
+		if err := json.Unmarshal(value, &event); err != nil {
+			return fmt.Errorf("invalid %s event: %w", topic, err)
+		}
+		return s.Notify(ctx, event.UserID, entities.NotificationAccountOverdrawn,
+			"Счёт в овердрафте",
+			fmt.Sprintf("Остаток счёта №%d стал отрицательным: %.2f %s. На задолженность начисляются проценты, лимит овердрафта %.2f %s",
+				event.AccountID, event.Balance, event.Currency, event.OverdraftLimit, event.Currency),
+		)
+	}
+
+	return nil
+}
+
+// Notify доставляет уведомление по всем каналам, включённым у пользователя для события.
+// Ошибка внешнего канала логируется и не мешает доставке в остальные.
+func (s *notificationsService) Notify(ctx context.Context, userID uint, event entities.NotificationEvent, title, body string) error {
+	enabled, err := s.enabledChannels(ctx, userID, event)
+	if err != nil {
+		return err
+	}
+
+	if enabled[entities.ChannelInApp] {
+		notification := &entities.Notification{
+			UserID: userID,
+			Event:  event,
+			Title:  title,
+			Body:   body,
+		}
+		if err := s.repo.Create(ctx, notification); err != nil {
+			return fmt.Errorf("failed to save notification: %w", err)
+		}
+	}
+
+	var user *entities.User
+	for channel, sender := range s.senders {
+		if !enabled[channel] {
+			continue
+		}
+
+		if user == nil {
+			if user, err = s.usersRepo.FindByID(ctx, userID); err != nil {
+				return fmt.Errorf("user not found: %v", err)
+			}
+			if user.ErasedAt != nil {
+				return nil
+			}
+		}
+
+		msg := notify.Message{
+			UserID:  userID,
+			To:      recipient(user, channel),
+			Event:   string(event),
+			Subject: title,
+			Body:    body,
+		}
+		if err := sender.Send(ctx, msg); err != nil {
+			lib.Log.Error("Failed to send notification",
+				zap.String("channel", string(channel)),
+				zap.Uint("user_id", userID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *notificationsService) List(ctx context.Context, filter *entities.NotificationFilter) (*entities.NotificationsPage, error) {
+	items, total, err := s.repo.FindByUser(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	unread, err := s.repo.CountUnread(ctx, filter.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return &entities.NotificationsPage{
+		Items:  items,
+		Total:  total,
+		Unread: unread,
+		Page:   filter.Page,
+		Limit:  filter.Limit,
+	}, nil
+}
+
+func (s *notificationsService) MarkRead(ctx context.Context, userID, id uint) error {
+	if err := s.repo.MarkRead(ctx, userID, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotificationNotFound
+		}
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+func (s *notificationsService) MarkAllRead(ctx context.Context, userID uint) error {
+	if err := s.repo.MarkAllRead(ctx, userID); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+// Preferences возвращает итоговую матрицу событие × канал с учётом значений по умолчанию
+func (s *notificationsService) Preferences(ctx context.Context, userID uint) (*entities.NotificationPreferencesResponse, error) {
+	resp := &entities.NotificationPreferencesResponse{}
+
+	for _, event := range entities.NotificationEvents {
+		enabled, err := s.enabledChannels(ctx, userID, event)
+		if err != nil {
+			return nil, err
+		}
+		for _, channel := range s.channels() {
+			resp.Preferences = append(resp.Preferences, entities.NotificationPreference{
+				Event:   event,
+				Channel: channel,
+				Enabled: enabled[channel],
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *notificationsService) UpdatePreferences(ctx context.Context, userID uint, req *entities.UpdateNotificationPreferencesRequest) (*entities.NotificationPreferencesResponse, error) {
+	prefs := make([]*entities.NotificationPreference, 0, len(req.Preferences))
+	for _, p := range req.Preferences {
+		if !isKnownNotificationEvent(p.Event) {
+			return nil, fmt.Errorf("%w: unknown event %s", ErrInvalidNotificationPref, p.Event)
+		}
+		if !s.isAvailableChannel(p.Channel) {
+			return nil, fmt.Errorf("%w: unknown channel %s", ErrInvalidNotificationPref, p.Channel)
+		}
+
+		prefs = append(prefs, &entities.NotificationPreference{
+			UserID:  userID,
+			Event:   p.Event,
+			Channel: p.Channel,
+			Enabled: p.Enabled,
+		})
+	}
+
+	if err := s.repo.SavePreferences(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return s.Preferences(ctx, userID)
+}
+
+// enabledChannels применяет настройки пользователя поверх каналов по умолчанию
+func (s *notificationsService) enabledChannels(ctx context.Context, userID uint, event entities.NotificationEvent) (map[entities.NotificationChannel]bool, error) {
+	prefs, err := s.repo.FindPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	enabled := make(map[entities.NotificationChannel]bool, len(s.defaults))
+	for channel, on := range s.defaults {
+		enabled[channel] = on
+	}
+	for _, pref := range prefs {
+		if pref.Event == event {
+			enabled[pref.Channel] = pref.Enabled
+		}
+	}
+
+	return enabled, nil
+}
+
+// channels — каналы, доступные в этой инсталляции: in-app и настроенные внешние
+func (s *notificationsService) channels() []entities.NotificationChannel {
+	var channels []entities.NotificationChannel
+	for _, channel := range entities.NotificationChannels {
+		if s.isAvailableChannel(channel) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+func (s *notificationsService) isAvailableChannel(channel entities.NotificationChannel) bool {
+	if channel == entities.ChannelInApp {
+		return true
+	}
+	_, ok := s.senders[channel]
+	return ok
+}
+
+func isKnownNotificationEvent(event entities.NotificationEvent) bool {
+	for _, known := range entities.NotificationEvents {
+		if known == event {
+			return true
+		}
+	}
+	return false
+}
+
+// recipient возвращает адрес получателя в канале. Телефонов и токенов устройств
+// пока нет, поэтому SMS и push адресуются идентификатором пользователя.
+func recipient(user *entities.User, channel entities.NotificationChannel) string {
+	if channel == entities.ChannelEmail {
+		return user.Email
+	}
+	return fmt.Sprintf("user:%d", user.ID)
+}
+
+func transactionNotificationBody(event entities.TransactionCompletedEvent) string {
+	switch event.Type {
+	case entities.Deposit:
+		return fmt.Sprintf("Счёт №%d пополнен на %.2f", event.ToAccountID, event.Amount)
+	default:
+		return fmt.Sprintf("Перевод %.2f со счёта №%d на счёт №%d", event.Amount, event.FromAccountID, event.ToAccountID)
+	}
+}
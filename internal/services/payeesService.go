@@ -0,0 +1,199 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+type PayeesService interface {
+	List(ctx context.Context, userID uint) ([]*entities.Payee, error)
+	Get(ctx context.Context, userID, id uint) (*entities.Payee, error)
+	Create(ctx context.Context, userID uint, req *entities.CreatePayeeRequest) (*entities.Payee, error)
+	Update(ctx context.Context, userID, id uint, req *entities.UpdatePayeeRequest) (*entities.Payee, error)
+	Delete(ctx context.Context, userID, id uint) error
+	CheckTransfer(ctx context.Context, userID uint, req *entities.TransferRequest) error
+}
+
+var (
+	ErrPayeeNotFound        = errors.New("payee not found")
+	ErrPayeeExists          = errors.New("payee with this account already exists")
+	ErrPayeeAccountNotFound = errors.New("payee account not found")
+	ErrPayeeCoolingOff      = errors.New("payee is in the cooling-off period")
+)
+
+type payeesService struct {
+	repo       repository.PayeesRepository
+	accRepo    repository.AccountsRepository
+	coolingOff time.Duration
+	audit      AuditService
+}
+
+func NewPayeesService(
+	r repository.PayeesRepository,
+	accRepo repository.AccountsRepository,
+	coolingOff time.Duration,
+	audit AuditService,
+) PayeesService {
+	return &payeesService{
+		repo:       r,
+		accRepo:    accRepo,
+		coolingOff: coolingOff,
+		audit:      audit,
+	}
+}
+
+func (s *payeesService) List(ctx context.Context, userID uint) ([]*entities.Payee, error) {
+	payees, err := s.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payees: %w", err)
+	}
+	return payees, nil
+}
+
+func (s *payeesService) Get(ctx context.Context, userID, id uint) (*entities.Payee, error) {
+	payee, err := s.repo.FindByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPayeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get payee: %w", err)
+	}
+	return payee, nil
+}
+
+// Create сохраняет получателя после проверки, что счёт существует, открыт и валюта совпадает
+func (s *payeesService) Create(ctx context.Context, userID uint, req *entities.CreatePayeeRequest) (payee *entities.Payee, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditPayeeCreate,
+			ResourceType: "payee",
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.ResourceID = payee.ID
+			event.After = payee
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	account, err := s.accRepo.FindByID(ctx, req.AccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPayeeAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	// Закрытый счёт и счёт в другой валюте неотличимы от несуществующего, чтобы по ответам
+	// нельзя было перебрать номера счетов и узнать их валюты
+	currency := strings.ToUpper(req.Currency)
+	if account.Status == entities.AccountClosed || account.Currency != currency {
+		return nil, ErrPayeeAccountNotFound
+	}
+
+	if _, err := s.repo.FindByAccount(ctx, userID, req.AccountID); err == nil {
+		return nil, ErrPayeeExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check payees: %w", err)
+	}
+
+	now := time.Now()
+	payee = &entities.Payee{
+		UserID:     userID,
+		Nickname:   strings.TrimSpace(req.Nickname),
+		AccountID:  req.AccountID,
+		Currency:   currency,
+		ActiveFrom: now.Add(s.coolingOff),
+	}
+
+	if err := s.repo.Create(ctx, payee); err != nil {
+		return nil, fmt.Errorf("failed to save payee: %w", err)
+	}
+
+	return payee, nil
+}
+
+func (s *payeesService) Update(ctx context.Context, userID, id uint, req *entities.UpdatePayeeRequest) (payee *entities.Payee, err error) {
+	var before entities.Payee
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditPayeeUpdate,
+			ResourceType: "payee",
+			ResourceID:   id,
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.Before = before
+			event.After = payee
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	payee, err = s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	before = *payee
+
+	payee.Nickname = strings.TrimSpace(req.Nickname)
+	if err := s.repo.Update(ctx, payee); err != nil {
+		return nil, fmt.Errorf("failed to update payee: %w", err)
+	}
+
+	return payee, nil
+}
+
+func (s *payeesService) Delete(ctx context.Context, userID, id uint) (err error) {
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditPayeeDelete,
+			ResourceType: "payee",
+			ResourceID:   id,
+			Err:          err,
+		})
+	}()
+
+	if err := s.repo.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPayeeNotFound
+		}
+		return fmt.Errorf("failed to delete payee: %w", err)
+	}
+	return nil
+}
+
+// CheckTransfer подставляет счёт получателя, если перевод задан через PayeeID,
+// и запрещает переводы получателям, у которых не истёк период охлаждения
+func (s *payeesService) CheckTransfer(ctx context.Context, userID uint, req *entities.TransferRequest) error {
+	var payee *entities.Payee
+	var err error
+
+	if req.PayeeID != 0 {
+		payee, err = s.Get(ctx, userID, req.PayeeID)
+		if err != nil {
+			return err
+		}
+		req.ToAccountID = payee.AccountID
+	} else {
+		payee, err = s.repo.FindByAccount(ctx, userID, req.ToAccountID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check payees: %w", err)
+		}
+	}
+
+	if time.Now().Before(payee.ActiveFrom) {
+		return fmt.Errorf("%w until %s", ErrPayeeCoolingOff, payee.ActiveFrom.Format(time.RFC3339))
+	}
+
+	return nil
+}
@@ -0,0 +1,392 @@
+package services
+
+import (
+	"bank-app-backend/internal/config"
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PotsService interface {
+	List(ctx context.Context, userID, accountID uint) ([]*entities.Pot, error)
+	Create(ctx context.Context, userID, accountID uint, req *entities.CreatePotRequest) (*entities.Pot, error)
+	Update(ctx context.Context, userID, potID uint, req *entities.UpdatePotRequest) (*entities.Pot, error)
+	Delete(ctx context.Context, userID, potID uint) error
+	Deposit(ctx context.Context, userID, potID uint, amount float64) (*entities.Pot, error)
+	Withdraw(ctx context.Context, userID, potID uint, amount float64) (*entities.Pot, error)
+	RoundUp(ctx context.Context, account *entities.Account, amount float64)
+	Run(ctx context.Context)
+	SaveWeekly(ctx context.Context, businessDate time.Time) (int, error)
+}
+
+var (
+	ErrPotNotFound          = errors.New("pot not found")
+	ErrPotInsufficientFunds = errors.New("not enough money in the pot")
+	ErrPotTargetDatePast    = errors.New("pot target date is in the past")
+
+	errPotSavedForDay = errors.New("pot already saved for the day")
+)
+
+// Правила, по которым копилка пополняется автоматически; пишутся в журнал аудита
+const (
+	potRuleWeekly  = "weekly"
+	potRuleRoundUp = "round_up"
+)
+
+type potsService struct {
+	repo       repository.PotsRepository
+	accRepo    repository.AccountsRepository
+	members    AccountMembersService
+	currencies CurrenciesService
+	interval   time.Duration
+	location   *time.Location
+	runs       repository.JobRunsRepository
+	audit      AuditService
+}
+
+func NewPotsService(
+	r repository.PotsRepository,
+	accRepo repository.AccountsRepository,
+	members AccountMembersService,
+	currencies CurrenciesService,
+	jobs config.JobsConfig,
+	runs repository.JobRunsRepository,
+	audit AuditService,
+) PotsService {
+	return &potsService{
+		repo:       r,
+		accRepo:    accRepo,
+		members:    members,
+		currencies: currencies,
+		interval:   jobs.Interval,
+		location:   loadLocation(jobs.Timezone),
+		runs:       runs,
+		audit:      audit,
+	}
+}
+
+func (s *potsService) List(ctx context.Context, userID, accountID uint) ([]*entities.Pot, error) {
+	if _, _, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionView); err != nil {
+		return nil, err
+	}
+
+	pots, err := s.repo.FindByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pots: %w", err)
+	}
+	return pots, nil
+}
+
+func (s *potsService) Create(ctx context.Context, userID, accountID uint, req *entities.CreatePotRequest) (pot *entities.Pot, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditPotCreate,
+			ResourceType: "pot",
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.ResourceID = pot.ID
+			event.After = pot
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	account, _, err := s.members.Authorize(ctx, userID, accountID, entities.PermissionSpend)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status == entities.AccountClosed {
+		return nil, &AccountStatusError{AccountID: account.ID, Status: account.Status}
+	}
+
+	targetDate, err := s.parseTargetDate(req.TargetDate)
+	if err != nil {
+		return nil, err
+	}
+
+	pot = &entities.Pot{
+		AccountID:    accountID,
+		UserID:       userID,
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		TargetDate:   targetDate,
+		WeeklyAmount: req.WeeklyAmount,
+		WeeklyDay:    time.Weekday(req.WeeklyDay),
+		RoundUp:      req.RoundUp,
+	}
+
+	if err := s.repo.Create(ctx, pot); err != nil {
+		return nil, fmt.Errorf("failed to create pot: %w", err)
+	}
+	return pot, nil
+}
+
+func (s *potsService) Update(ctx context.Context, userID, potID uint, req *entities.UpdatePotRequest) (pot *entities.Pot, err error) {
+	var before entities.Pot
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditPotUpdate,
+			ResourceType: "pot",
+			ResourceID:   potID,
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.Before = before
+			event.After = pot
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	pot, err = s.authorizedPot(ctx, userID, potID)
+	if err != nil {
+		return nil, err
+	}
+	before = *pot
+
+	if req.Name != nil {
+		pot.Name = *req.Name
+	}
+	if req.TargetAmount != nil {
+		pot.TargetAmount = *req.TargetAmount
+	}
+	if req.TargetDate != nil {
+		if pot.TargetDate, err = s.parseTargetDate(*req.TargetDate); err != nil {
+			return nil, err
+		}
+	}
+	if req.WeeklyAmount != nil {
+		pot.WeeklyAmount = *req.WeeklyAmount
+	}
+	if req.WeeklyDay != nil {
+		pot.WeeklyDay = time.Weekday(*req.WeeklyDay)
+	}
+	if req.RoundUp != nil {
+		pot.RoundUp = *req.RoundUp
+	}
+
+	if err := s.repo.Update(ctx, pot); err != nil {
+		return nil, fmt.Errorf("failed to update pot: %w", err)
+	}
+	return pot, nil
+}
+
+// Delete удаляет копилку; её деньги остаются на счёте и снова доступны для списаний
+func (s *potsService) Delete(ctx context.Context, userID, potID uint) (err error) {
+	var deleted *entities.Pot
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditPotDelete,
+			ResourceType: "pot",
+			ResourceID:   potID,
+			Before:       deleted,
+			Err:          err,
+		})
+	}()
+
+	if _, err := s.authorizedPot(ctx, userID, potID); err != nil {
+		return err
+	}
+
+	deleted, err = s.repo.Delete(ctx, potID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPotNotFound
+		}
+		return fmt.Errorf("failed to delete pot: %w", err)
+	}
+
+	s.invalidate(ctx, deleted.AccountID)
+	return nil
+}
+
+// Deposit откладывает amount из доступного остатка счёта в копилку
+func (s *potsService) Deposit(ctx context.Context, userID, potID uint, amount float64) (*entities.Pot, error) {
+	if _, err := s.authorizedPot(ctx, userID, potID); err != nil {
+		return nil, err
+	}
+	return s.move(ctx, potID, amount, "", nil)
+}
+
+// Withdraw возвращает amount из копилки в доступный остаток счёта
+func (s *potsService) Withdraw(ctx context.Context, userID, potID uint, amount float64) (*entities.Pot, error) {
+	if _, err := s.authorizedPot(ctx, userID, potID); err != nil {
+		return nil, err
+	}
+	return s.move(ctx, potID, -amount, "", nil)
+}
+
+// RoundUp откладывает в копилку счёта с включённым округлением разницу между списанием
+// amount и ближайшей большей кратной суммой. Списание к этому моменту уже проведено,
+// поэтому ошибки только логируются.
+func (s *potsService) RoundUp(ctx context.Context, account *entities.Account, amount float64) {
+	pot, err := s.repo.FindRoundUp(ctx, account.ID)
+	if err != nil {
+		lib.Log.Error("Failed to get round-up pot", zap.Uint("account_id", account.ID), zap.Error(err))
+		return
+	}
+	if pot == nil {
+		return
+	}
+
+	extra := pot.RoundUpOf(amount)
+	if extra == 0 {
+		return
+	}
+
+	if _, err := s.move(ctx, pot.ID, extra, potRuleRoundUp, nil); err != nil {
+		lib.Log.Warn("Round-up to pot skipped", zap.Uint("pot_id", pot.ID), zap.Error(err))
+	}
+}
+
+// Run раз за операционный день выполняет еженедельные пополнения копилок
+func (s *potsService) Run(ctx context.Context) {
+	runDaily(ctx, "pots", s.interval, s.location, s.runs, func(ctx context.Context, date time.Time) error {
+		_, err := s.SaveWeekly(ctx, date)
+		return err
+	})
+}
+
+// SaveWeekly пополняет копилки, у которых день еженедельного пополнения приходится на
+// businessDate, и возвращает их число. Если на счёте не хватает денег, пополнение за этот
+// день пропускается.
+func (s *potsService) SaveWeekly(ctx context.Context, businessDate time.Time) (int, error) {
+	pots, err := s.repo.FindWeeklyDue(ctx, businessDate)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pots: %w", err)
+	}
+
+	saved := 0
+	for _, pot := range pots {
+		_, err := s.move(ctx, pot.ID, pot.WeeklyAmount, potRuleWeekly, func(p *entities.Pot) error {
+			if p.LastWeeklyOn != nil && !p.LastWeeklyOn.Before(businessDate) {
+				return errPotSavedForDay
+			}
+			p.LastWeeklyOn = &businessDate
+			return nil
+		})
+		if err != nil {
+			if !errors.Is(err, errPotSavedForDay) {
+				lib.Log.Warn("Weekly pot saving skipped", zap.Uint("pot_id", pot.ID), zap.Error(err))
+			}
+			continue
+		}
+		saved++
+	}
+
+	if saved > 0 {
+		lib.Log.Info("Weekly pot savings made", zap.Int("count", saved))
+	}
+	return saved, nil
+}
+
+// move переводит amount со счёта в копилку или, при отрицательном amount, обратно.
+// В копилку откладываются только собственные деньги счёта, без овердрафта. rule —
+// правило автопополнения или пустая строка для операции клиента; prepare вызывается
+// под блокировкой копилки и может изменить её поля.
+func (s *potsService) move(ctx context.Context, potID uint, amount float64, rule string, prepare func(pot *entities.Pot) error) (pot *entities.Pot, err error) {
+	defer func() {
+		after := map[string]interface{}{"amount": amount}
+		if rule != "" {
+			after["rule"] = rule
+		}
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditPotMove,
+			ResourceType: "pot",
+			ResourceID:   potID,
+			After:        after,
+			Err:          err,
+		})
+	}()
+
+	pot, err = s.repo.Move(ctx, potID, amount, func(p *entities.Pot, account *entities.Account) error {
+		if err := s.currencies.CheckAmount(account.Currency, amount); err != nil {
+			return err
+		}
+		if prepare != nil {
+			if err := prepare(p); err != nil {
+				return err
+			}
+		}
+
+		if amount > 0 {
+			if err := checkDebit(account); err != nil {
+				return err
+			}
+			if account.Balance-account.Held-account.Pots < amount {
+				return ErrInsufficientFunds
+			}
+			return nil
+		}
+
+		if err := checkCredit(account); err != nil {
+			return err
+		}
+		if p.Balance < -amount {
+			return ErrPotInsufficientFunds
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPotNotFound
+		}
+		if errors.Is(err, ErrPotNotFound) || errors.Is(err, ErrAccountInactive) ||
+			errors.Is(err, ErrInsufficientFunds) || errors.Is(err, ErrPotInsufficientFunds) ||
+			errors.Is(err, ErrInvalidAmountPrecision) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to move money to pot: %w", err)
+	}
+
+	s.invalidate(ctx, pot.AccountID)
+	return pot, nil
+}
+
+// authorizedPot возвращает копилку, если пользователь может распоряжаться её счётом
+func (s *potsService) authorizedPot(ctx context.Context, userID, potID uint) (*entities.Pot, error) {
+	pot, err := s.repo.FindByID(ctx, potID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPotNotFound
+		}
+		return nil, fmt.Errorf("failed to get pot: %w", err)
+	}
+
+	if _, _, err := s.members.Authorize(ctx, userID, pot.AccountID, entities.PermissionSpend); err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			return nil, ErrPotNotFound
+		}
+		return nil, err
+	}
+	return pot, nil
+}
+
+// parseTargetDate разбирает дату цели; пустая строка означает, что даты нет
+func (s *potsService) parseTargetDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target date: %w", err)
+	}
+	if date.Before(previousBusinessDate(time.Now(), s.location).AddDate(0, 0, 1)) {
+		return nil, ErrPotTargetDatePast
+	}
+	return &date, nil
+}
+
+func (s *potsService) invalidate(ctx context.Context, accountID uint) {
+	if err := s.accRepo.Invalidate(ctx, accountID); err != nil {
+		lib.Log.Warn("Failed to invalidate account cache", zap.Uint("account_id", accountID), zap.Error(err))
+	}
+}
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"time"
+)
+
+var (
+	ErrErasureNotFound       = errors.New("erasure request not found")
+	ErrErasureAlreadyPending = errors.New("erasure request is already pending")
+	ErrErasureNotAllowed     = errors.New("erasure is not allowed while accounts hold funds or have active holds, cards or loans")
+)
+
+type PrivacyService interface {
+	Export(ctx context.Context, userID uint) (*entities.PersonalDataExport, error)
+	RequestErasure(ctx context.Context, userID uint) (*entities.ErasureRequest, error)
+	GetErasureRequests(ctx context.Context, status entities.ErasureStatus) ([]*entities.ErasureRequest, error)
+	ProcessErasure(ctx context.Context, staffID, requestID uint, input *entities.ProcessErasureRequest) (*entities.ErasureRequest, error)
+}
+
+type privacyService struct {
+	usersRepo    repository.UsersRepository
+	kycRepo      repository.KYCRepository
+	accountsRepo repository.AccountsRepository
+	membersRepo  repository.AccountMembersRepository
+	txRepo       repository.TransactionsRepository
+	sessionsRepo repository.SessionsRepository
+	apiKeysRepo  repository.APIKeysRepository
+	erasureRepo  repository.ErasureRequestsRepository
+	notifyRepo   repository.NotificationsRepository
+	payeesRepo   repository.PayeesRepository
+	holdsRepo    repository.HoldsRepository
+	cardsRepo    repository.CardsRepository
+	loansRepo    repository.LoansRepository
+	audit        AuditService
+}
+
+func NewPrivacyService(
+	usersRepo repository.UsersRepository,
+	kycRepo repository.KYCRepository,
+	accountsRepo repository.AccountsRepository,
+	membersRepo repository.AccountMembersRepository,
+	txRepo repository.TransactionsRepository,
+	sessionsRepo repository.SessionsRepository,
+	apiKeysRepo repository.APIKeysRepository,
+	erasureRepo repository.ErasureRequestsRepository,
+	notifyRepo repository.NotificationsRepository,
+	payeesRepo repository.PayeesRepository,
+	holdsRepo repository.HoldsRepository,
+	cardsRepo repository.CardsRepository,
+	loansRepo repository.LoansRepository,
+	audit AuditService,
+) PrivacyService {
+	return &privacyService{
+		usersRepo:    usersRepo,
+		kycRepo:      kycRepo,
+		accountsRepo: accountsRepo,
+		membersRepo:  membersRepo,
+		txRepo:       txRepo,
+		sessionsRepo: sessionsRepo,
+		apiKeysRepo:  apiKeysRepo,
+		erasureRepo:  erasureRepo,
+		notifyRepo:   notifyRepo,
+		payeesRepo:   payeesRepo,
+		holdsRepo:    holdsRepo,
+		cardsRepo:    cardsRepo,
+		loansRepo:    loansRepo,
+		audit:        audit,
+	}
+}
+
+// Export собирает все данные, хранящиеся о пользователе, в один архив
+func (s *privacyService) Export(ctx context.Context, userID uint) (*entities.PersonalDataExport, error) {
+	user, err := s.usersRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %v", err)
+	}
+
+	export := &entities.PersonalDataExport{
+		GeneratedAt: time.Now(),
+		Profile:     user.ToResponse(),
+	}
+
+	profile, err := s.kycRepo.FindByUserID(ctx, userID)
+	if err == nil {
+		export.KYC = profile.ToDTO()
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get KYC profile: %w", err)
+	}
+
+	accounts, err := s.accountsRepo.GetAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	export.Accounts = entities.AccountsToResponse(accounts)
+
+	accountIDs := make([]uint, 0, len(accounts))
+	for _, account := range accounts {
+		accountIDs = append(accountIDs, account.ID)
+	}
+
+	export.Transactions, err = s.txRepo.FindAllByUser(ctx, userID, accountIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	sessions, err := s.sessionsRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	export.Sessions = entities.SessionsToResponse(sessions, "")
+
+	keys, err := s.apiKeysRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	export.APIKeys = entities.APIKeysToResponse(keys)
+
+	export.Notifications, err = s.notifyRepo.FindAllByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	export.NotificationPreferences, err = s.notifyRepo.FindPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	export.Payees, err = s.payeesRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payees: %w", err)
+	}
+
+	cards, err := s.cardsRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+	export.Cards = make([]*entities.CardResponse, 0, len(cards))
+	for _, card := range cards {
+		export.Cards = append(export.Cards, card.ToResponse())
+	}
+
+	export.Loans, err = s.loansRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loans: %w", err)
+	}
+
+	return export, nil
+}
+
+// RequestErasure регистрирует запрос на удаление персональных данных.
+// Запрос исполняет сотрудник, так как часть данных подлежит обязательному хранению.
+func (s *privacyService) RequestErasure(ctx context.Context, userID uint) (*entities.ErasureRequest, error) {
+	if _, err := s.erasureRepo.FindOpenByUser(ctx, userID); err == nil {
+		return nil, ErrErasureAlreadyPending
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get erasure request: %w", err)
+	}
+
+	if err := s.checkNoFunds(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	req := &entities.ErasureRequest{
+		UserID: userID,
+		Status: entities.ErasureStatusRequested,
+	}
+
+	if err := s.erasureRepo.Create(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to create erasure request: %w", err)
+	}
+
+	return req, nil
+}
+
+func (s *privacyService) GetErasureRequests(ctx context.Context, status entities.ErasureStatus) ([]*entities.ErasureRequest, error) {
+	reqs, err := s.erasureRepo.FindAll(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get erasure requests: %w", err)
+	}
+	return reqs, nil
+}
+
+// ProcessErasure исполняет или отклоняет запрос на удаление
+func (s *privacyService) ProcessErasure(ctx context.Context, staffID, requestID uint, input *entities.ProcessErasureRequest) (req *entities.ErasureRequest, err error) {
+	var before entities.ErasureRequest
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditErasure,
+			ResourceType: "erasure_request",
+			ResourceID:   requestID,
+			After:        input,
+			Err:          err,
+		}
+		if err == nil {
+			event.Before = before
+			event.After = req
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	req, err = s.erasureRepo.FindByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrErasureNotFound
+		}
+		return nil, fmt.Errorf("failed to get erasure request: %w", err)
+	}
+	before = *req
+
+	if req.Status != entities.ErasureStatusRequested {
+		return nil, fmt.Errorf("erasure request is already %s", req.Status)
+	}
+
+	now := time.Now()
+	req.ProcessedBy = &staffID
+	req.ProcessedAt = &now
+	req.Reason = input.Reason
+
+	if !input.Approve {
+		req.Status = entities.ErasureStatusRejected
+	} else {
+		if err := s.erase(ctx, req.UserID, now); err != nil {
+			return nil, err
+		}
+		req.Status = entities.ErasureStatusCompleted
+	}
+
+	if err := s.erasureRepo.Update(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to update erasure request: %w", err)
+	}
+
+	return req, nil
+}
+
+// erase псевдонимизирует персональные данные пользователя. Счета и транзакции
+// сохраняются, так как их хранение обязательно по закону.
+func (s *privacyService) erase(ctx context.Context, userID uint, at time.Time) error {
+	if err := s.checkNoFunds(ctx, userID); err != nil {
+		return err
+	}
+
+	user, err := s.usersRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %v", err)
+	}
+
+	accounts, err := s.accountsRepo.GetOwned(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+	for _, account := range accounts {
+		if account.Status == entities.AccountClosed {
+			continue
+		}
+		if err := transitionAccount(account, entities.AccountClosed, "Удаление персональных данных"); err != nil {
+			return err
+		}
+		if err := s.accountsRepo.Update(ctx, account); err != nil {
+			return fmt.Errorf("failed to close account: %w", err)
+		}
+	}
+
+	// Карты пользователя на чужих совместных счетах закрываются вместе с членством в них
+	cards, err := s.cardsRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+	for _, card := range cards {
+		if card.Status == entities.CardCancelled {
+			continue
+		}
+		card.Status = entities.CardCancelled
+		if err := s.cardsRepo.Update(ctx, card); err != nil {
+			return fmt.Errorf("failed to cancel card: %w", err)
+		}
+	}
+
+	if err := s.membersRepo.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to leave joint accounts: %w", err)
+	}
+
+	if err := s.sessionsRepo.DeleteAllByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	if err := s.apiKeysRepo.RevokeAllByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke API keys: %w", err)
+	}
+
+	profile, err := s.kycRepo.FindByUserID(ctx, userID)
+	if err == nil {
+		profile.Pseudonymize()
+		if err := s.kycRepo.Save(ctx, profile); err != nil {
+			return fmt.Errorf("failed to erase KYC profile: %w", err)
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get KYC profile: %w", err)
+	}
+
+	user.Pseudonymize(at)
+	if err := s.usersRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to erase user: %w", err)
+	}
+
+	// UpdatePassword также сбрасывает user:<id> в Redis и на всех инстансах
+	if err := s.usersRepo.UpdatePassword(ctx, userID, ""); err != nil {
+		return fmt.Errorf("failed to erase credentials: %w", err)
+	}
+
+	lib.Log.Info("User personal data erased", zap.Uint("user_id", userID))
+	return nil
+}
+
+// checkNoFunds проверяет, что собственные счета можно закрыть, и что у пользователя нет
+// кредитов на рассмотрении или непогашенных. Совместные счета других владельцев при
+// удалении данных не закрываются, из них пользователь просто исключается.
+func (s *privacyService) checkNoFunds(ctx context.Context, userID uint) error {
+	accounts, err := s.accountsRepo.GetOwned(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	for _, account := range accounts {
+		if account.Status == entities.AccountClosed {
+			continue
+		}
+		// Замороженный счёт нельзя закрыть, пока его не разморозят
+		if account.Status == entities.AccountFrozen {
+			return ErrErasureNotAllowed
+		}
+		if err := checkAccountClosable(ctx, account, s.holdsRepo, s.cardsRepo, s.loansRepo); err != nil {
+			if errors.Is(err, ErrAccountNotClosable) {
+				return fmt.Errorf("%w: account %d: %v", ErrErasureNotAllowed, account.ID, err)
+			}
+			return err
+		}
+	}
+
+	loans, err := s.loansRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get loans: %w", err)
+	}
+	for _, loan := range loans {
+		if loan.Status == entities.LoanPending || loan.Status == entities.LoanActive {
+			return ErrErasureNotAllowed
+		}
+	}
+	return nil
+}
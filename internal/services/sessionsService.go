@@ -0,0 +1,67 @@
+package services
+
+import (
+	"bank-app-backend/internal/entities"
+	"bank-app-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type SessionsService interface {
+	List(ctx context.Context, userID uint) ([]*entities.Session, error)
+	Revoke(ctx context.Context, userID uint, sessionID string) error
+	RevokeAll(ctx context.Context, userID uint) error
+	Exists(ctx context.Context, sessionID string) (bool, error)
+}
+
+type sessionsService struct {
+	repo repository.SessionsRepository
+}
+
+func NewSessionsService(r repository.SessionsRepository) SessionsService {
+	return &sessionsService{repo: r}
+}
+
+// List возвращает активные сессии пользователя, начиная с последней использованной
+func (s *sessionsService) List(ctx context.Context, userID uint) ([]*entities.Session, error) {
+	sessions, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list sessions: %v", err)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	return sessions, nil
+}
+
+func (s *sessionsService) Revoke(ctx context.Context, userID uint, sessionID string) error {
+	session, err := s.repo.Get(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	if err := s.repo.Delete(ctx, userID, sessionID); err != nil {
+		return fmt.Errorf("could not revoke session: %v", err)
+	}
+
+	return nil
+}
+
+func (s *sessionsService) RevokeAll(ctx context.Context, userID uint) error {
+	if err := s.repo.DeleteAllByUser(ctx, userID); err != nil {
+		return fmt.Errorf("could not revoke sessions: %v", err)
+	}
+
+	return nil
+}
+
+// Exists проверяет, что сессия не отозвана и не истекла
+func (s *sessionsService) Exists(ctx context.Context, sessionID string) (bool, error) {
+	return s.repo.Exists(ctx, sessionID)
+}
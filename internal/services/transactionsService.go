@@ -4,25 +4,73 @@ import (
 	"bank-app-backend/internal/entities"
 	"bank-app-backend/internal/repository"
 	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
 )
 
 type TransactionsService interface {
 	GetTransactions(ctx context.Context, filter *entities.TransactionFilter) ([]entities.Transaction, error)
-	GetTransactionByID(ctx context.Context, id uint) (*entities.Transaction, error)
+	GetTransactionByID(ctx context.Context, userID, id uint) (*entities.Transaction, error)
 }
 
+var ErrTransactionNotFound = errors.New("transaction not found")
+
 type transactionsService struct {
-	txRepo repository.TransactionsRepository
+	txRepo  repository.TransactionsRepository
+	accRepo repository.AccountsRepository
 }
 
-func NewTransactionService(txRepo repository.TransactionsRepository) TransactionsService {
-	return &transactionsService{txRepo: txRepo}
+func NewTransactionService(txRepo repository.TransactionsRepository, accRepo repository.AccountsRepository) TransactionsService {
+	return &transactionsService{
+		txRepo:  txRepo,
+		accRepo: accRepo,
+	}
 }
 
+// GetTransactions возвращает транзакции пользователя и операции по всем счетам,
+// участником которых он является, включая совместные
 func (s *transactionsService) GetTransactions(ctx context.Context, filter *entities.TransactionFilter) ([]entities.Transaction, error) {
+	accounts, err := s.accRepo.GetAll(ctx, filter.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	filter.AccountIDs = make([]uint, 0, len(accounts))
+	for _, account := range accounts {
+		filter.AccountIDs = append(filter.AccountIDs, account.ID)
+	}
+
 	return s.txRepo.FindAll(ctx, filter)
 }
 
-func (s *transactionsService) GetTransactionByID(ctx context.Context, id uint) (*entities.Transaction, error) {
-	return s.txRepo.FindByID(ctx, id)
+// GetTransactionByID возвращает транзакцию, если её создал пользователь
+// или она затрагивает счёт, участником которого он является
+func (s *transactionsService) GetTransactionByID(ctx context.Context, userID, id uint) (*entities.Transaction, error) {
+	tx, err := s.txRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if tx.UserID == userID {
+		return tx, nil
+	}
+
+	for _, accountID := range []uint{tx.FromAccountID, tx.ToAccountID} {
+		if accountID == 0 {
+			continue
+		}
+		_, err := s.accRepo.GetByID(ctx, userID, accountID)
+		if err == nil {
+			return tx, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to check account access: %w", err)
+		}
+	}
+
+	return nil, ErrTransactionNotFound
 }
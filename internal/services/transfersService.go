@@ -6,56 +6,175 @@ import (
 	lib "bank-app-backend/internal/lib/logger"
 	"bank-app-backend/internal/repository"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
 type TransfersService interface {
 	ProcessTransfer(ctx context.Context, req entities.TransferRequest) (*entities.Transaction, error)
+	Reviews(ctx context.Context, status entities.TransferReviewStatus) ([]*entities.TransferReview, error)
+	ApproveReview(ctx context.Context, staffID, reviewID uint, req *entities.ReviewTransferRequest) (*entities.TransferReview, error)
+	RejectReview(ctx context.Context, staffID, reviewID uint, req *entities.ReviewTransferRequest) (*entities.TransferReview, error)
+}
+
+var (
+	ErrTransferBlocked        = errors.New("transfer blocked by fraud screening")
+	ErrTransferHeld           = errors.New("transfer held for review")
+	ErrTransferReviewNotFound = errors.New("transfer review not found")
+	ErrInvalidTransferAmount  = errors.New("transfer amount must be positive")
+	ErrTransferReviewClaimed  = errors.New("transfer review is already being processed")
+	ErrOwnTransferReview      = errors.New("staff cannot approve a review of their own transfer")
+)
+
+// TransferHeldError означает, что перевод не выполнен и ждёт решения сотрудника
+type TransferHeldError struct {
+	Review *entities.TransferReview
+}
+
+func (e *TransferHeldError) Error() string {
+	return fmt.Sprintf("%s #%d: %s", ErrTransferHeld, e.Review.ID, strings.Join(e.Review.Reasons, ", "))
+}
+
+func (e *TransferHeldError) Is(target error) bool {
+	return target == ErrTransferHeld
 }
 
 type transfersService struct {
-	txRepo   repository.TransactionsRepository
-	accRepo  repository.AccountsRepository
-	producer *kafka.Producer
+	txRepo     repository.TransactionsRepository
+	accRepo    repository.AccountsRepository
+	members    AccountMembersService
+	limits     LimitsService
+	fees       FeesService
+	pots       PotsService
+	currencies CurrenciesService
+	fraud      FraudService
+	reviews    repository.TransferReviewsRepository
+	kyc        KYCService
+	payees     PayeesService
+	producer   *kafka.Producer
+	overdrawn  *kafka.Producer
+	audit      AuditService
 }
 
-func NewTransfersService(txRepo repository.TransactionsRepository, accRepo repository.AccountsRepository, prod *kafka.Producer) TransfersService {
+func NewTransfersService(
+	txRepo repository.TransactionsRepository,
+	accRepo repository.AccountsRepository,
+	members AccountMembersService,
+	limits LimitsService,
+	fees FeesService,
+	pots PotsService,
+	currencies CurrenciesService,
+	fraud FraudService,
+	reviews repository.TransferReviewsRepository,
+	kyc KYCService,
+	payees PayeesService,
+	prod *kafka.Producer,
+	overdrawn *kafka.Producer,
+	audit AuditService,
+) TransfersService {
 	return &transfersService{
-		txRepo:   txRepo,
-		accRepo:  accRepo,
-		producer: prod,
+		txRepo:     txRepo,
+		accRepo:    accRepo,
+		members:    members,
+		limits:     limits,
+		fees:       fees,
+		pots:       pots,
+		currencies: currencies,
+		fraud:      fraud,
+		reviews:    reviews,
+		kyc:        kyc,
+		payees:     payees,
+		producer:   prod,
+		overdrawn:  overdrawn,
+		audit:      audit,
 	}
 }
 
 func (s *transfersService) ProcessTransfer(ctx context.Context, req entities.TransferRequest) (*entities.Transaction, error) {
-	fromAccount, err := s.accRepo.GetByID(ctx, req.UserID, req.FromAccountID)
+	return s.process(ctx, req, nil)
+}
+
+// process выполняет перевод. Если передана заявка review, перевод уже одобрил сотрудник:
+// проверка на мошенничество не повторяется, а решение по заявке сохраняется вместе с проводкой.
+func (s *transfersService) process(ctx context.Context, req entities.TransferRequest, review *entities.TransferReview) (tx *entities.Transaction, err error) {
+	defer func() {
+		event := entities.AuditEvent{
+			Action:       entities.AuditTransfer,
+			ResourceType: "transaction",
+			After:        req,
+			Err:          err,
+		}
+		if err == nil {
+			event.ResourceID = tx.ID
+			event.After = tx
+		}
+		s.audit.Record(ctx, event)
+	}()
+
+	if req.Amount <= 0 {
+		return nil, ErrInvalidTransferAmount
+	}
+
+	if err := s.kyc.CheckTransfer(ctx, req.UserID, req.Amount, req.Type); err != nil {
+		return nil, err
+	}
+
+	if err := s.payees.CheckTransfer(ctx, req.UserID, &req); err != nil {
+		return nil, err
+	}
+
+	fromAccount, member, err := s.members.Authorize(ctx, req.UserID, req.FromAccountID, entities.PermissionSpend)
 	if err != nil {
 		return nil, err
 	}
 
-	if fromAccount.Balance < req.Amount {
-		return nil, errors.New("insufficient funds")
+	if err := checkSpendLimit(member, req.Amount); err != nil {
+		return nil, err
+	}
+
+	if err := checkDebit(fromAccount); err != nil {
+		return nil, err
 	}
 
-	toAccount, err := s.accRepo.GetByID(ctx, req.UserID, req.ToAccountID)
+	if err := s.currencies.CheckAmount(fromAccount.Currency, req.Amount); err != nil {
+		return nil, err
+	}
+
+	quote, err := s.fees.Quote(ctx, fromAccount, req.Type, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	toAccount, err := s.destinationAccount(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Printf("REQ: %+v\n", req)
-	fromAccount.Balance -= req.Amount
-	toAccount.Balance += req.Amount
 
-	if err := s.accRepo.Update(ctx, fromAccount); err != nil {
+	if err := checkCredit(toAccount); err != nil {
 		return nil, err
 	}
-	if err := s.accRepo.Update(ctx, toAccount); err != nil {
+
+	if toAccount.Currency != fromAccount.Currency {
+		return nil, errors.New("currency mismatch between accounts")
+	}
+
+	if review == nil {
+		if err := s.screen(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	limits, err := s.limits.Charge(ctx, req.UserID, fromAccount, req.Amount)
+	if err != nil {
 		return nil, err
 	}
 
-	tx := &entities.Transaction{
+	tx = &entities.Transaction{
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
 		UserID:        req.UserID,
@@ -65,20 +184,214 @@ func (s *transfersService) ProcessTransfer(ctx context.Context, req entities.Tra
 		CreatedAt:     time.Now(),
 	}
 
-	if err := s.sendKafkaEvent(tx); err != nil {
-		lib.Log.Error("Failed to send Kafka event", zap.Error(err))
+	var balanceBefore float64
+	fromAccount, toAccount, err = s.accRepo.Post(ctx, &repository.Posting{
+		Transaction: tx,
+		Debit:       quote.Total,
+		Limits:      limits,
+		Fee:         s.fees.Charge(fromAccount, quote),
+		Review:      review,
+		Check: func(from, to *entities.Account) error {
+			if err := checkDebit(from); err != nil {
+				return err
+			}
+			// Холды и копилки читаются под той же блокировкой, что и списание
+			if from.Available() < quote.Total {
+				return ErrInsufficientFunds
+			}
+			if err := checkCredit(to); err != nil {
+				return err
+			}
+			balanceBefore = from.Balance
+			return nil
+		},
+	})
+	if err != nil {
+		if errors.Is(err, ErrAccountInactive) || errors.Is(err, ErrInsufficientFunds) ||
+			errors.Is(err, ErrLimitExceeded) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to post transfer: %w", err)
+	}
+
+	publishTransactionCompleted(s.producer, tx, toAccount.UserID)
+
+	publishOverdrawn(s.overdrawn, fromAccount, balanceBefore)
+
+	// Перевод между своими счетами — не трата, поэтому округляются только внешние переводы
+	if req.Type == entities.ExternalTransfer {
+		s.pots.RoundUp(ctx, fromAccount, req.Amount)
+	}
+
+	return tx, nil
+}
+
+func (s *transfersService) Reviews(ctx context.Context, status entities.TransferReviewStatus) ([]*entities.TransferReview, error) {
+	reviews, err := s.reviews.FindByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+// ApproveReview выполняет задержанный перевод со всеми проверками, кроме антифрода.
+// Сотрудник сначала захватывает заявку (pending → approving), поэтому два одобрения
+// не проведут перевод дважды; решение сохраняется в одной транзакции с проводкой.
+// Если перевод не удался, например из-за нехватки средств, заявка остаётся на проверке.
+func (s *transfersService) ApproveReview(ctx context.Context, staffID, reviewID uint, req *entities.ReviewTransferRequest) (review *entities.TransferReview, err error) {
+	defer func() {
+		s.recordReview(ctx, reviewID, entities.TransferReviewApproved, review, err)
+	}()
+
+	review, err = s.pendingReview(ctx, reviewID)
+	if err != nil {
 		return nil, err
 	}
+	if review.UserID == staffID {
+		return nil, ErrOwnTransferReview
+	}
+
+	claimed, err := s.reviews.SetStatus(ctx, reviewID, entities.TransferReviewPending, entities.TransferReviewApproving)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim transfer review: %w", err)
+	}
+	if !claimed {
+		return nil, ErrTransferReviewClaimed
+	}
+
+	decideReview(review, staffID, entities.TransferReviewApproved, req.Note)
+	if _, err := s.process(ctx, review.Request(), review); err != nil {
+		s.unclaimReview(ctx, reviewID)
+		return nil, fmt.Errorf("failed to execute held transfer: %w", err)
+	}
+	return review, nil
+}
+
+// unclaimReview возвращает заявку на проверку после неудачного перевода
+func (s *transfersService) unclaimReview(ctx context.Context, reviewID uint) {
+	if _, err := s.reviews.SetStatus(context.WithoutCancel(ctx), reviewID, entities.TransferReviewApproving, entities.TransferReviewPending); err != nil {
+		lib.Log.Error("Failed to return transfer review to pending", zap.Uint("review_id", reviewID), zap.Error(err))
+	}
+}
+
+func (s *transfersService) RejectReview(ctx context.Context, staffID, reviewID uint, req *entities.ReviewTransferRequest) (review *entities.TransferReview, err error) {
+	defer func() {
+		s.recordReview(ctx, reviewID, entities.TransferReviewRejected, review, err)
+	}()
+
+	if strings.TrimSpace(req.Note) == "" {
+		return nil, errors.New("note is required when rejecting a transfer")
+	}
 
-	if err := s.txRepo.Create(ctx, tx); err != nil {
+	review, err = s.pendingReview(ctx, reviewID)
+	if err != nil {
 		return nil, err
 	}
 
-	return tx, nil
+	decideReview(review, staffID, entities.TransferReviewRejected, req.Note)
+	if err := s.reviews.Close(ctx, review, entities.TransferReviewPending); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransferReviewClaimed
+		}
+		return nil, fmt.Errorf("failed to update transfer review: %w", err)
+	}
+	return review, nil
 }
 
-// sendKafkaEvent отправляет событие в Kafka
-func (s *transfersService) sendKafkaEvent(tx *entities.Transaction) error {
-	txEvent := fmt.Sprintf("Transaction of %f completed for user %d", tx.Amount, tx.UserID)
-	return s.producer.SendEvent([]byte(fmt.Sprintf("%d", tx.ID)), []byte(txEvent))
+// screen блокирует перевод или ставит его в очередь ручной проверки по решению антифрода
+func (s *transfersService) screen(ctx context.Context, req entities.TransferRequest) error {
+	result, err := s.fraud.Screen(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	switch result.Decision {
+	case entities.FraudBlock:
+		return fmt.Errorf("%w: %s", ErrTransferBlocked, strings.Join(result.Reasons, ", "))
+	case entities.FraudHold:
+		review := &entities.TransferReview{
+			UserID:        req.UserID,
+			FromAccountID: req.FromAccountID,
+			ToAccountID:   req.ToAccountID,
+			Amount:        req.Amount,
+			Description:   req.Description,
+			Type:          req.Type,
+			Reasons:       result.Reasons,
+			Status:        entities.TransferReviewPending,
+		}
+		if err := s.reviews.Create(ctx, review); err != nil {
+			return fmt.Errorf("failed to hold transfer: %w", err)
+		}
+		return &TransferHeldError{Review: review}
+	}
+
+	return nil
+}
+
+func (s *transfersService) pendingReview(ctx context.Context, reviewID uint) (*entities.TransferReview, error) {
+	review, err := s.reviews.FindByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransferReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get transfer review: %w", err)
+	}
+	if review.Status != entities.TransferReviewPending {
+		return nil, fmt.Errorf("transfer review is already %s", review.Status)
+	}
+	return review, nil
+}
+
+func decideReview(review *entities.TransferReview, staffID uint, status entities.TransferReviewStatus, note string) {
+	now := time.Now()
+	review.Status = status
+	review.ReviewerID = &staffID
+	review.Note = strings.TrimSpace(note)
+	review.ReviewedAt = &now
+}
+
+func (s *transfersService) recordReview(ctx context.Context, reviewID uint, status entities.TransferReviewStatus, review *entities.TransferReview, err error) {
+	event := entities.AuditEvent{
+		Action:       entities.AuditTransferReview,
+		ResourceType: "transfer_review",
+		ResourceID:   reviewID,
+		After:        map[string]interface{}{"status": status},
+		Err:          err,
+	}
+	if err == nil {
+		event.Before = map[string]interface{}{"status": entities.TransferReviewPending}
+		event.After = review
+	}
+	s.audit.Record(ctx, event)
+}
+
+// destinationAccount возвращает счёт зачисления: при внутреннем переводе — счёт,
+// участником которого является пользователь, при внешнем — счёт любого клиента банка
+func (s *transfersService) destinationAccount(ctx context.Context, req entities.TransferRequest) (*entities.Account, error) {
+	if req.Type == entities.ExternalTransfer {
+		return s.accRepo.FindByID(ctx, req.ToAccountID)
+	}
+
+	return s.accRepo.GetByID(ctx, req.UserID, req.ToAccountID)
+}
+
+// publishTransactionCompleted сообщает об операции её автору и владельцу счёта зачисления
+// recipientID. Операция к этому моменту уже проведена, поэтому ошибка отправки только логируется.
+func publishTransactionCompleted(producer *kafka.Producer, tx *entities.Transaction, recipientID uint) {
+	value, err := json.Marshal(entities.TransactionCompletedEvent{
+		TransactionID: tx.ID,
+		UserID:        tx.UserID,
+		RecipientID:   recipientID,
+		FromAccountID: tx.FromAccountID,
+		ToAccountID:   tx.ToAccountID,
+		Amount:        tx.Amount,
+		Type:          tx.Type,
+		Description:   tx.Description,
+	})
+	if err == nil {
+		err = producer.SendEvent([]byte(fmt.Sprint(tx.ID)), value)
+	}
+	if err != nil {
+		lib.Log.Error("Failed to send transaction completed event", zap.Uint("transaction_id", tx.ID), zap.Error(err))
+	}
 }
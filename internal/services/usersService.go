@@ -2,23 +2,55 @@ package services
 
 import (
 	"bank-app-backend/internal/entities"
+	lib "bank-app-backend/internal/lib/logger"
 	"bank-app-backend/internal/repository"
 	"context"
+	"errors"
 	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"time"
 )
 
 type UsersService interface {
 	Me(ctx context.Context, userID uint) (*entities.User, error)
-	GetAll(ctx context.Context) ([]*entities.User, error)
+	Search(ctx context.Context, filter *entities.UserFilter) (*entities.UsersPage, error)
+	Details(ctx context.Context, userID uint) (*entities.UserDetailsResponse, error)
 	Update(ctx context.Context, userID uint, input *entities.UpdateUserRequest) (*entities.User, error)
+	UpdateRole(ctx context.Context, userID uint, role entities.Role) (*entities.User, error)
+	EnsureAdmins(ctx context.Context, emails []string) error
+	Block(ctx context.Context, adminID, userID uint, reason string) (*entities.User, error)
+	Unblock(ctx context.Context, userID uint) (*entities.User, error)
 }
 
+var (
+	ErrUserBlocked     = errors.New("user is blocked")
+	ErrCannotBlockSelf = errors.New("cannot block yourself")
+	ErrLastAdmin       = errors.New("cannot remove the role of or block the last admin")
+)
+
 type usersService struct {
-	repo repository.UsersRepository
+	repo         repository.UsersRepository
+	accountsRepo repository.AccountsRepository
+	kycRepo      repository.KYCRepository
+	sessionsRepo repository.SessionsRepository
+	audit        AuditService
 }
 
-func NewUsersService(r repository.UsersRepository) UsersService {
-	return &usersService{repo: r}
+func NewUsersService(
+	r repository.UsersRepository,
+	accountsRepo repository.AccountsRepository,
+	kycRepo repository.KYCRepository,
+	sessionsRepo repository.SessionsRepository,
+	audit AuditService,
+) UsersService {
+	return &usersService{
+		repo:         r,
+		accountsRepo: accountsRepo,
+		kycRepo:      kycRepo,
+		sessionsRepo: sessionsRepo,
+		audit:        audit,
+	}
 }
 
 func (s *usersService) Me(ctx context.Context, userID uint) (*entities.User, error) {
@@ -29,31 +61,234 @@ func (s *usersService) Me(ctx context.Context, userID uint) (*entities.User, err
 	return user, nil
 }
 
-func (s *usersService) GetAll(ctx context.Context) ([]*entities.User, error) {
-	users, err := s.repo.FindAll(ctx)
+func (s *usersService) Search(ctx context.Context, filter *entities.UserFilter) (*entities.UsersPage, error) {
+	users, total, err := s.repo.Search(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("users not found: %v", err)
+		return nil, fmt.Errorf("failed to search users: %v", err)
 	}
 
-	return users, nil
+	return &entities.UsersPage{
+		Items: entities.UsersToResponse(users),
+		Total: total,
+		Page:  filter.Page,
+		Limit: filter.Limit,
+	}, nil
 }
 
-func (s *usersService) Update(ctx context.Context, userID uint, input *entities.UpdateUserRequest) (*entities.User, error) {
+// Details возвращает пользователя вместе со статусами и счетами
+func (s *usersService) Details(ctx context.Context, userID uint) (*entities.UserDetailsResponse, error) {
 	user, err := s.repo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %v", err)
 	}
 
-	if input.Email != nil {
-		user.Email = *input.Email
+	accounts, err := s.accountsRepo.GetAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %v", err)
 	}
-	if input.Username != nil {
-		user.Username = *input.Username
+
+	kycStatus := entities.KYCStatusNotSubmitted
+	profile, err := s.kycRepo.FindByUserID(ctx, userID)
+	if err == nil {
+		kycStatus = profile.Status
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get KYC profile: %v", err)
 	}
 
-	if err := s.repo.Update(ctx, user); err != nil {
+	return &entities.UserDetailsResponse{
+		User:        user.ToResponse(),
+		BlockedAt:   user.BlockedAt,
+		BlockReason: user.BlockReason,
+		ErasedAt:    user.ErasedAt,
+		KYCStatus:   kycStatus,
+		Accounts:    entities.AccountsToResponse(accounts),
+	}, nil
+}
+
+func (s *usersService) Update(ctx context.Context, userID uint, input *entities.UpdateUserRequest) (user *entities.User, err error) {
+	var before map[string]interface{}
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditUserUpdate,
+			ResourceType: "user",
+			ResourceID:   userID,
+			Before:       before,
+			After:        userAuditSnapshot(user),
+			Err:          err,
+		})
+	}()
+
+	user, err = s.repo.Change(ctx, userID, []string{"email", "username"}, func(current *entities.User, _ int64) error {
+		before = userAuditSnapshot(current)
+		if input.Email != nil {
+			current.Email = *input.Email
+		}
+		if input.Username != nil {
+			current.Username = *input.Username
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found: %v", err)
+		}
 		return nil, fmt.Errorf("failed to update user: %v", err)
 	}
 
 	return user, nil
 }
+
+func (s *usersService) UpdateRole(ctx context.Context, userID uint, role entities.Role) (user *entities.User, err error) {
+	var before map[string]interface{}
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditUserRoleUpdate,
+			ResourceType: "user",
+			ResourceID:   userID,
+			Before:       before,
+			After:        userAuditSnapshot(user),
+			Err:          err,
+		})
+	}()
+
+	var previous entities.Role
+	user, err = s.repo.ChangeRole(ctx, userID, role, func(current *entities.User, admins int64) error {
+		before = userAuditSnapshot(current)
+		previous = current.Role
+		if current.Role == entities.RoleAdmin && !current.IsBlocked() && role != entities.RoleAdmin && admins <= 1 {
+			return ErrLastAdmin
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found: %v", err)
+		}
+		if errors.Is(err, ErrLastAdmin) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update role: %v", err)
+	}
+
+	// Роль записана в токенах, поэтому после смены пользователь входит заново
+	if previous != role {
+		if err := s.sessionsRepo.DeleteAllByUser(ctx, userID); err != nil {
+			return nil, fmt.Errorf("failed to revoke sessions: %v", err)
+		}
+	}
+
+	return user, nil
+}
+
+// EnsureAdmins назначает роль администратора пользователям из конфигурации
+func (s *usersService) EnsureAdmins(ctx context.Context, emails []string) error {
+	if err := s.repo.PromoteByEmails(ctx, emails, entities.RoleAdmin); err != nil {
+		return fmt.Errorf("failed to assign admin role: %v", err)
+	}
+	return nil
+}
+
+// Block блокирует пользователя и завершает все его сессии. Последнего незаблокированного
+// администратора заблокировать нельзя, как и снять с него роль.
+func (s *usersService) Block(ctx context.Context, adminID, userID uint, reason string) (user *entities.User, err error) {
+	var before map[string]interface{}
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditUserBlock,
+			ResourceType: "user",
+			ResourceID:   userID,
+			Before:       before,
+			After:        userAuditSnapshot(user),
+			Err:          err,
+		})
+	}()
+
+	if adminID == userID {
+		return nil, ErrCannotBlockSelf
+	}
+
+	alreadyBlocked := false
+	user, err = s.repo.Change(ctx, userID, []string{"blocked_at", "block_reason"}, func(current *entities.User, admins int64) error {
+		before = userAuditSnapshot(current)
+		if current.IsBlocked() {
+			alreadyBlocked = true
+			return nil
+		}
+		if current.Role == entities.RoleAdmin && admins <= 1 {
+			return ErrLastAdmin
+		}
+
+		now := time.Now()
+		current.BlockedAt = &now
+		current.BlockReason = reason
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found: %v", err)
+		}
+		if errors.Is(err, ErrLastAdmin) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to block user: %v", err)
+	}
+	if alreadyBlocked {
+		return user, nil
+	}
+
+	if err := s.sessionsRepo.DeleteAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %v", err)
+	}
+
+	lib.Log.Warn("User blocked",
+		zap.Uint("user_id", userID),
+		zap.Uint("admin_id", adminID),
+		zap.String("reason", reason),
+	)
+
+	return user, nil
+}
+
+func (s *usersService) Unblock(ctx context.Context, userID uint) (user *entities.User, err error) {
+	var before map[string]interface{}
+	defer func() {
+		s.audit.Record(ctx, entities.AuditEvent{
+			Action:       entities.AuditUserUnblock,
+			ResourceType: "user",
+			ResourceID:   userID,
+			Before:       before,
+			After:        userAuditSnapshot(user),
+			Err:          err,
+		})
+	}()
+
+	user, err = s.repo.Change(ctx, userID, []string{"blocked_at", "block_reason"}, func(current *entities.User, _ int64) error {
+		before = userAuditSnapshot(current)
+		current.BlockedAt = nil
+		current.BlockReason = ""
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found: %v", err)
+		}
+		return nil, fmt.Errorf("failed to unblock user: %v", err)
+	}
+
+	lib.Log.Info("User unblocked", zap.Uint("user_id", userID))
+	return user, nil
+}
+
+// userAuditSnapshot — поля пользователя, изменения которых попадают в журнал аудита
+func userAuditSnapshot(user *entities.User) map[string]interface{} {
+	if user == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"email":        user.Email,
+		"username":     user.Username,
+		"role":         user.Role,
+		"blocked_at":   user.BlockedAt,
+		"block_reason": user.BlockReason,
+	}
+}